@@ -0,0 +1,333 @@
+package ddl
+
+import (
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+// ToDDL returns the script to create the table with its columns, primary key, foreign keys,
+// check constraints, indexes and comments. Every statement is terminated by a semicolon.
+//
+// Tables of other database systems are translated by their generic data types.
+// Foreign keys are defined within the table, so the referenced tables have to be created first
+func (t *Table) ToDDL(d Dialect) (string, error) {
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+	if t.Kind.IsView() {
+		return "", fmt.Errorf("failed to create table %q: views are not supported", t.Name)
+	}
+
+	return strings.Join(d.CreateTable(t), ";\n\n") + ";\n", nil
+}
+
+// CreateTable returns the statements without a trailing semicolon to create the table.
+// Oracle requires separate statements for comments and indexes
+func (d Dialect) CreateTable(t *Table) []string {
+	t = d.TargetTable(t)
+
+	definitions := []string{}
+	for _, c := range t.Columns {
+		definitions = append(definitions, d.ColumnDefinition(c))
+	}
+	if t.PrimaryKey != nil {
+		definitions = append(definitions, d.PrimaryKey(t.PrimaryKey))
+	}
+	for _, check := range t.Checks {
+		// The expressions contain identifiers and functions of the source dialect
+		if source := tableDialect(t); source != "" && source != d {
+			logger.Warning("Skipping check constraint %s of %s.%s: expression of %s can't be translated", check.Name, t.Schema, t.Name, source)
+			continue
+		}
+
+		constraint := ""
+		if check.Name != "" {
+			constraint = "CONSTRAINT " + d.Quote(d.ObjectName(t, check.Name)) + " "
+		}
+		definitions = append(definitions, fmt.Sprintf("%sCHECK (%s)", constraint, check.Expression))
+	}
+	if d == DialectMariadb {
+		for _, idx := range t.Indexes {
+			if isCreatableIndex(t, idx) {
+				definitions = append(definitions, mariadbIndex(idx))
+			}
+		}
+	}
+	for _, fk := range t.ForeignKeys {
+		definitions = append(definitions, d.ForeignKey(fk))
+	}
+	create := fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", d.QuoteTable(t.Schema, t.Name), strings.Join(definitions, ",\n\t"))
+
+	if d == DialectOracle {
+		rtc := []string{create}
+		if t.Comment != "" {
+			rtc = append(rtc, fmt.Sprintf("COMMENT ON TABLE %s IS %s", d.QuoteTable(t.Schema, t.Name), d.QuoteString(t.Comment)))
+		}
+		for _, c := range t.Columns {
+			if c.Comment != "" {
+				rtc = append(rtc, d.ColumnComment(t, c))
+			}
+		}
+		for _, idx := range t.Indexes {
+			if isCreatableIndex(t, idx) {
+				rtc = append(rtc, d.CreateIndex(t, idx))
+			}
+		}
+		return rtc
+	}
+
+	// The storage options are only known for tables of MariaDB
+	if tableDialect(t) == DialectMariadb {
+		if t.Engine != "" {
+			create += " ENGINE=" + t.Engine
+		}
+		if t.Charset != "" {
+			create += " DEFAULT CHARSET=" + t.Charset
+		}
+		if t.Collation != "" {
+			create += " COLLATE=" + t.Collation
+		}
+	}
+	if t.Comment != "" {
+		create += " COMMENT=" + d.QuoteString(t.Comment)
+	}
+	return []string{create}
+}
+
+// ColumnComment returns an oracle statement to set the comment of the column
+func (d Dialect) ColumnComment(t *Table, c *Column) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", d.QuoteTable(t.Schema, t.Name), d.Quote(c.Name), d.QuoteString(c.Comment))
+}
+
+// PrimaryKey returns the definition of the primary key constraint
+func (d Dialect) PrimaryKey(pk *PrimaryKey) string {
+	rtc := fmt.Sprintf("PRIMARY KEY (%s)", d.QuoteColumns(pk.Columns))
+
+	// MariaDB always names the primary key "PRIMARY". Oracle requires unique names within the schema
+	if d == DialectOracle && pk.Name != "" && pk.Name != "PRIMARY" {
+		rtc = "CONSTRAINT " + d.Quote(pk.Name) + " " + rtc
+	}
+	return rtc
+}
+
+// ForeignKey returns the definition of the foreign key constraint like
+// "CONSTRAINT `fk` FOREIGN KEY (`a`) REFERENCES `s`.`t` (`id`) ON DELETE CASCADE"
+func (d Dialect) ForeignKey(fk *ForeignKey) string {
+	constraint := ""
+	if fk.Name != "" {
+		constraint = "CONSTRAINT " + d.Quote(fk.Name) + " "
+	}
+	rtc := fmt.Sprintf(
+		"%sFOREIGN KEY (%s) REFERENCES %s (%s)",
+		constraint, d.QuoteColumns(fk.Columns),
+		d.QuoteTable(fk.ReferencedSchema, fk.ReferencedTable), d.QuoteColumns(fk.ReferencedColumns),
+	)
+
+	// Oracle only supports the actions "CASCADE" and "SET NULL" on deletes
+	if d == DialectOracle {
+		if fk.OnDelete == "CASCADE" || fk.OnDelete == "SET NULL" {
+			rtc += " ON DELETE " + fk.OnDelete
+		}
+		return rtc
+	}
+
+	if fk.OnDelete != "" {
+		rtc += " ON DELETE " + fk.OnDelete
+	}
+	if fk.OnUpdate != "" {
+		rtc += " ON UPDATE " + fk.OnUpdate
+	}
+	return rtc
+}
+
+// CreateIndex returns the statement to create the index of the table
+func (d Dialect) CreateIndex(t *Table, idx *Index) string {
+	if d.convertsIdentifiers(t) {
+		idx = d.targetIndex(idx)
+	}
+	t = d.TargetTable(t)
+
+	kind := ""
+	switch {
+	case idx.Unique:
+		kind = "UNIQUE "
+	case d == DialectMariadb && (idx.Type == "FULLTEXT" || idx.Type == "SPATIAL"):
+		kind = idx.Type + " "
+	case d == DialectOracle && idx.Type == "BITMAP":
+		kind = idx.Type + " "
+	}
+
+	name := d.Quote(idx.Name)
+	if d == DialectOracle {
+		name = d.QuoteTable(t.Schema, d.ObjectName(t, idx.Name))
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)", kind, name, d.QuoteTable(t.Schema, t.Name), d.QuoteColumns(idx.Columns))
+}
+
+// ObjectName returns the name of an index or check constraint of the table.
+// Oracle requires unique names within the schema, while other database systems
+// only require them within the table. These names are prefixed with the name of the table
+// and are shortened to the maximum lenght of Oracle identifiers
+func (d Dialect) ObjectName(t *Table, name string) string {
+	if d != DialectOracle {
+		return name
+	}
+	if d.convertsIdentifiers(t) {
+		name = strings.ToUpper(t.Name + "_" + name)
+	}
+
+	return shortOracleName(name)
+}
+
+// maxOracleIdentifier is the maximum lenght of Oracle identifiers in bytes since Oracle 12.2
+const maxOracleIdentifier = 128
+
+// shortOracleName shortens names that exceed the maximum lenght of Oracle identifiers.
+// A hash of the whole name is appended to keep shortened names unique
+func shortOracleName(name string) string {
+	if len(name) <= maxOracleIdentifier {
+		return name
+	}
+
+	hash := fmt.Sprintf("%08X", crc32.ChecksumIEEE([]byte(name)))
+	end := maxOracleIdentifier - len(hash) - 1
+	for end > 0 && !utf8.RuneStart(name[end]) {
+		end--
+	}
+	return name[:end] + "_" + hash
+}
+
+// TargetTable returns the table with the identifiers that are used within the dialect.
+// Oracle stores unquoted identifiers in upper case, so the identifiers of tables of other
+// database systems are converted to upper case. Otherwise the table is returned unchanged
+func (d Dialect) TargetTable(t *Table) *Table {
+	if !d.convertsIdentifiers(t) {
+		return t
+	}
+
+	rtc := *t
+	rtc.Schema, rtc.Name = strings.ToUpper(t.Schema), strings.ToUpper(t.Name)
+	rtc.Columns = make([]*Column, len(t.Columns))
+	for i, c := range t.Columns {
+		column := *c
+		column.Name = strings.ToUpper(c.Name)
+		rtc.Columns[i] = &column
+	}
+	if t.PrimaryKey != nil {
+		rtc.PrimaryKey = &PrimaryKey{Name: strings.ToUpper(t.PrimaryKey.Name), Columns: upperIdentifiers(t.PrimaryKey.Columns)}
+	}
+	rtc.Indexes = make([]*Index, len(t.Indexes))
+	for i, idx := range t.Indexes {
+		rtc.Indexes[i] = d.targetIndex(idx)
+	}
+	rtc.ForeignKeys = make([]*ForeignKey, len(t.ForeignKeys))
+	for i, fk := range t.ForeignKeys {
+		foreignKey := *fk
+		foreignKey.Name = strings.ToUpper(fk.Name)
+		foreignKey.Columns = upperIdentifiers(fk.Columns)
+		foreignKey.ReferencedSchema = strings.ToUpper(fk.ReferencedSchema)
+		foreignKey.ReferencedTable = strings.ToUpper(fk.ReferencedTable)
+		foreignKey.ReferencedColumns = upperIdentifiers(fk.ReferencedColumns)
+		rtc.ForeignKeys[i] = &foreignKey
+	}
+	rtc.Checks = make([]*CheckConstraint, len(t.Checks))
+	for i, check := range t.Checks {
+		rtc.Checks[i] = &CheckConstraint{Name: strings.ToUpper(check.Name), Expression: check.Expression, Columns: upperIdentifiers(check.Columns)}
+	}
+
+	return &rtc
+}
+
+// convertsIdentifiers returns weather the identifiers of the table have to be converted
+// to upper case for the dialect. See "TargetTable"
+func (d Dialect) convertsIdentifiers(t *Table) bool {
+	return d == DialectOracle && tableDialect(t) != DialectOracle
+}
+
+// targetIndex returns a copy of the index with upper case identifiers. Expressions
+// of function based indexes are not changed
+func (d Dialect) targetIndex(idx *Index) *Index {
+	rtc := *idx
+	rtc.Name = strings.ToUpper(idx.Name)
+	rtc.Columns = upperIdentifiers(idx.Columns)
+	return &rtc
+}
+
+// upperIdentifiers returns the names in upper case. Expressions are not changed
+func upperIdentifiers(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	rtc := make([]string, len(names))
+	for i, name := range names {
+		rtc[i] = name
+		if plainColumn.MatchString(name) {
+			rtc[i] = strings.ToUpper(name)
+		}
+	}
+	return rtc
+}
+
+// plainColumn matches column names that are no expressions of function based indexes
+var plainColumn = regexp.MustCompile(`^[\w$#]+$`)
+
+// QuoteColumns returns the quoted and comma separated columns. Expressions
+// of function based indexes are not quoted
+func (d Dialect) QuoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		if plainColumn.MatchString(c) {
+			quoted[i] = d.Quote(c)
+		} else {
+			quoted[i] = c
+		}
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// mariadbIndex returns the definition of the index within a MariaDB "CREATE TABLE" statement
+func mariadbIndex(idx *Index) string {
+	kind := "KEY"
+	switch {
+	case idx.Unique:
+		kind = "UNIQUE KEY"
+	case idx.Type == "FULLTEXT" || idx.Type == "SPATIAL":
+		kind = idx.Type + " KEY"
+	}
+
+	return fmt.Sprintf("%s %s (%s)", kind, DialectMariadb.Quote(idx.Name), DialectMariadb.QuoteColumns(idx.Columns))
+}
+
+// isCreatableIndex returns weather the index has to be created. The index of the primary
+// key is created by the constraint and indexes with unknown expressions can't be created
+func isCreatableIndex(t *Table, idx *Index) bool {
+	if t.PrimaryKey != nil && idx.Name == t.PrimaryKey.Name {
+		return false
+	}
+	if slices.Contains(idx.Columns, "") {
+		logger.Warning("Skipping index %s of %s.%s: expression of a key part is unknown", idx.Name, t.Schema, t.Name)
+		return false
+	}
+
+	return true
+}
+
+// tableDialect returns the dialect of the database system the columns of the table
+// were read from or an empty string for other database systems
+func tableDialect(t *Table) Dialect {
+	for _, c := range t.Columns {
+		if d := columnDialect(c); d != "" {
+			return d
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,228 @@
+package ddl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// getCreateTables returns the MariaDB tables used to create the DDL
+func getCreateTables(t *testing.T) []*Table {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE parent (id INT(10) AUTO_INCREMENT PRIMARY KEY);
+		CREATE TABLE child (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			parent_id INT(10) NOT NULL,
+			name      VARCHAR(20) NOT NULL DEFAULT 'It''s me' COMMENT 'Name',
+			state     ENUM('on', 'off') DEFAULT 'on',
+			price     DECIMAL(10,2) DEFAULT 0,
+			created   DATETIME DEFAULT current_timestamp(),
+			UNIQUE INDEX idx_name (name),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id) ON DELETE CASCADE,
+			CONSTRAINT chk_price CHECK (price >= 0)
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	tables, err := s.GetTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	return tables
+}
+
+// toDDL returns the concatenated DDL of all tables
+func toDDL(t *testing.T, tables []*Table, d Dialect) string {
+	rtc := ""
+	for _, tbl := range tables {
+		ddl, err := tbl.ToDDL(d)
+		if err != nil {
+			t.Fatalf("Failed to get DDL of %q: %s", tbl.Name, err)
+		}
+		rtc += ddl
+	}
+
+	return rtc
+}
+
+func TestToDDLMariadb(t *testing.T) {
+	tables := getCreateTables(t)
+	script := toDDL(t, tables, DialectMariadb)
+
+	expected := "CREATE TABLE `ddl`.`child` (\n" +
+		"\t`id` int(10) NOT NULL AUTO_INCREMENT,\n" +
+		"\t`parent_id` int(10) NOT NULL,\n" +
+		"\t`name` varchar(20) NOT NULL DEFAULT 'It''s me' COMMENT 'Name',\n" +
+		"\t`state` enum('on','off') DEFAULT 'on',\n" +
+		"\t`price` decimal(10,2) DEFAULT 0,\n" +
+		"\t`created` datetime DEFAULT current_timestamp(),\n" +
+		"\tPRIMARY KEY (`id`),\n" +
+		"\tCONSTRAINT `chk_price` CHECK (price >= 0),\n" +
+		"\tKEY `fk_parent` (`parent_id`),\n" +
+		"\tUNIQUE KEY `idx_name` (`name`),\n" +
+		"\tCONSTRAINT `fk_parent` FOREIGN KEY (`parent_id`) REFERENCES `ddl`.`parent` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT\n" +
+		");\n" +
+		"CREATE TABLE `ddl`.`parent` (\n" +
+		"\t`id` int(10) NOT NULL AUTO_INCREMENT,\n" +
+		"\tPRIMARY KEY (`id`)\n" +
+		");\n"
+	if diff := cmp.Diff(expected, script); diff != "" {
+		t.Errorf("Mismatch of DDL (-want +got):\n%s", diff)
+	}
+
+	// Parsing the DDL again results in the same tables
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(script); err != nil {
+		t.Fatalf("Failed to parse DDL: %s", err)
+	}
+	parsed, _ := s.GetTables("ddl")
+	if diff := cmp.Diff(tables, parsed); diff != "" {
+		t.Errorf("Mismatch of parsed tables (-want +got):\n%s", diff)
+	}
+}
+
+func TestToDDLOracle(t *testing.T) {
+	s := NewOracleScript("DDL")
+	if err := s.Parse(`
+		CREATE TABLE items (
+			id      NUMBER(10) GENERATED ALWAYS AS IDENTITY,
+			user_id NUMBER(10) NOT NULL,
+			name    VARCHAR2(20) DEFAULT 'Tim' NOT NULL,
+			CONSTRAINT pk_items PRIMARY KEY (id),
+			CONSTRAINT fk_items_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+		COMMENT ON COLUMN items.name IS 'Name';
+		CREATE INDEX idx_items_user ON items (user_id);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+	tables, _ := s.GetTables("DDL")
+	tables[0].Comment = "It's an item"
+
+	expected := "CREATE TABLE \"DDL\".\"ITEMS\" (\n" +
+		"\t\"ID\" NUMBER(10) GENERATED ALWAYS AS IDENTITY (START WITH 1 INCREMENT BY 1) NOT NULL,\n" +
+		"\t\"USER_ID\" NUMBER(10) NOT NULL,\n" +
+		"\t\"NAME\" VARCHAR2(20) DEFAULT 'Tim' NOT NULL,\n" +
+		"\tCONSTRAINT \"PK_ITEMS\" PRIMARY KEY (\"ID\"),\n" +
+		"\tCONSTRAINT \"FK_ITEMS_USER\" FOREIGN KEY (\"USER_ID\") REFERENCES \"DDL\".\"USERS\" (\"ID\") ON DELETE CASCADE\n" +
+		");\n\n" +
+		"COMMENT ON TABLE \"DDL\".\"ITEMS\" IS 'It''s an item';\n\n" +
+		"COMMENT ON COLUMN \"DDL\".\"ITEMS\".\"NAME\" IS 'Name';\n\n" +
+		"CREATE INDEX \"DDL\".\"IDX_ITEMS_USER\" ON \"DDL\".\"ITEMS\" (\"USER_ID\");\n"
+	if diff := cmp.Diff(expected, toDDL(t, tables, DialectOracle)); diff != "" {
+		t.Errorf("Mismatch of DDL (-want +got):\n%s", diff)
+	}
+}
+
+func TestToDDLCrossDialect(t *testing.T) {
+	tables := getCreateTables(t)
+
+	// The data types are translated by their generic data types and the
+	// identifiers are converted to upper case
+	expected := "CREATE TABLE \"DDL\".\"PARENT\" (\n" +
+		"\t\"ID\" NUMBER(10) GENERATED BY DEFAULT AS IDENTITY NOT NULL,\n" +
+		"\tPRIMARY KEY (\"ID\")\n" +
+		");\n"
+	if diff := cmp.Diff(expected, toDDL(t, tables[1:], DialectOracle)); diff != "" {
+		t.Errorf("Mismatch of DDL (-want +got):\n%s", diff)
+	}
+
+	script := toDDL(t, tables[:1], DialectOracle)
+	for _, part := range []string{
+		"\t\"NAME\" VARCHAR2(20 CHAR) DEFAULT 'It''s me' NOT NULL,\n",
+		"\t\"STATE\" VARCHAR2(3 CHAR) DEFAULT 'on',\n",
+		"\t\"PRICE\" NUMBER(10,2) DEFAULT 0,\n",
+		"\t\"CREATED\" DATE DEFAULT SYSTIMESTAMP,\n",
+		"CONSTRAINT \"FK_PARENT\" FOREIGN KEY (\"PARENT_ID\") REFERENCES \"DDL\".\"PARENT\" (\"ID\") ON DELETE CASCADE\n",
+		"COMMENT ON COLUMN \"DDL\".\"CHILD\".\"NAME\" IS 'Name';\n",
+		"CREATE UNIQUE INDEX \"DDL\".\"CHILD_IDX_NAME\" ON \"DDL\".\"CHILD\" (\"NAME\");\n",
+	} {
+		if !strings.Contains(script, part) {
+			t.Errorf("Expected %q within DDL:\n%s", part, script)
+		}
+	}
+
+	// Check constraints of other dialects are skipped
+	if strings.Contains(script, "CHECK") {
+		t.Errorf("Expected the check constraint of MariaDB to be skipped:\n%s", script)
+	}
+
+	// Views can't be created
+	if _, err := (&Table{Name: "v", Kind: TableKindView}).ToDDL(DialectMariadb); err == nil {
+		t.Errorf("Expected an error for a view")
+	}
+	if _, err := tables[0].ToDDL("sqlite"); err == nil {
+		t.Errorf("Expected an error for an unsupported dialect")
+	}
+}
+
+func TestToDDLOracleToMariadb(t *testing.T) {
+	s := NewOracleScript("DDL")
+	if err := s.Parse(`
+		CREATE TABLE items (
+			id      NUMBER(10) NOT NULL,
+			created DATE DEFAULT SYSDATE,
+			stamp   TIMESTAMP(6) DEFAULT SYSTIMESTAMP,
+			guid    RAW(16) DEFAULT SYS_GUID(),
+			CHECK (id > 0)
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+	tables, _ := s.GetTables("DDL")
+
+	// Expressions are translated or skipped if they are unknown
+	script := toDDL(t, tables, DialectMariadb)
+	for _, part := range []string{
+		"\t`CREATED` datetime DEFAULT current_timestamp(),\n",
+		"\t`STAMP` datetime(6) DEFAULT current_timestamp(),\n",
+	} {
+		if !strings.Contains(script, part) {
+			t.Errorf("Expected %q within DDL:\n%s", part, script)
+		}
+	}
+	if strings.Contains(script, "SYS_GUID") {
+		t.Errorf("Expected the unknown default value to be skipped:\n%s", script)
+	}
+
+	// Unnamed check constraints don't have a constraint name
+	script = toDDL(t, tables, DialectOracle)
+	if !strings.Contains(script, "\tCHECK (id > 0)\n") {
+		t.Errorf("Expected an unnamed check constraint within DDL:\n%s", script)
+	}
+}
+
+func TestObjectNameOracle(t *testing.T) {
+	tbl := &Table{Schema: "shop", Name: "orders"}
+	if name := DialectOracle.ObjectName(tbl, "idx_name"); name != "ORDERS_IDX_NAME" {
+		t.Errorf("Expected 'ORDERS_IDX_NAME'. Got %q", name)
+	}
+
+	// Names are shortened to the maximum lenght and stay unique
+	tbl.Name = strings.Repeat("t", 64)
+	first := DialectOracle.ObjectName(tbl, strings.Repeat("i", 64)+"_a")
+	second := DialectOracle.ObjectName(tbl, strings.Repeat("i", 64)+"_b")
+	if len(first) != maxOracleIdentifier || len(second) != maxOracleIdentifier || first == second {
+		t.Errorf("Expected two different names with %d bytes. Got %q and %q", maxOracleIdentifier, first, second)
+	}
+	if !strings.HasPrefix(first, strings.Repeat("T", 64)+"_") {
+		t.Errorf("Expected the name of the table as a prefix. Got %q", first)
+	}
+}
+
+func TestToDDLUnknownIndexExpression(t *testing.T) {
+	tbl := &Table{
+		Name:    "item",
+		Columns: []*Column{{Name: "name", Type: TextType, InternalType: "text", CanBeNull: true}},
+		Indexes: []*Index{{Name: "idx_lower", Columns: []string{""}}, {Name: "idx_name", Columns: []string{"name"}}},
+	}
+
+	// Functional indexes of MariaDB are read without their expression
+	expected := "CREATE TABLE `item` (\n\t`name` longtext,\n\tKEY `idx_name` (`name`)\n);\n"
+	if diff := cmp.Diff(expected, toDDL(t, []*Table{tbl}, DialectMariadb)); diff != "" {
+		t.Errorf("Mismatch of DDL (-want +got):\n%s", diff)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
 // Location is a database type that stores a geographic
@@ -65,3 +68,174 @@ func (g Location) Value() (driver.Value, error) {
 
 	return buf.Bytes(), nil
 }
+
+// Decimal is a database type that stores an exact fixed point number
+// without losing any precision like a float64 would do.
+// The zero value represents "0"
+type Decimal struct {
+
+	// Value without the decimal point. Nil represents zero
+	unscaled *big.Int
+
+	// Number of digits on the right side of the decimal point
+	scale int
+}
+
+// maxDecimalExponent is the largest absolute exponent of the scientific notation
+// that is accepted. PostgreSQL supports up to 16383 digits after the decimal point
+const maxDecimalExponent = 16384
+
+// NullDecimal represents a decimal that may be null
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// ParseDecimal parses a decimal from its string representation like
+// "-12.50" or "1.25E-3"
+func ParseDecimal(value string) (Decimal, error) {
+	str := strings.TrimSpace(value)
+
+	// Exponent of the scientific notation
+	exponent := 0
+	if i := strings.IndexAny(str, "eE"); i != -1 {
+		exp, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid exponent of decimal %q: %s", value, err)
+		}
+		if exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("exponent of decimal %q exceeds the maximum of %d", value, maxDecimalExponent)
+		}
+		exponent = exp
+		str = str[:i]
+	}
+
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := len(fracPart) - exponent
+	if scale < 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// String returns the decimal with all digits after the decimal point
+// like "12.50"
+func (d Decimal) String() string {
+	digits := "0"
+	negative := false
+	if d.unscaled != nil {
+		digits = new(big.Int).Abs(d.unscaled).String()
+		negative = d.unscaled.Sign() < 0
+	}
+
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Float64 returns the nearest float64 value of the decimal
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Scan handles the scanning of the custom decimal type.
+// The drivers return the value as a string, []byte or number
+func (d *Decimal) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case string:
+		*d, err = ParseDecimal(v)
+	case int64:
+		*d = Decimal{unscaled: big.NewInt(v)}
+	case float64:
+		*d, err = ParseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Decimal. Use NullDecimal instead")
+	default:
+		return fmt.Errorf("expected string or number for Decimal type, got %T", src)
+	}
+
+	return err
+}
+
+// Value returns the decimal as a string so no precision is lost
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON returns the decimal as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON parses a decimal from a JSON number or string.
+// Null results in the zero value
+func (d *Decimal) UnmarshalJSON(data []byte) (err error) {
+	if string(data) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	*d, err = ParseDecimal(strings.Trim(string(data), `"`))
+	return err
+}
+
+// Scan handles the scanning of a nullable decimal
+func (d *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		*d = NullDecimal{}
+		return nil
+	}
+
+	d.Valid = true
+	return d.Decimal.Scan(src)
+}
+
+// Value returns the decimal as a string or nil if it's not valid
+func (d NullDecimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.Value()
+}
+
+// MarshalJSON returns the decimal as a JSON number or null
+func (d NullDecimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return d.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON parses a decimal from a JSON number, string or null
+func (d *NullDecimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = NullDecimal{}
+		return nil
+	}
+
+	d.Valid = true
+	return d.Decimal.UnmarshalJSON(data)
+}
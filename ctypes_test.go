@@ -0,0 +1,106 @@
+package ddl
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for input, expected := range map[string]string{
+		"0":                              "0",
+		"12.50":                          "12.50",
+		"-0.05":                          "-0.05",
+		"+3":                             "3",
+		".5":                             "0.5",
+		"1.25E-3":                        "0.00125",
+		"1.5e2":                          "150",
+		"12345678901234567890.123456789": "12345678901234567890.123456789",
+	} {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %s", input, err)
+			continue
+		}
+		if got := d.String(); got != expected {
+			t.Errorf("Expected %q for %q. Got %q", expected, input, got)
+		}
+	}
+
+	for _, input := range []string{"", "-", "1.2.3", "abc", "1e", "1e999999999", "1e-999999999"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	for _, tt := range []struct {
+		src      any
+		expected string
+	}{
+		{[]byte("99.99"), "99.99"},
+		{"-1.10", "-1.10"},
+		{int64(42), "42"},
+		{float64(0.25), "0.25"},
+	} {
+		var d Decimal
+		if err := d.Scan(tt.src); err != nil {
+			t.Errorf("Failed to scan %v: %s", tt.src, err)
+		} else if d.String() != tt.expected {
+			t.Errorf("Expected %q for %v. Got %q", tt.expected, tt.src, d.String())
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan(nil); err == nil {
+		t.Errorf("Expected an error when scanning NULL into a Decimal")
+	}
+
+	var n NullDecimal
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected an invalid NullDecimal. Got %+v (%v)", n, err)
+	}
+	if val, _ := n.Value(); val != nil {
+		t.Errorf("Expected nil value for an invalid NullDecimal. Got %v", val)
+	}
+}
+
+func TestDecimalJson(t *testing.T) {
+	type invoice struct {
+		Total    Decimal     `json:"total"`
+		Discount NullDecimal `json:"discount"`
+	}
+
+	data, err := json.Marshal(invoice{Total: mustParseDecimal(t, "1234.50")})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %s", err)
+	}
+	if expected := `{"total":1234.50,"discount":null}`; string(data) != expected {
+		t.Errorf("Expected %s. Got %s", expected, data)
+	}
+
+	var got invoice
+	if err := json.Unmarshal([]byte(`{"total":"0.10","discount":5.5}`), &got); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+	if got.Total.String() != "0.10" || !got.Discount.Valid || got.Discount.Decimal.String() != "5.5" {
+		t.Errorf("Unexpected result of unmarshal: %s, %+v", got.Total, got.Discount)
+	}
+
+	// Null is the zero value or an invalid decimal
+	got = invoice{Discount: NullDecimal{Valid: true}}
+	if err := json.Unmarshal([]byte(`{"total":null,"discount":null}`), &got); err != nil {
+		t.Fatalf("Failed to unmarshal null: %s", err)
+	}
+	if got.Total.String() != "0" || got.Discount.Valid {
+		t.Errorf("Unexpected result of unmarshal null: %s, %+v", got.Total, got.Discount)
+	}
+}
+
+func mustParseDecimal(t *testing.T, value string) Decimal {
+	d, err := ParseDecimal(value)
+	if err != nil {
+		t.Fatalf("Failed to parse decimal %q: %s", value, err)
+	}
+	return d
+}
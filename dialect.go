@@ -0,0 +1,303 @@
+package ddl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+// Dialect is the SQL dialect used to write statements for a database system
+type Dialect string
+
+const (
+	DialectMariadb Dialect = "mariadb"
+	DialectOracle  Dialect = "oracle"
+)
+
+// Validate returns an error if the dialect is not supported
+func (d Dialect) Validate() error {
+	if d != DialectMariadb && d != DialectOracle {
+		return fmt.Errorf("unsupported dialect %q", d)
+	}
+	return nil
+}
+
+// Quote quotes the identifier like "`name`" or `"NAME"`
+func (d Dialect) Quote(identifier string) string {
+	if d == DialectMariadb {
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// QuoteTable quotes the name of a table or index and qualifies it with the
+// schema if one is provided
+func (d Dialect) QuoteTable(schema, name string) string {
+	if schema == "" {
+		return d.Quote(name)
+	}
+	return d.Quote(schema) + "." + d.Quote(name)
+}
+
+// QuoteString returns the value as a string literal. Single quotes are escaped by doubling them
+func (d Dialect) QuoteString(value string) string {
+	if d == DialectMariadb {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ColumnType returns the data type of the column like "varchar(100)" or "NUMBER(10)".
+// See "TranslateType" for columns of other database systems
+func (d Dialect) ColumnType(c *Column) string {
+	return d.TranslateType(c).To
+}
+
+// ColumnDefinition returns the definition of the column used within a "CREATE TABLE"
+// statement like "`name` varchar(100) NOT NULL DEFAULT 'Tim'".
+// Comments of oracle columns are not part of the definition
+func (d Dialect) ColumnDefinition(c *Column) string {
+	rtc := d.Quote(c.Name) + " " + d.ColumnType(c)
+
+	if d == DialectOracle {
+		if c.Invisible {
+			rtc += " INVISIBLE"
+		}
+		if c.IsGenerated() {
+			return rtc + " GENERATED ALWAYS AS (" + c.GenerationExpression + ") VIRTUAL"
+		}
+
+		if identity := d.identity(c); identity != "" {
+			rtc += " " + identity
+		} else if value, ok := d.defaultValue(c); ok {
+			rtc += " DEFAULT " + value
+		}
+		if !c.CanBeNull {
+			rtc += " NOT NULL"
+		}
+		return rtc
+	}
+
+	if c.IsGenerated() {
+		rtc += " AS (" + c.GenerationExpression + ")"
+		if c.GenerationStored {
+			rtc += " PERSISTENT"
+		} else {
+			rtc += " VIRTUAL"
+		}
+	} else {
+		if !c.CanBeNull {
+			rtc += " NOT NULL"
+		}
+		if c.IsAutoIncrement() {
+			rtc += " AUTO_INCREMENT"
+		} else if value, ok := d.defaultValue(c); ok {
+			rtc += " DEFAULT " + value
+		}
+	}
+	if c.Invisible {
+		rtc += " INVISIBLE"
+	}
+	if c.Comment != "" {
+		rtc += " COMMENT " + d.QuoteString(c.Comment)
+	}
+
+	return rtc
+}
+
+// identity returns the identity clause of an oracle column like
+// "GENERATED BY DEFAULT AS IDENTITY (START WITH 1 INCREMENT BY 1)"
+func (d Dialect) identity(c *Column) string {
+	generation := "BY DEFAULT"
+	var seq *Sequence
+	if extras, ok := c.Extras.(*OracleColumn); ok {
+		// Sequences assigned by a trigger are not part of the column
+		if extras.IdentityGeneration == "" {
+			return ""
+		}
+		generation, seq = extras.IdentityGeneration, extras.Sequence
+	} else if !c.IsAutoIncrement() {
+		return ""
+	}
+
+	rtc := "GENERATED " + generation + " AS IDENTITY"
+	if seq != nil && seq.Increment != 0 {
+		rtc += fmt.Sprintf(" (START WITH %d INCREMENT BY %d)", seq.Start, seq.Increment)
+	}
+	return rtc
+}
+
+// defaultExpression matches default values that are expressions instead of literals
+// like "current_timestamp()", "SYSDATE" or "seq.NEXTVAL"
+var defaultExpression = regexp.MustCompile(`(?i)^([a-z_][\w$.]*\(.*\)|[a-z_][\w$]*\.(NEXTVAL|CURRVAL)|NULL|TRUE|FALSE|CURRENT_TIMESTAMP|CURRENT_DATE|CURRENT_TIME|SYSDATE|SYSTIMESTAMP|LOCALTIMESTAMP|USER)$`)
+
+// defaultExpressions contains the expressions of default values by the dialect they
+// are translated to. The keys are upper case and without empty brackets or a precision
+var defaultExpressions = map[Dialect]map[string]string{
+	DialectMariadb: {
+		"SYSDATE": "current_timestamp()", "SYSTIMESTAMP": "current_timestamp()", "LOCALTIMESTAMP": "current_timestamp()",
+		"CURRENT_TIMESTAMP": "current_timestamp()", "CURRENT_DATE": "curdate()",
+		"NULL": "NULL", "TRUE": "TRUE", "FALSE": "FALSE",
+	},
+	DialectOracle: {
+		"CURRENT_TIMESTAMP": "SYSTIMESTAMP", "NOW": "SYSTIMESTAMP", "LOCALTIMESTAMP": "SYSTIMESTAMP", "SYSDATE": "SYSDATE",
+		"CURRENT_DATE": "TRUNC(SYSDATE)", "CURDATE": "TRUNC(SYSDATE)",
+		"NULL": "NULL", "TRUE": "1", "FALSE": "0",
+	},
+}
+
+// expressionPrecision matches empty brackets or the precision of an expression like "current_timestamp(6)"
+var expressionPrecision = regexp.MustCompile(`\(\s*\d*\s*\)$`)
+
+// DefaultValue returns the default value of the column as an SQL expression.
+// The quotes of string literals are removed by the database systems, so numbers
+// and known expressions are returned as is and all other values are quoted.
+// Expressions of other database systems that can't be translated are returned as "NULL"
+func (d Dialect) DefaultValue(c *Column) string {
+	if value, ok := d.defaultValue(c); ok {
+		return value
+	}
+	return "NULL"
+}
+
+// defaultValue returns the default value of the column and weather it has one
+// that can be used within this dialect
+func (d Dialect) defaultValue(c *Column) (string, bool) {
+	if !c.DefaultValue.Valid {
+		return "", false
+	}
+
+	value := c.DefaultValue.String
+	if defaultExpression.MatchString(value) {
+		translated, ok := d.translateExpression(c, value)
+		if !ok {
+			logger.Warning("Skipping default value %s of column %s: expression of %s can't be translated", value, c.Name, columnDialect(c))
+		}
+		return translated, ok
+	}
+
+	switch c.Type {
+	case IntType, DoubleType, DecimalType, BoolType, BitType, YearType:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value, true
+		}
+	}
+
+	return d.QuoteString(value), true
+}
+
+// translateExpression translates the expression of a default value into the dialect.
+// Expressions of columns from other database systems are kept as is
+func (d Dialect) translateExpression(c *Column, expression string) (string, bool) {
+	source := columnDialect(c)
+	if source == d {
+		return expression, true
+	}
+	if translated, ok := defaultExpressions[d][strings.ToUpper(expressionPrecision.ReplaceAllString(expression, ""))]; ok {
+		return translated, true
+	}
+
+	return expression, source == ""
+}
+
+// columnDialect returns the dialect of the database system the column was read from
+// or an empty string for other database systems
+func columnDialect(c *Column) Dialect {
+	switch c.Extras.(type) {
+	case *MariadbColumn:
+		return DialectMariadb
+	case *OracleColumn:
+		return DialectOracle
+	}
+
+	return ""
+}
+
+// IsAutoIncrement returns weather the database system generates the value of
+// the column by an auto increment, identity or sequence
+func (c *Column) IsAutoIncrement() bool {
+	switch extras := c.Extras.(type) {
+	case *MariadbColumn:
+		return extras.AutoIncrement
+	case *OracleColumn:
+		return extras.AutoIncrement
+	case *MssqlColumn:
+		return extras.AutoIncrement
+	case *PostgresColumn:
+		return extras.AutoIncrement
+	case *SqliteColumn:
+		return extras.AutoIncrement
+	}
+
+	return false
+}
+
+// dataTypeLenght returns the character lenght or numeric precision of the column
+// or zero if it's not known
+func (c *Column) dataTypeLenght() int {
+	if c.NumericPrecision != 0 {
+		return c.NumericPrecision
+	}
+
+	switch extras := c.Extras.(type) {
+	case *MariadbColumn:
+		return extras.DataTypeLenght
+	case *OracleColumn:
+		return extras.DataTypeLenght
+	case *MssqlColumn:
+		return extras.DataTypeLenght
+	case *PostgresColumn:
+		return extras.DataTypeLenght
+	case *SqliteColumn:
+		return extras.DataTypeLenght
+	}
+
+	return 0
+}
+
+// enumValues returns the allowed values of the column
+func (c *Column) enumValues() []string {
+	switch extras := c.Extras.(type) {
+	case *MariadbColumn:
+		return extras.EnumValues
+	case *OracleColumn:
+		return extras.EnumValues
+	}
+
+	return nil
+}
+
+// oracleColumnType returns the data type of an oracle column with its lenght
+func oracleColumnType(c *OracleColumn) string {
+	switch strings.ToUpper(c.InternalType) {
+	case "VARCHAR2", "VARCHAR", "CHAR":
+		if c.CharLenght != 0 {
+			return fmt.Sprintf("%s(%d CHAR)", c.InternalType, c.CharLenght)
+		}
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght)
+	case "RAW":
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght)
+	case "NVARCHAR2", "NCHAR":
+		// The lenght is stored in bytes with two bytes per character
+		if c.CharLenght != 0 {
+			return fmt.Sprintf("%s(%d)", c.InternalType, c.CharLenght)
+		}
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght/2)
+	case "FLOAT":
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght)
+	case "NUMBER":
+		switch c.Scale {
+		case 64:
+			return c.InternalType
+		case 0:
+			return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght)
+		default:
+			return fmt.Sprintf("%s(%d,%d)", c.InternalType, c.DataTypeLenght, c.Scale)
+		}
+	}
+
+	return c.InternalType
+}
@@ -0,0 +1,541 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RPJoshL/go-ddl-parser"
+	"github.com/RPJoshL/go-ddl-parser/structt"
+)
+
+// Severity describes the impact of a change on clients that were built for the
+// old tables like the generated structs
+type Severity string
+
+const (
+	// The change is compatible with existing clients
+	SeverityAdditive Severity = "ADDITIVE"
+	// Existing clients may fail to read or write the table
+	SeverityBreaking Severity = "BREAKING"
+)
+
+// Kind of a change
+type Kind string
+
+const (
+	TableAdded          Kind = "TABLE ADDED"
+	TableRemoved        Kind = "TABLE REMOVED"
+	TableRenamed        Kind = "TABLE RENAMED"
+	TableCommentChanged Kind = "TABLE COMMENT CHANGED"
+
+	ColumnAdded              Kind = "COLUMN ADDED"
+	ColumnRemoved            Kind = "COLUMN REMOVED"
+	ColumnRenamed            Kind = "COLUMN RENAMED"
+	ColumnTypeChanged        Kind = "COLUMN TYPE CHANGED"
+	ColumnNullabilityChanged Kind = "COLUMN NULLABILITY CHANGED"
+	ColumnDefaultChanged     Kind = "COLUMN DEFAULT CHANGED"
+	ColumnCommentChanged     Kind = "COLUMN COMMENT CHANGED"
+
+	PrimaryKeyChanged Kind = "PRIMARY KEY CHANGED"
+
+	IndexAdded   Kind = "INDEX ADDED"
+	IndexRemoved Kind = "INDEX REMOVED"
+	IndexChanged Kind = "INDEX CHANGED"
+
+	ForeignKeyAdded   Kind = "FOREIGN KEY ADDED"
+	ForeignKeyRemoved Kind = "FOREIGN KEY REMOVED"
+	ForeignKeyChanged Kind = "FOREIGN KEY CHANGED"
+)
+
+// Change is a single difference between the old and the new tables
+type Change struct {
+
+	// Kind of the change
+	Kind Kind
+
+	// Weather the change is breaking or additive
+	Severity Severity
+
+	// Schema and name of the table. For renamed tables the old name is used
+	Schema string
+	Table  string
+
+	// Name of the column, index or foreign key. It's empty for changes of a table.
+	// For renamed columns the old name is used
+	Name string
+
+	// Readable old and new value like the data type or the name of a renamed object.
+	// They are empty if the object was added or removed
+	Old string
+	New string
+}
+
+// String returns a readable description of the change like
+// `[BREAKING] ddl.user.age: COLUMN TYPE CHANGED "int(10)" -> "varchar(10)"`
+func (c *Change) String() string {
+	rtc := fmt.Sprintf("[%s] %s.%s", c.Severity, c.Schema, c.Table)
+	if c.Name != "" {
+		rtc += "." + c.Name
+	}
+	rtc += ": " + string(c.Kind)
+
+	if c.Old != "" || c.New != "" {
+		rtc += fmt.Sprintf(" %q -> %q", c.Old, c.New)
+	}
+	return rtc
+}
+
+// HasBreaking returns weather any of the changes is breaking
+func HasBreaking(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Severity == SeverityBreaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compare returns all changes from the old to the new tables. Tables are matched by
+// their schema and name. A removed and an added table with the same definition are
+// reported as renamed if the match is unique. Columns additionally have to keep their position.
+// The changes are sorted by the schema and name of the old table
+func Compare(oldTables, newTables []*ddl.Table) []*Change {
+	rtc := []*Change{}
+
+	// Match the tables by their name
+	newByName := map[string]*ddl.Table{}
+	for _, t := range newTables {
+		newByName[tableKey(t)] = t
+	}
+	oldByName := map[string]*ddl.Table{}
+	removed := []*ddl.Table{}
+	for _, old := range oldTables {
+		oldByName[tableKey(old)] = old
+		if newTable, ok := newByName[tableKey(old)]; ok {
+			rtc = append(rtc, compareTable(old, newTable)...)
+		} else {
+			removed = append(removed, old)
+		}
+	}
+	added := []*ddl.Table{}
+	for _, t := range newTables {
+		if _, ok := oldByName[tableKey(t)]; !ok {
+			added = append(added, t)
+		}
+	}
+
+	// Detect renamed tables with the same columns
+	renamed := matchRenamed(removed, added, tableSignature)
+	for _, old := range removed {
+		if newTable, ok := renamed[old]; ok {
+			rtc = append(rtc, newChange(TableRenamed, SeverityBreaking, old, "", old.Name, newTable.Name))
+			rtc = append(rtc, compareTable(old, newTable)...)
+		} else {
+			rtc = append(rtc, newChange(TableRemoved, SeverityBreaking, old, "", "", ""))
+		}
+	}
+	for _, t := range added {
+		if !isMatched(renamed, t) {
+			rtc = append(rtc, newChange(TableAdded, SeverityAdditive, t, "", "", ""))
+		}
+	}
+
+	sort.SliceStable(rtc, func(i, j int) bool {
+		if rtc[i].Schema != rtc[j].Schema {
+			return rtc[i].Schema < rtc[j].Schema
+		}
+		return rtc[i].Table < rtc[j].Table
+	})
+	return rtc
+}
+
+// compareTable returns all changes between two versions of a table
+func compareTable(old, new *ddl.Table) []*Change {
+	rtc := []*Change{}
+
+	if old.Comment != new.Comment {
+		rtc = append(rtc, newChange(TableCommentChanged, SeverityAdditive, old, "", old.Comment, new.Comment))
+	}
+
+	rtc = append(rtc, compareColumns(old, new)...)
+	rtc = append(rtc, comparePrimaryKeys(old, new)...)
+	rtc = append(rtc, compareIndexes(old, new)...)
+	rtc = append(rtc, compareForeignKeys(old, new)...)
+
+	return rtc
+}
+
+// compareColumns returns the added, removed, renamed and changed columns of a table
+func compareColumns(old, new *ddl.Table) []*Change {
+	rtc := []*Change{}
+
+	removed := []*ddl.Column{}
+	for _, c := range old.Columns {
+		if newColumn := new.GetColumn(c.Name); newColumn != nil {
+			rtc = append(rtc, compareColumn(old, c, newColumn)...)
+		} else {
+			removed = append(removed, c)
+		}
+	}
+	added := []*ddl.Column{}
+	for _, c := range new.Columns {
+		if old.GetColumn(c.Name) == nil {
+			added = append(added, c)
+		}
+	}
+
+	// Columns of the same type are common. Only columns at the same position are
+	// renamed, otherwise they are reported as removed and added
+	renamed := matchRenamed(removed, added, func(c *ddl.Column) string {
+		position := slices.Index(old.Columns, c)
+		if position == -1 {
+			position = slices.Index(new.Columns, c)
+		}
+		return fmt.Sprintf("%d %s", position, columnSignature(c))
+	})
+	for _, c := range removed {
+		if newColumn, ok := renamed[c]; ok {
+			rtc = append(rtc, newChange(ColumnRenamed, SeverityBreaking, old, c.Name, c.Name, newColumn.Name))
+			rtc = append(rtc, compareColumn(old, c, newColumn)...)
+		} else {
+			rtc = append(rtc, newChange(ColumnRemoved, SeverityBreaking, old, c.Name, "", ""))
+		}
+	}
+	for _, c := range added {
+		if isMatched(renamed, c) {
+			continue
+		}
+
+		// Inserts of existing clients fail for required columns without a value
+		severity := SeverityAdditive
+		if !c.CanBeNull && !c.DefaultValue.Valid && !c.IsGenerated() && !c.IsAutoIncrement() {
+			severity = SeverityBreaking
+		}
+		rtc = append(rtc, newChange(ColumnAdded, severity, old, c.Name, "", ""))
+	}
+
+	return rtc
+}
+
+// compareColumn returns the changes between two versions of a column
+func compareColumn(tbl *ddl.Table, old, new *ddl.Column) []*Change {
+	rtc := []*Change{}
+
+	// Existing clients can't read the column into the struct field if the generated
+	// Go type changed. Existing values may not fit anymore into a narrowed type
+	if oldType, newType := columnType(old), columnType(new); goType(old) != goType(new) || isNarrowed(oldType, newType) {
+		rtc = append(rtc, newChange(ColumnTypeChanged, SeverityBreaking, tbl, old.Name, oldType, newType))
+	} else if !strings.EqualFold(oldType, newType) {
+		rtc = append(rtc, newChange(ColumnTypeChanged, SeverityAdditive, tbl, old.Name, oldType, newType))
+	}
+
+	// NULL values can't be read into non nullable fields and vice versa can't
+	// be written anymore
+	if old.CanBeNull != new.CanBeNull {
+		rtc = append(rtc, newChange(ColumnNullabilityChanged, SeverityBreaking, tbl, old.Name, nullability(old), nullability(new)))
+	}
+
+	if old.DefaultValue != new.DefaultValue {
+		rtc = append(rtc, newChange(ColumnDefaultChanged, SeverityAdditive, tbl, old.Name, defaultValue(old), defaultValue(new)))
+	}
+
+	if old.Comment != new.Comment {
+		rtc = append(rtc, newChange(ColumnCommentChanged, SeverityAdditive, tbl, old.Name, old.Comment, new.Comment))
+	}
+
+	return rtc
+}
+
+// comparePrimaryKeys returns a change if the columns of the primary key changed
+func comparePrimaryKeys(old, new *ddl.Table) []*Change {
+	oldColumns, newColumns := "", ""
+	if old.PrimaryKey != nil {
+		oldColumns = strings.Join(old.PrimaryKey.Columns, ", ")
+	}
+	if new.PrimaryKey != nil {
+		newColumns = strings.Join(new.PrimaryKey.Columns, ", ")
+	}
+
+	if oldColumns == newColumns {
+		return nil
+	}
+	return []*Change{newChange(PrimaryKeyChanged, SeverityBreaking, old, "", oldColumns, newColumns)}
+}
+
+// compareIndexes returns the added, removed and changed indexes matched by their name.
+// The index of the primary key is ignored
+func compareIndexes(old, new *ddl.Table) []*Change {
+	rtc := []*Change{}
+
+	oldIndexes := indexesByName(old)
+	newIndexes := indexesByName(new)
+	for _, idx := range old.Indexes {
+		if _, ok := oldIndexes[idx.Name]; !ok {
+			continue
+		}
+
+		newIdx, ok := newIndexes[idx.Name]
+		if !ok {
+			rtc = append(rtc, newChange(IndexRemoved, SeverityAdditive, old, idx.Name, "", ""))
+		} else if oldDef, newDef := indexDefinition(idx), indexDefinition(newIdx); oldDef != newDef {
+			rtc = append(rtc, newChange(IndexChanged, SeverityAdditive, old, idx.Name, oldDef, newDef))
+		}
+	}
+	for _, idx := range new.Indexes {
+		if _, ok := newIndexes[idx.Name]; !ok {
+			continue
+		}
+
+		if _, ok := oldIndexes[idx.Name]; !ok {
+			rtc = append(rtc, newChange(IndexAdded, SeverityAdditive, old, idx.Name, "", ""))
+		}
+	}
+
+	return rtc
+}
+
+// compareForeignKeys returns the added, removed and changed foreign keys. They are
+// matched by their name or by their columns if they don't have a name.
+// The generated structs reference other structs by the foreign keys, so removing
+// or changing the referenced columns is breaking
+func compareForeignKeys(old, new *ddl.Table) []*Change {
+	rtc := []*Change{}
+
+	newKeys := map[string]*ddl.ForeignKey{}
+	for _, fk := range new.ForeignKeys {
+		newKeys[foreignKeyName(fk)] = fk
+	}
+	oldKeys := map[string]*ddl.ForeignKey{}
+	for _, fk := range old.ForeignKeys {
+		name := foreignKeyName(fk)
+		oldKeys[name] = fk
+
+		newFk, ok := newKeys[name]
+		switch {
+		case !ok:
+			rtc = append(rtc, newChange(ForeignKeyRemoved, SeverityBreaking, old, name, "", ""))
+		case foreignKeyReference(fk) != foreignKeyReference(newFk):
+			rtc = append(rtc, newChange(ForeignKeyChanged, SeverityBreaking, old, name, foreignKeyReference(fk), foreignKeyReference(newFk)))
+		case fk.OnDelete != newFk.OnDelete || fk.OnUpdate != newFk.OnUpdate:
+			rtc = append(rtc, newChange(ForeignKeyChanged, SeverityAdditive, old, name, foreignKeyActions(fk), foreignKeyActions(newFk)))
+		}
+	}
+	for _, fk := range new.ForeignKeys {
+		if _, ok := oldKeys[foreignKeyName(fk)]; !ok {
+			rtc = append(rtc, newChange(ForeignKeyAdded, SeverityAdditive, old, foreignKeyName(fk), "", ""))
+		}
+	}
+
+	return rtc
+}
+
+// matchRenamed returns the pairs of removed and added objects that have the same
+// signature. Only signatures that are unique on both sides are matched
+func matchRenamed[T comparable](removed, added []T, signature func(T) string) map[T]T {
+	addedBySignature := map[string][]T{}
+	for _, a := range added {
+		addedBySignature[signature(a)] = append(addedBySignature[signature(a)], a)
+	}
+	removedCount := map[string]int{}
+	for _, r := range removed {
+		removedCount[signature(r)]++
+	}
+
+	rtc := map[T]T{}
+	for _, r := range removed {
+		sig := signature(r)
+		if removedCount[sig] == 1 && len(addedBySignature[sig]) == 1 {
+			rtc[r] = addedBySignature[sig][0]
+		}
+	}
+
+	return rtc
+}
+
+// isMatched returns weather the added object was matched as a renamed object
+func isMatched[T comparable](renamed map[T]T, added T) bool {
+	for _, a := range renamed {
+		if a == added {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newChange creates a change of the table
+func newChange(kind Kind, severity Severity, tbl *ddl.Table, name, old, new string) *Change {
+	return &Change{
+		Kind:     kind,
+		Severity: severity,
+		Schema:   tbl.Schema,
+		Table:    tbl.Name,
+		Name:     name,
+		Old:      old,
+		New:      new,
+	}
+}
+
+// tableKey returns the unique identifier of a table
+func tableKey(t *ddl.Table) string {
+	return t.Schema + "." + t.Name
+}
+
+// tableSignature returns the schema and the definition of all columns
+func tableSignature(t *ddl.Table) string {
+	rtc := t.Schema
+	for _, c := range t.Columns {
+		rtc += "|" + c.Name + " " + columnSignature(c)
+	}
+
+	return rtc
+}
+
+// columnSignature returns the definition of a column without its name
+func columnSignature(c *ddl.Column) string {
+	return fmt.Sprintf("%s %s %s %s %s", c.Type, strings.ToLower(columnType(c)), nullability(c), defaultValue(c), c.GenerationExpression)
+}
+
+// columnType returns the internal type of the column with its lenght. The internal
+// type of oracle columns doesn't contain the lenght
+func columnType(c *ddl.Column) string {
+	if _, ok := c.Extras.(*ddl.OracleColumn); ok {
+		return ddl.DialectOracle.ColumnType(c)
+	}
+	return c.InternalType
+}
+
+// typeRanks contains the order of types within the same family that only
+// differ by their size
+var typeRanks = map[string]struct {
+	family string
+	rank   int
+}{
+	"tinyint": {"int", 1}, "smallint": {"int", 2}, "mediumint": {"int", 3}, "int": {"int", 4}, "integer": {"int", 4}, "bigint": {"int", 5},
+	"tinytext": {"text", 1}, "text": {"text", 2}, "mediumtext": {"text", 3}, "longtext": {"text", 4},
+	"tinyblob": {"blob", 1}, "blob": {"blob", 2}, "mediumblob": {"blob", 3}, "longblob": {"blob", 4},
+}
+
+// typeArguments matches the name of a data type with its lenght or precision and scale
+var typeArguments = regexp.MustCompile(`^([a-z0-9_ ]*?)\s*(?:\(\s*(\d+)(?:\s*,\s*(\d+))?[^)]*\))?(?:\s.*)?$`)
+
+// unsignedType matches the attribute "UNSIGNED" of a numeric type
+var unsignedType = regexp.MustCompile(`(?i)\bunsigned\b`)
+
+// isNarrowed returns weather the new data type can store fewer values than the old one
+// like a smaller lenght, a smaller precision or scale or a smaller integer type
+func isNarrowed(oldType, newType string) bool {
+	oldMatch := typeArguments.FindStringSubmatch(strings.ToLower(oldType))
+	newMatch := typeArguments.FindStringSubmatch(strings.ToLower(newType))
+	if oldMatch == nil || newMatch == nil {
+		return false
+	}
+
+	// Negative values can't be stored anymore
+	oldUnsigned, newUnsigned := unsignedType.MatchString(oldType), unsignedType.MatchString(newType)
+	if newUnsigned && !oldUnsigned {
+		return true
+	}
+
+	// The lenght of integers is only the display width. The upper half of an
+	// unsigned integer only fits into a bigger signed integer
+	oldRank, oldRanked := typeRanks[oldMatch[1]]
+	newRank, newRanked := typeRanks[newMatch[1]]
+	if oldRanked && newRanked && oldRank.family == newRank.family {
+		if oldUnsigned && !newUnsigned {
+			return newRank.rank <= oldRank.rank
+		}
+		return newRank.rank < oldRank.rank
+	}
+
+	if oldMatch[2] == "" || newMatch[2] == "" {
+		return false
+	}
+	oldLenght, _ := strconv.Atoi(oldMatch[2])
+	newLenght, _ := strconv.Atoi(newMatch[2])
+	oldScale, _ := strconv.Atoi(oldMatch[3])
+	newScale, _ := strconv.Atoi(newMatch[3])
+
+	// The digits on the left side of the dot must not decrease either
+	return newLenght < oldLenght || newScale < oldScale || newLenght-newScale < oldLenght-oldScale
+}
+
+// goType returns the Go type of the struct field that is generated for the column.
+// The nullability is compared separately and is ignored
+func goType(c *ddl.Column) string {
+	column := *c
+	column.CanBeNull = false
+	return structt.GoType(&column)
+}
+
+// nullability returns "NULL" or "NOT NULL"
+func nullability(c *ddl.Column) string {
+	if c.CanBeNull {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// defaultValue returns the default value of the column or an empty string if
+// the column has no default value
+func defaultValue(c *ddl.Column) string {
+	if !c.DefaultValue.Valid {
+		return ""
+	}
+	return c.DefaultValue.String
+}
+
+// indexesByName returns all indexes of the table without the index of the primary key
+func indexesByName(t *ddl.Table) map[string]*ddl.Index {
+	rtc := map[string]*ddl.Index{}
+	for _, idx := range t.Indexes {
+		if t.PrimaryKey != nil && idx.Name == t.PrimaryKey.Name {
+			continue
+		}
+		rtc[idx.Name] = idx
+	}
+
+	return rtc
+}
+
+// indexDefinition returns a readable definition of the index like "UNIQUE BTREE (a, b)"
+func indexDefinition(idx *ddl.Index) string {
+	rtc := idx.Type
+	if idx.Unique {
+		rtc = strings.TrimSpace("UNIQUE " + rtc)
+	}
+	rtc += " (" + strings.Join(idx.Columns, ", ") + ")"
+	if idx.Predicate != "" {
+		rtc += " WHERE " + idx.Predicate
+	}
+
+	return strings.TrimSpace(rtc)
+}
+
+// foreignKeyName returns the name of the foreign key or its columns if it has no name
+func foreignKeyName(fk *ddl.ForeignKey) string {
+	if fk.Name != "" {
+		return fk.Name
+	}
+	return strings.Join(fk.Columns, "+")
+}
+
+// foreignKeyReference returns the columns and the referenced columns of the foreign key
+func foreignKeyReference(fk *ddl.ForeignKey) string {
+	table := fk.ReferencedTable
+	if fk.ReferencedSchema != "" {
+		table = fk.ReferencedSchema + "." + table
+	}
+
+	return fmt.Sprintf("(%s) REFERENCES %s (%s)", strings.Join(fk.Columns, ", "), table, strings.Join(fk.ReferencedColumns, ", "))
+}
+
+// foreignKeyActions returns the referential actions of the foreign key
+func foreignKeyActions(fk *ddl.ForeignKey) string {
+	return fmt.Sprintf("ON DELETE %s ON UPDATE %s", fk.OnDelete, fk.OnUpdate)
+}
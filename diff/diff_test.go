@@ -0,0 +1,233 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/RPJoshL/go-ddl-parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parseTables returns the tables of the MariaDB script within the schema "ddl"
+func parseTables(t *testing.T, script string) []*ddl.Table {
+	s := ddl.NewMariadbScript("ddl")
+	if err := s.Parse(script); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	tables, err := s.GetTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	return tables
+}
+
+func TestCompare(t *testing.T) {
+	old := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY);
+		CREATE TABLE user (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name      VARCHAR(20) NOT NULL,
+			mail      VARCHAR(50) COMMENT 'Mail',
+			age       INT(10),
+			state     VARCHAR(5) DEFAULT 'on',
+			parent_id INT(10),
+			removed   DATE,
+			INDEX idx_name (name),
+			INDEX idx_mail (mail),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id)
+		);
+		CREATE TABLE logs (id INT(10), message TEXT);
+		CREATE TABLE obsolete (id INT(10), data BLOB);
+	`)
+	new := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY);
+		CREATE TABLE user (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			full_name VARCHAR(20) NOT NULL,
+			mail      VARCHAR(100) COMMENT 'E-Mail',
+			age       VARCHAR(10) NOT NULL,
+			state     VARCHAR(5) DEFAULT 'off',
+			parent_id INT(10),
+			created   DATETIME,
+			required  INT(10) NOT NULL,
+			INDEX idx_mail (mail, age),
+			UNIQUE INDEX idx_age (age),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id) ON DELETE CASCADE
+		);
+		CREATE TABLE audit_logs (id INT(10), message TEXT);
+		CREATE TABLE fresh (id INT(10));
+	`)
+
+	expected := []*Change{
+		{Kind: TableAdded, Severity: SeverityAdditive, Schema: "ddl", Table: "fresh"},
+		{Kind: TableRenamed, Severity: SeverityBreaking, Schema: "ddl", Table: "logs", Old: "logs", New: "audit_logs"},
+		{Kind: TableRemoved, Severity: SeverityBreaking, Schema: "ddl", Table: "obsolete"},
+		{Kind: ColumnTypeChanged, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "mail", Old: "varchar(50)", New: "varchar(100)"},
+		{Kind: ColumnCommentChanged, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "mail", Old: "Mail", New: "E-Mail"},
+		{Kind: ColumnTypeChanged, Severity: SeverityBreaking, Schema: "ddl", Table: "user", Name: "age", Old: "int(10)", New: "varchar(10)"},
+		{Kind: ColumnNullabilityChanged, Severity: SeverityBreaking, Schema: "ddl", Table: "user", Name: "age", Old: "NULL", New: "NOT NULL"},
+		{Kind: ColumnDefaultChanged, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "state", Old: "on", New: "off"},
+		{Kind: ColumnRenamed, Severity: SeverityBreaking, Schema: "ddl", Table: "user", Name: "name", Old: "name", New: "full_name"},
+		{Kind: ColumnRemoved, Severity: SeverityBreaking, Schema: "ddl", Table: "user", Name: "removed"},
+		{Kind: ColumnAdded, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "created"},
+		{Kind: ColumnAdded, Severity: SeverityBreaking, Schema: "ddl", Table: "user", Name: "required"},
+		{Kind: IndexChanged, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "idx_mail", Old: "BTREE (mail)", New: "BTREE (mail, age)"},
+		{Kind: IndexRemoved, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "idx_name"},
+		{Kind: IndexAdded, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "idx_age"},
+		{
+			Kind: ForeignKeyChanged, Severity: SeverityAdditive, Schema: "ddl", Table: "user", Name: "fk_parent",
+			Old: "ON DELETE RESTRICT ON UPDATE RESTRICT", New: "ON DELETE CASCADE ON UPDATE RESTRICT",
+		},
+	}
+
+	changes := Compare(old, new)
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Errorf("Mismatch of changes (-want +got):\n%s", diff)
+	}
+	if !HasBreaking(changes) {
+		t.Errorf("Expected breaking changes")
+	}
+
+	// Comparing the same tables doesn't return any changes
+	if changes := Compare(old, old); len(changes) != 0 || HasBreaking(changes) {
+		t.Errorf("Expected no changes. Got %v", changes)
+	}
+}
+
+func TestCompareNarrowedTypes(t *testing.T) {
+	old := parseTables(t, `
+		CREATE TABLE user (
+			name   VARCHAR(100),
+			age    INT(10),
+			price  DECIMAL(10, 2),
+			amount DECIMAL(10, 2),
+			bio    TEXT
+		);
+	`)
+	new := parseTables(t, `
+		CREATE TABLE user (
+			name   VARCHAR(10),
+			age    TINYINT(3),
+			price  DECIMAL(10, 4),
+			amount DECIMAL(12, 2),
+			bio    LONGTEXT
+		);
+	`)
+
+	got := []string{}
+	for _, c := range Compare(old, new) {
+		got = append(got, c.String())
+	}
+	expected := []string{
+		`[BREAKING] ddl.user.name: COLUMN TYPE CHANGED "varchar(100)" -> "varchar(10)"`,
+		`[BREAKING] ddl.user.age: COLUMN TYPE CHANGED "int(10)" -> "tinyint(3)"`,
+		`[BREAKING] ddl.user.price: COLUMN TYPE CHANGED "decimal(10,2)" -> "decimal(10,4)"`,
+		`[ADDITIVE] ddl.user.amount: COLUMN TYPE CHANGED "decimal(10,2)" -> "decimal(12,2)"`,
+		`[ADDITIVE] ddl.user.bio: COLUMN TYPE CHANGED "text" -> "longtext"`,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of changes (-want +got):\n%s", diff)
+	}
+
+	// The internal type of oracle columns doesn't contain the lenght
+	oldOracle := parseOracleTables(t, `CREATE TABLE users (name VARCHAR2(100), age NUMBER(10));`)
+	newOracle := parseOracleTables(t, `CREATE TABLE users (name VARCHAR2(10), age NUMBER(12));`)
+	got = []string{}
+	for _, c := range Compare(oldOracle, newOracle) {
+		got = append(got, c.String())
+	}
+	expected = []string{
+		`[BREAKING] DDL.USERS.NAME: COLUMN TYPE CHANGED "VARCHAR2(100)" -> "VARCHAR2(10)"`,
+		`[ADDITIVE] DDL.USERS.AGE: COLUMN TYPE CHANGED "NUMBER(10)" -> "NUMBER(12)"`,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of oracle changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompareColumnTypes(t *testing.T) {
+	for _, tt := range []struct {
+		old      string
+		new      string
+		severity Severity
+	}{
+		// The Go type "string" stays the same
+		{"VARCHAR(20)", "TEXT", SeverityAdditive},
+		{"VARCHAR(20)", "ENUM('a', 'b')", SeverityAdditive},
+		{"TEXT", "INT(10)", SeverityBreaking},
+		{"INT(10)", "DOUBLE", SeverityBreaking},
+
+		// Signed and unsigned integers
+		{"INT(10)", "INT(10) UNSIGNED", SeverityBreaking},
+		{"INT(10)", "BIGINT(20) UNSIGNED", SeverityBreaking},
+		{"DECIMAL(10,2)", "DECIMAL(10,2) UNSIGNED", SeverityBreaking},
+		{"INT(10) UNSIGNED", "INT(10)", SeverityBreaking},
+		{"INT(10) UNSIGNED", "BIGINT(20)", SeverityAdditive},
+		{"INT(10) UNSIGNED", "BIGINT(20) UNSIGNED", SeverityAdditive},
+	} {
+		old := parseTables(t, "CREATE TABLE tbl (c "+tt.old+");")
+		new := parseTables(t, "CREATE TABLE tbl (c "+tt.new+");")
+
+		changes := Compare(old, new)
+		if len(changes) != 1 || changes[0].Kind != ColumnTypeChanged || changes[0].Severity != tt.severity {
+			t.Errorf("Expected a %s type change from %q to %q. Got %v", tt.severity, tt.old, tt.new, changes)
+		}
+	}
+}
+
+func TestCompareRenamedColumnPosition(t *testing.T) {
+	old := parseTables(t, `CREATE TABLE user (id INT(10), name VARCHAR(20), mail VARCHAR(50));`)
+	new := parseTables(t, `CREATE TABLE user (id INT(10), mail VARCHAR(50), full_name VARCHAR(20));`)
+
+	// The removed and added column don't have the same position
+	got := []string{}
+	for _, c := range Compare(old, new) {
+		got = append(got, c.String())
+	}
+	expected := []string{
+		`[BREAKING] ddl.user.name: COLUMN REMOVED`,
+		`[ADDITIVE] ddl.user.full_name: COLUMN ADDED`,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestComparePrimaryAndForeignKeys(t *testing.T) {
+	old := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY, code INT(10) UNIQUE);
+		CREATE TABLE child (
+			id        INT(10),
+			parent_id INT(10),
+			CONSTRAINT pk_child PRIMARY KEY (id),
+			CONSTRAINT fk_one FOREIGN KEY (parent_id) REFERENCES parent (id),
+			CONSTRAINT fk_two FOREIGN KEY (parent_id) REFERENCES parent (id)
+		);
+	`)
+	new := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY, code INT(10) UNIQUE);
+		CREATE TABLE child (
+			id        INT(10),
+			parent_id INT(10),
+			CONSTRAINT pk_child PRIMARY KEY (id, parent_id),
+			CONSTRAINT fk_two FOREIGN KEY (parent_id) REFERENCES parent (code),
+			CONSTRAINT fk_three FOREIGN KEY (id) REFERENCES parent (id)
+		);
+	`)
+
+	got := []string{}
+	for _, c := range Compare(old, new) {
+		if c.Kind == PrimaryKeyChanged || c.Kind == ForeignKeyAdded || c.Kind == ForeignKeyRemoved || c.Kind == ForeignKeyChanged {
+			got = append(got, c.String())
+		}
+	}
+	expected := []string{
+		`[BREAKING] ddl.child: PRIMARY KEY CHANGED "id" -> "id, parent_id"`,
+		`[BREAKING] ddl.child.fk_one: FOREIGN KEY REMOVED`,
+		`[BREAKING] ddl.child.fk_two: FOREIGN KEY CHANGED "(parent_id) REFERENCES ddl.parent (id)" -> "(parent_id) REFERENCES ddl.parent (code)"`,
+		`[ADDITIVE] ddl.child.fk_three: FOREIGN KEY ADDED`,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of changes (-want +got):\n%s", diff)
+	}
+}
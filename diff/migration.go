@@ -0,0 +1,403 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/RPJoshL/go-ddl-parser"
+	"github.com/RPJoshL/go-logger"
+)
+
+// Migration contains the statements to migrate the old tables to the new
+// tables (up) and to revert this migration (down)
+type Migration struct {
+	Up   []string
+	Down []string
+}
+
+// migration builds the statements of a single direction. The statements are
+// collected by their phase, so they can be executed in a valid order
+type migration struct {
+	dialect   ddl.Dialect
+	changes   []*Change
+	oldTables map[string]*ddl.Table
+	newTables map[string]*ddl.Table
+
+	// New tables and column names of renamed objects by their old key
+	renamedTables  map[string]*ddl.Table
+	renamedColumns map[string]string
+
+	// Columns that were already modified
+	modified map[string]bool
+
+	// Removed tables in the order of the changes
+	removedTables []*ddl.Table
+
+	dropForeignKeys []string
+	dropIndexes     []string
+	renameTables    []string
+	createTables    []string
+	alterTables     []string
+	createIndexes   []string
+	addForeignKeys  []string
+	dropTables      []string
+}
+
+// NewMigration returns the statements to migrate from the old to the new tables and back.
+// Views are ignored
+func NewMigration(oldTables, newTables []*ddl.Table, dialect ddl.Dialect) (*Migration, error) {
+	up, err := Statements(oldTables, newTables, dialect)
+	if err != nil {
+		return nil, err
+	}
+	down, err := Statements(newTables, oldTables, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migration{Up: up, Down: down}, nil
+}
+
+// WriteFiles writes the migration into the files "<name>.up.sql" and "<name>.down.sql"
+// of the directory
+func (m *Migration) WriteFiles(dir, name string) error {
+	for suffix, statements := range map[string][]string{"up": m.Up, "down": m.Down} {
+		content := ""
+		for _, s := range statements {
+			content += s + ";\n\n"
+		}
+
+		path := filepath.Join(dir, name+"."+suffix+".sql")
+		if err := os.WriteFile(path, []byte(strings.TrimSuffix(content, "\n")), 0644); err != nil {
+			return fmt.Errorf("failed to write file %q: %s", path, err)
+		}
+	}
+
+	return nil
+}
+
+// Statements returns the ordered statements without a trailing semicolon that migrate
+// the old tables to the new tables. Foreign keys and indexes are dropped first and
+// created after all tables were created or altered. Views are ignored
+func Statements(oldTables, newTables []*ddl.Table, dialect ddl.Dialect) ([]string, error) {
+	if err := dialect.Validate(); err != nil {
+		return nil, err
+	}
+
+	oldTables, newTables = targetTables(dialect, withoutViews(oldTables)), targetTables(dialect, withoutViews(newTables))
+	m := &migration{
+		dialect:        dialect,
+		changes:        Compare(oldTables, newTables),
+		oldTables:      map[string]*ddl.Table{},
+		newTables:      map[string]*ddl.Table{},
+		renamedTables:  map[string]*ddl.Table{},
+		renamedColumns: map[string]string{},
+		modified:       map[string]bool{},
+	}
+	for _, t := range oldTables {
+		m.oldTables[tableKey(t)] = t
+	}
+	for _, t := range newTables {
+		m.newTables[tableKey(t)] = t
+	}
+
+	// Renamed objects are required to resolve the new names
+	for _, c := range m.changes {
+		switch c.Kind {
+		case TableRenamed:
+			m.renamedTables[c.Schema+"."+c.Table] = m.newTables[c.Schema+"."+c.New]
+		case ColumnRenamed:
+			m.renamedColumns[c.Schema+"."+c.Table+"."+c.Name] = c.New
+		}
+	}
+
+	for _, c := range m.changes {
+		m.addChange(c)
+	}
+	m.dropRemovedTables()
+
+	rtc := []string{}
+	for _, statements := range [][]string{
+		m.dropForeignKeys, m.dropIndexes, m.renameTables, m.createTables,
+		m.alterTables, m.createIndexes, m.addForeignKeys, m.dropTables,
+	} {
+		rtc = append(rtc, statements...)
+	}
+	return rtc, nil
+}
+
+// addChange adds the statements of a single change
+func (m *migration) addChange(c *Change) {
+	d := m.dialect
+	oldTable := m.oldTables[c.Schema+"."+c.Table]
+	newTable := m.newTable(c)
+
+	switch c.Kind {
+	case TableAdded:
+		m.createTables = append(m.createTables, m.createTable(newTable)...)
+		for _, idx := range newTable.Indexes {
+			if _, ok := indexesByName(newTable)[idx.Name]; ok {
+				m.createIndex(newTable, idx)
+			}
+		}
+		for _, fk := range newTable.ForeignKeys {
+			m.addForeignKeys = append(m.addForeignKeys, m.addForeignKey(newTable, fk))
+		}
+	case TableRemoved:
+		// Foreign keys between removed tables would prevent dropping them
+		// Unnamed ones are handled by the order of the dropped tables
+		for _, fk := range oldTable.ForeignKeys {
+			if fk.Name != "" {
+				m.dropForeignKey(oldTable, fk)
+			}
+		}
+		m.removedTables = append(m.removedTables, oldTable)
+	case TableRenamed:
+		if d == ddl.DialectMariadb {
+			m.renameTables = append(m.renameTables, fmt.Sprintf("RENAME TABLE %s TO %s", quoteTable(d, oldTable), quoteTable(d, newTable)))
+		} else {
+			m.renameTables = append(m.renameTables, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quoteTable(d, oldTable), d.Quote(newTable.Name)))
+		}
+	case TableCommentChanged:
+		if d == ddl.DialectMariadb {
+			m.alter(newTable, "COMMENT = "+d.QuoteString(newTable.Comment))
+		} else {
+			m.alterTables = append(m.alterTables, fmt.Sprintf("COMMENT ON TABLE %s IS %s", quoteTable(d, newTable), d.QuoteString(newTable.Comment)))
+		}
+
+	case ColumnAdded:
+		col := newTable.GetColumn(c.Name)
+		if d == ddl.DialectMariadb {
+			m.alter(newTable, "ADD COLUMN "+d.ColumnDefinition(col))
+		} else {
+			m.alter(newTable, "ADD ("+d.ColumnDefinition(col)+")")
+			if col.Comment != "" {
+				m.alterTables = append(m.alterTables, m.dialect.ColumnComment(newTable, col))
+			}
+		}
+	case ColumnRemoved:
+		m.alter(newTable, "DROP COLUMN "+d.Quote(c.Name))
+	case ColumnRenamed:
+		m.alter(newTable, fmt.Sprintf("RENAME COLUMN %s TO %s", d.Quote(c.Name), d.Quote(c.New)))
+	case ColumnTypeChanged, ColumnNullabilityChanged, ColumnDefaultChanged, ColumnCommentChanged:
+		m.modifyColumn(c, newTable)
+
+	case PrimaryKeyChanged:
+		if oldTable.PrimaryKey != nil {
+			m.alter(newTable, "DROP PRIMARY KEY")
+		}
+		if newTable.PrimaryKey != nil {
+			m.alter(newTable, "ADD "+m.dialect.PrimaryKey(newTable.PrimaryKey))
+		}
+
+	case IndexAdded, IndexRemoved, IndexChanged:
+		if c.Kind != IndexAdded {
+			m.dropIndexes = append(m.dropIndexes, m.dropIndex(oldTable, c.Name))
+		}
+		if c.Kind != IndexRemoved {
+			m.createIndex(newTable, indexesByName(newTable)[c.Name])
+		}
+
+	case ForeignKeyAdded, ForeignKeyRemoved, ForeignKeyChanged:
+		if c.Kind != ForeignKeyAdded {
+			// The changed foreign key can't be added while the old one still exists
+			if !m.dropForeignKey(oldTable, findForeignKey(oldTable, c.Name)) && c.Kind == ForeignKeyChanged {
+				return
+			}
+		}
+		if c.Kind != ForeignKeyRemoved {
+			m.addForeignKeys = append(m.addForeignKeys, m.addForeignKey(newTable, findForeignKey(newTable, c.Name)))
+		}
+	}
+}
+
+// dropRemovedTables adds the statements to drop the removed tables. The names of
+// foreign keys are not always known, so tables are dropped before the tables
+// they reference
+func (m *migration) dropRemovedTables() {
+	remaining := m.removedTables
+	for len(remaining) != 0 {
+		referenced := map[string]bool{}
+		for _, t := range remaining {
+			for _, fk := range t.ForeignKeys {
+				if fk.Name == "" && fk.ReferencedSchema+"."+fk.ReferencedTable != tableKey(t) {
+					referenced[fk.ReferencedSchema+"."+fk.ReferencedTable] = true
+				}
+			}
+		}
+
+		next := []*ddl.Table{}
+		for _, t := range remaining {
+			if referenced[tableKey(t)] {
+				next = append(next, t)
+			} else {
+				m.dropTables = append(m.dropTables, "DROP TABLE "+quoteTable(m.dialect, t))
+			}
+		}
+
+		// Cyclic references can't be resolved
+		if len(next) == len(remaining) {
+			for _, t := range next {
+				m.dropTables = append(m.dropTables, "DROP TABLE "+quoteTable(m.dialect, t))
+			}
+			break
+		}
+		remaining = next
+	}
+}
+
+// newTable returns the new version of the table that is affected by the change
+func (m *migration) newTable(c *Change) *ddl.Table {
+	if t, ok := m.renamedTables[c.Schema+"."+c.Table]; ok {
+		return t
+	}
+	return m.newTables[c.Schema+"."+c.Table]
+}
+
+// alter adds an "ALTER TABLE" statement with the action
+func (m *migration) alter(t *ddl.Table, action string) {
+	m.alterTables = append(m.alterTables, fmt.Sprintf("ALTER TABLE %s %s", quoteTable(m.dialect, t), action))
+}
+
+// modifyColumn adds the statements to modify a column. All changes of the
+// column are applied at once
+func (m *migration) modifyColumn(c *Change, newTable *ddl.Table) {
+	key := c.Schema + "." + c.Table + "." + c.Name
+	if m.modified[key] {
+		return
+	}
+	m.modified[key] = true
+
+	name := c.Name
+	if renamed, ok := m.renamedColumns[key]; ok {
+		name = renamed
+	}
+	col := newTable.GetColumn(name)
+
+	// MariaDB requires the complete definition of the column
+	if m.dialect == ddl.DialectMariadb {
+		m.alter(newTable, "MODIFY COLUMN "+m.dialect.ColumnDefinition(col))
+		return
+	}
+
+	changed := map[Kind]bool{}
+	for _, change := range m.changes {
+		if change.Schema+"."+change.Table+"."+change.Name == key {
+			changed[change.Kind] = true
+		}
+	}
+
+	// Only the changed properties are modified in the order of the syntax
+	parts := []string{}
+	if changed[ColumnTypeChanged] {
+		parts = append(parts, m.dialect.ColumnType(col))
+	}
+	if changed[ColumnDefaultChanged] {
+		if col.DefaultValue.Valid {
+			parts = append(parts, "DEFAULT "+m.dialect.DefaultValue(col))
+		} else {
+			parts = append(parts, "DEFAULT NULL")
+		}
+	}
+	if changed[ColumnNullabilityChanged] {
+		parts = append(parts, nullability(col))
+	}
+	if len(parts) != 0 {
+		m.alter(newTable, fmt.Sprintf("MODIFY (%s %s)", m.dialect.Quote(col.Name), strings.Join(parts, " ")))
+	}
+	if changed[ColumnCommentChanged] {
+		m.alterTables = append(m.alterTables, m.dialect.ColumnComment(newTable, col))
+	}
+}
+
+// createTable returns the statements to create the table with its columns, primary
+// key and comments. Indexes and foreign keys are created separately
+func (m *migration) createTable(t *ddl.Table) []string {
+	table := *t
+	table.Indexes, table.ForeignKeys = nil, nil
+
+	return m.dialect.CreateTable(&table)
+}
+
+// createIndex adds the statement to create the index. Indexes with unknown
+// expressions of key parts can't be created
+func (m *migration) createIndex(t *ddl.Table, idx *ddl.Index) {
+	if slices.Contains(idx.Columns, "") {
+		logger.Warning("Skipping index %s of %s.%s: expression of a key part is unknown", idx.Name, t.Schema, t.Name)
+		return
+	}
+
+	m.createIndexes = append(m.createIndexes, m.dialect.CreateIndex(t, idx))
+}
+
+// dropIndex returns the statement to drop the index
+func (m *migration) dropIndex(t *ddl.Table, name string) string {
+	if m.dialect == ddl.DialectMariadb {
+		return fmt.Sprintf("DROP INDEX %s ON %s", m.dialect.Quote(name), quoteTable(m.dialect, t))
+	}
+	return "DROP INDEX " + m.dialect.QuoteTable(t.Schema, m.dialect.ObjectName(t, name))
+}
+
+// addForeignKey returns the statement to add the foreign key to the table
+func (m *migration) addForeignKey(t *ddl.Table, fk *ddl.ForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s", quoteTable(m.dialect, t), m.dialect.ForeignKey(fk))
+}
+
+// dropForeignKey adds the statement to drop the foreign key of the table and returns
+// weather it was added. Foreign keys without a known name can't be dropped
+func (m *migration) dropForeignKey(t *ddl.Table, fk *ddl.ForeignKey) bool {
+	if fk.Name == "" {
+		logger.Warning("Skipping foreign key (%s) of %s.%s: name of the constraint is unknown", strings.Join(fk.Columns, ", "), t.Schema, t.Name)
+		return false
+	}
+
+	stmt := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", quoteTable(m.dialect, t), m.dialect.Quote(fk.Name))
+	if m.dialect == ddl.DialectMariadb {
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", quoteTable(m.dialect, t), m.dialect.Quote(fk.Name))
+	}
+	m.dropForeignKeys = append(m.dropForeignKeys, stmt)
+
+	return true
+}
+
+// findForeignKey returns the foreign key with the name of "foreignKeyName".
+// Names of foreign keys without a name are only used for matching
+func findForeignKey(t *ddl.Table, name string) *ddl.ForeignKey {
+	for _, fk := range t.ForeignKeys {
+		if foreignKeyName(fk) == name {
+			return fk
+		}
+	}
+
+	return nil
+}
+
+// targetTables returns the tables with the identifiers that are used within the dialect
+func targetTables(d ddl.Dialect, tables []*ddl.Table) []*ddl.Table {
+	rtc := make([]*ddl.Table, len(tables))
+	for i, t := range tables {
+		rtc[i] = d.TargetTable(t)
+	}
+
+	return rtc
+}
+
+// withoutViews returns all tables that are no views
+func withoutViews(tables []*ddl.Table) []*ddl.Table {
+	rtc := []*ddl.Table{}
+	for _, t := range tables {
+		if !t.Kind.IsView() {
+			rtc = append(rtc, t)
+		}
+	}
+
+	return rtc
+}
+
+// quoteTable returns the quoted schema and name of the table
+func quoteTable(d ddl.Dialect, t *ddl.Table) string {
+	return d.QuoteTable(t.Schema, t.Name)
+}
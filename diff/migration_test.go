@@ -0,0 +1,242 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RPJoshL/go-ddl-parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parseOracleTables returns the tables of the oracle script within the schema "DDL"
+func parseOracleTables(t *testing.T, script string) []*ddl.Table {
+	s := ddl.NewOracleScript("DDL")
+	if err := s.Parse(script); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	tables, err := s.GetTables("DDL")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	return tables
+}
+
+func TestStatementsMariadb(t *testing.T) {
+	old := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY);
+		CREATE TABLE user (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name      VARCHAR(20) NOT NULL,
+			mail      VARCHAR(50),
+			parent_id INT(10),
+			removed   DATE,
+			INDEX idx_mail (mail),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id)
+		);
+		CREATE TABLE logs (id INT(10), message TEXT);
+	`)
+	new := parseTables(t, `
+		CREATE TABLE parent (id INT(10) PRIMARY KEY);
+		CREATE TABLE user (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			full_name VARCHAR(20) NOT NULL,
+			mail      VARCHAR(100) NOT NULL DEFAULT 'none' COMMENT 'E-Mail',
+			parent_id INT(10),
+			INDEX idx_mail (mail, full_name),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id) ON DELETE CASCADE
+		);
+		CREATE TABLE audit_logs (id INT(10), message TEXT);
+		CREATE TABLE item (
+			id      INT(10) NOT NULL PRIMARY KEY,
+			user_id INT(10) NOT NULL,
+			CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES user (id)
+		);
+	`)
+
+	statements, err := Statements(old, new, ddl.DialectMariadb)
+	if err != nil {
+		t.Fatalf("Failed to get statements: %s", err)
+	}
+
+	expected := []string{
+		"ALTER TABLE `ddl`.`user` DROP FOREIGN KEY `fk_parent`",
+		"DROP INDEX `idx_mail` ON `ddl`.`user`",
+		"RENAME TABLE `ddl`.`logs` TO `ddl`.`audit_logs`",
+		"CREATE TABLE `ddl`.`item` (\n\t`id` int(10) NOT NULL,\n\t`user_id` int(10) NOT NULL,\n\tPRIMARY KEY (`id`)\n)",
+		"ALTER TABLE `ddl`.`user` MODIFY COLUMN `mail` varchar(100) NOT NULL DEFAULT 'none' COMMENT 'E-Mail'",
+		"ALTER TABLE `ddl`.`user` RENAME COLUMN `name` TO `full_name`",
+		"ALTER TABLE `ddl`.`user` DROP COLUMN `removed`",
+		"CREATE INDEX `fk_user` ON `ddl`.`item` (`user_id`)",
+		"CREATE INDEX `idx_mail` ON `ddl`.`user` (`mail`, `full_name`)",
+		"ALTER TABLE `ddl`.`item` ADD CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `ddl`.`user` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT",
+		"ALTER TABLE `ddl`.`user` ADD CONSTRAINT `fk_parent` FOREIGN KEY (`parent_id`) REFERENCES `ddl`.`parent` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT",
+	}
+	if diff := cmp.Diff(expected, statements); diff != "" {
+		t.Errorf("Mismatch of statements (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatementsOracle(t *testing.T) {
+	old := parseOracleTables(t, `
+		CREATE TABLE users (
+			id      NUMBER(10) GENERATED BY DEFAULT AS IDENTITY,
+			name    VARCHAR2(20) NOT NULL,
+			age     NUMBER(3),
+			CONSTRAINT pk_users PRIMARY KEY (id)
+		);
+	`)
+	new := parseOracleTables(t, `
+		CREATE TABLE users (
+			id      NUMBER(10) GENERATED BY DEFAULT AS IDENTITY,
+			name    VARCHAR2(50),
+			age     NUMBER(3) DEFAULT 18 NOT NULL,
+			CONSTRAINT pk_users PRIMARY KEY (id)
+		);
+		COMMENT ON COLUMN users.age IS 'Age in years';
+		CREATE TABLE items (
+			id      NUMBER(10) NOT NULL,
+			user_id NUMBER(10) NOT NULL,
+			price   NUMBER(10,2) DEFAULT 0,
+			CONSTRAINT pk_items PRIMARY KEY (id),
+			CONSTRAINT fk_items_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+		CREATE INDEX idx_items_user ON items (user_id);
+	`)
+
+	new[0].Comment = "It's an item"
+
+	migration, err := NewMigration(old, new, ddl.DialectOracle)
+	if err != nil {
+		t.Fatalf("Failed to get migration: %s", err)
+	}
+
+	expectedUp := []string{
+		"CREATE TABLE \"DDL\".\"ITEMS\" (\n\t\"ID\" NUMBER(10) NOT NULL,\n\t\"USER_ID\" NUMBER(10) NOT NULL,\n\t\"PRICE\" NUMBER(10,2) DEFAULT 0,\n\tCONSTRAINT \"PK_ITEMS\" PRIMARY KEY (\"ID\")\n)",
+		`COMMENT ON TABLE "DDL"."ITEMS" IS 'It''s an item'`,
+		`ALTER TABLE "DDL"."USERS" MODIFY ("NAME" VARCHAR2(50) NULL)`,
+		`ALTER TABLE "DDL"."USERS" MODIFY ("AGE" DEFAULT 18 NOT NULL)`,
+		`COMMENT ON COLUMN "DDL"."USERS"."AGE" IS 'Age in years'`,
+		`CREATE INDEX "DDL"."IDX_ITEMS_USER" ON "DDL"."ITEMS" ("USER_ID")`,
+		`ALTER TABLE "DDL"."ITEMS" ADD CONSTRAINT "FK_ITEMS_USER" FOREIGN KEY ("USER_ID") REFERENCES "DDL"."USERS" ("ID") ON DELETE CASCADE`,
+	}
+	if diff := cmp.Diff(expectedUp, migration.Up); diff != "" {
+		t.Errorf("Mismatch of up statements (-want +got):\n%s", diff)
+	}
+
+	// Foreign keys of removed tables are dropped first. Dropping the table drops its indexes
+	expectedDown := []string{
+		`ALTER TABLE "DDL"."ITEMS" DROP CONSTRAINT "FK_ITEMS_USER"`,
+		`ALTER TABLE "DDL"."USERS" MODIFY ("NAME" VARCHAR2(20) NOT NULL)`,
+		`ALTER TABLE "DDL"."USERS" MODIFY ("AGE" DEFAULT NULL NULL)`,
+		`COMMENT ON COLUMN "DDL"."USERS"."AGE" IS ''`,
+		`DROP TABLE "DDL"."ITEMS"`,
+	}
+	if diff := cmp.Diff(expectedDown, migration.Down); diff != "" {
+		t.Errorf("Mismatch of down statements (-want +got):\n%s", diff)
+	}
+
+	// Write the migration files
+	dir := t.TempDir()
+	if err := migration.WriteFiles(dir, "001_items"); err != nil {
+		t.Fatalf("Failed to write files: %s", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "001_items.down.sql"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %s", err)
+	}
+	if !strings.HasPrefix(string(content), expectedDown[0]+";\n\n"+expectedDown[1]+";\n") || !strings.HasSuffix(string(content), "DROP TABLE \"DDL\".\"ITEMS\";\n") {
+		t.Errorf("Unexpected content of down migration:\n%s", content)
+	}
+}
+
+func TestStatementsRemovedParent(t *testing.T) {
+	old := parseTables(t, `
+		CREATE TABLE a_parent (id INT(10) PRIMARY KEY);
+		CREATE TABLE b_child (
+			id        INT(10) PRIMARY KEY,
+			parent_id INT(10),
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES a_parent (id)
+		);
+	`)
+
+	statements, err := Statements(old, nil, ddl.DialectMariadb)
+	if err != nil {
+		t.Fatalf("Failed to get statements: %s", err)
+	}
+	expected := []string{
+		"ALTER TABLE `ddl`.`b_child` DROP FOREIGN KEY `fk_parent`",
+		"DROP TABLE `ddl`.`a_parent`",
+		"DROP TABLE `ddl`.`b_child`",
+	}
+	if diff := cmp.Diff(expected, statements); diff != "" {
+		t.Errorf("Mismatch of statements (-want +got):\n%s", diff)
+	}
+
+	// Without the name of the foreign key, the child is dropped first
+	old = parseOracleTables(t, `
+		CREATE TABLE a_parent (id NUMBER(10) PRIMARY KEY);
+		CREATE TABLE b_child (id NUMBER(10) PRIMARY KEY, parent_id NUMBER(10) REFERENCES a_parent (id));
+	`)
+	statements, err = Statements(old, nil, ddl.DialectOracle)
+	if err != nil {
+		t.Fatalf("Failed to get statements: %s", err)
+	}
+	expected = []string{
+		`DROP TABLE "DDL"."B_CHILD"`,
+		`DROP TABLE "DDL"."A_PARENT"`,
+	}
+	if diff := cmp.Diff(expected, statements); diff != "" {
+		t.Errorf("Mismatch of oracle statements (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatementsUnnamedForeignKey(t *testing.T) {
+	old := parseOracleTables(t, `
+		CREATE TABLE parent (id NUMBER(10) PRIMARY KEY);
+		CREATE TABLE child (id NUMBER(10) PRIMARY KEY, parent_id NUMBER(10) REFERENCES parent (id), other_id NUMBER(10) REFERENCES parent (id));
+	`)
+	new := parseOracleTables(t, `
+		CREATE TABLE parent (id NUMBER(10) PRIMARY KEY);
+		CREATE TABLE child (id NUMBER(10) PRIMARY KEY, parent_id NUMBER(10), other_id NUMBER(10) REFERENCES parent (id) ON DELETE CASCADE);
+	`)
+
+	// The foreign keys are detected but can't be dropped without their name
+	changes := Compare(old, new)
+	if len(changes) != 2 || changes[0].Kind != ForeignKeyRemoved || changes[1].Kind != ForeignKeyChanged {
+		t.Fatalf("Expected a removed and a changed foreign key. Got %v", changes)
+	}
+	statements, err := Statements(old, new, ddl.DialectOracle)
+	if err != nil {
+		t.Fatalf("Failed to get statements: %s", err)
+	}
+	if len(statements) != 0 {
+		t.Errorf("Expected no statements. Got %v", statements)
+	}
+}
+
+func TestStatementsCrossDialect(t *testing.T) {
+	old := parseTables(t, `CREATE TABLE orders (id INT(10) PRIMARY KEY);`)
+	new := parseTables(t, `CREATE TABLE orders (id INT(10) PRIMARY KEY, total INT(10), INDEX idx_total (total));`)
+
+	// The identifiers of MariaDB tables are converted to upper case for Oracle
+	statements, err := Statements(old, new, ddl.DialectOracle)
+	if err != nil {
+		t.Fatalf("Failed to get statements: %s", err)
+	}
+	expected := []string{
+		`ALTER TABLE "DDL"."ORDERS" ADD ("TOTAL" NUMBER(10))`,
+		`CREATE INDEX "DDL"."ORDERS_IDX_TOTAL" ON "DDL"."ORDERS" ("TOTAL")`,
+	}
+	if diff := cmp.Diff(expected, statements); diff != "" {
+		t.Errorf("Mismatch of statements (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatementsUnsupportedDialect(t *testing.T) {
+	if _, err := Statements(nil, nil, "sqlite"); err == nil {
+		t.Errorf("Expected an error for an unsupported dialect")
+	}
+}
@@ -0,0 +1,210 @@
+package ddl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// TableFilter selects the tables to load from multiple schemas at once.
+// All patterns are matched case-insensitive against the name. An empty
+// include list matches every name
+type TableFilter struct {
+
+	// Patterns of the schemas to load tables from like "billing_*"
+	IncludeSchemas []string
+
+	// Patterns of the schemas to ignore
+	ExcludeSchemas []string
+
+	// Patterns of the tables to load
+	IncludeTables []string
+
+	// Patterns of the tables to ignore like "*_bak"
+	ExcludeTables []string
+
+	// Kinds of the tables to load. All kinds are loaded if it's empty
+	Kinds []TableKind
+
+	// Weather the patterns are regular expressions instead of glob
+	// patterns like "billing_*". Like glob patterns, they have to match the whole name
+	Regex bool
+
+	// Compiled regular expressions by their pattern
+	regexps map[string]*regexp.Regexp
+
+	// Guards the compiled regular expressions so the filter can be shared
+	mu sync.Mutex
+}
+
+// MatchSchema returns weather tables of the schema should be loaded
+func (f *TableFilter) MatchSchema(schema string) (bool, error) {
+	return f.match(f.IncludeSchemas, f.ExcludeSchemas, schema)
+}
+
+// MatchTable returns weather the table should be loaded. The schema of the
+// table is not checked
+func (f *TableFilter) MatchTable(tbl *Table) (bool, error) {
+	if len(f.Kinds) != 0 {
+		found := false
+		for _, k := range f.Kinds {
+			found = found || k == tbl.Kind
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	return f.match(f.IncludeTables, f.ExcludeTables, tbl.Name)
+}
+
+// match returns weather the name matches any include pattern and
+// no exclude pattern
+func (f *TableFilter) match(include, exclude []string, name string) (bool, error) {
+	if len(include) != 0 {
+		if matched, err := f.matchAny(include, name); err != nil || !matched {
+			return false, err
+		}
+	}
+
+	matched, err := f.matchAny(exclude, name)
+	return !matched && err == nil, err
+}
+
+// matchAny returns weather any of the patterns matches the name
+func (f *TableFilter) matchAny(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		if matched, err := f.matchPattern(pattern, name); err != nil || matched {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// matchPattern matches a single glob pattern or regular expression
+// case-insensitive against the name
+func (f *TableFilter) matchPattern(pattern, name string) (bool, error) {
+	if f.Regex {
+		reg, err := f.compile(pattern)
+		if err != nil {
+			return false, err
+		}
+		return reg.MatchString(name), nil
+	}
+
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	return matched, nil
+}
+
+// compile returns the cached regular expression of the pattern
+func (f *TableFilter) compile(pattern string) (*regexp.Regexp, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if reg, ok := f.regexps[pattern]; ok {
+		return reg, nil
+	}
+
+	reg, err := regexp.Compile("(?i)^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	if f.regexps == nil {
+		f.regexps = map[string]*regexp.Regexp{}
+	}
+	f.regexps[pattern] = reg
+
+	return reg, nil
+}
+
+// tableLoader contains the functions to load the tables of a single schema
+type tableLoader struct {
+
+	// Returns the tables of the schema without their columns
+	listTables func(ctx context.Context, schema string) ([]*Table, error)
+
+	// Returns all tables of the schema with a single query
+	getTables func(ctx context.Context, schema string) ([]*Table, error)
+
+	// Returns a single table of the schema
+	getTable func(ctx context.Context, schema, name string) (*Table, error)
+}
+
+// filterTables loads the tables of all schemas that are matched by the filter.
+// The tables are listed and filtered first so that only the matching tables
+// are loaded. If every table of a schema matches, all of them are loaded at once
+func filterTables(ctx context.Context, filter *TableFilter, schemas []string, loader tableLoader) ([]*Table, error) {
+	if filter == nil {
+		filter = &TableFilter{}
+	}
+
+	rtc := []*Table{}
+	for _, schema := range schemas {
+		if matched, err := filter.MatchSchema(schema); err != nil {
+			return nil, err
+		} else if !matched {
+			continue
+		}
+
+		tables, err := loader.listTables(ctx, schema)
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for _, t := range tables {
+			if matched, err := filter.MatchTable(t); err != nil {
+				return nil, err
+			} else if matched {
+				names = append(names, t.Name)
+			}
+		}
+
+		// Load the whole schema at once
+		if len(names) != 0 && len(names) == len(tables) {
+			all, err := loader.getTables(ctx, schema)
+			if err != nil {
+				return nil, err
+			}
+			rtc = append(rtc, all...)
+			continue
+		}
+
+		for _, name := range names {
+			t, err := loader.getTable(ctx, schema, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get data for %s.%s: %s", schema, name, err)
+			}
+			rtc = append(rtc, t)
+		}
+	}
+
+	return rtc, nil
+}
+
+// scanStrings reads the first column of all rows as a string and closes the rows
+func scanStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	rtc := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+		rtc = append(rtc, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
@@ -0,0 +1,134 @@
+package ddl
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTableFilterMatch(t *testing.T) {
+	filter := &TableFilter{
+		IncludeSchemas: []string{"billing_*"},
+		ExcludeSchemas: []string{"billing_old"},
+		ExcludeTables:  []string{"*_bak"},
+		Kinds:          []TableKind{TableKindTable, TableKindView},
+	}
+
+	for schema, expected := range map[string]bool{
+		"billing_eu":  true,
+		"BILLING_US":  true,
+		"billing_old": false,
+		"shop":        false,
+	} {
+		if matched, err := filter.MatchSchema(schema); err != nil || matched != expected {
+			t.Errorf("Expected %t for schema %q. Got %t (%v)", expected, schema, matched, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		table    *Table
+		expected bool
+	}{
+		{&Table{Name: "invoices", Kind: TableKindTable}, true},
+		{&Table{Name: "open_invoices", Kind: TableKindView}, true},
+		{&Table{Name: "invoices_bak", Kind: TableKindTable}, false},
+		{&Table{Name: "INVOICES_BAK", Kind: TableKindTable}, false},
+		{&Table{Name: "invoice_stats", Kind: TableKindMaterializedView}, false},
+	} {
+		if matched, err := filter.MatchTable(tt.table); err != nil || matched != tt.expected {
+			t.Errorf("Expected %t for table %q. Got %t (%v)", tt.expected, tt.table.Name, matched, err)
+		}
+	}
+}
+
+func TestTableFilterRegex(t *testing.T) {
+	filter := &TableFilter{
+		IncludeTables: []string{`^invoice_\d+$`, `billing_.*`},
+		Regex:         true,
+	}
+
+	// Like glob patterns, regular expressions have to match the whole name
+	for name, expected := range map[string]bool{
+		"invoice_2024":   true,
+		"INVOICE_1":      true,
+		"invoice_old":    false,
+		"billing_x":      true,
+		"old_billing_x":  false,
+		"invoice_1_copy": false,
+	} {
+		if matched, err := filter.MatchTable(&Table{Name: name}); err != nil || matched != expected {
+			t.Errorf("Expected %t for table %q. Got %t (%v)", expected, name, matched, err)
+		}
+	}
+
+	// The filter can be used concurrently
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			filter.MatchTable(&Table{Name: "billing_x"})
+		}()
+	}
+	wg.Wait()
+
+	// Invalid patterns
+	if _, err := (&TableFilter{IncludeTables: []string{"("}, Regex: true}).MatchTable(&Table{Name: "a"}); err == nil {
+		t.Errorf("Expected an error for an invalid regular expression")
+	}
+	if _, err := (&TableFilter{ExcludeSchemas: []string{"["}}).MatchSchema("a"); err == nil {
+		t.Errorf("Expected an error for an invalid glob pattern")
+	}
+}
+
+func TestFilterTables(t *testing.T) {
+	listed, loaded := []string{}, []string{}
+	loader := tableLoader{
+		listTables: func(ctx context.Context, schema string) ([]*Table, error) {
+			listed = append(listed, schema)
+			if schema == "billing_us" {
+				return []*Table{{Schema: schema, Name: "invoices"}}, nil
+			}
+			return []*Table{
+				{Schema: schema, Name: "invoices"},
+				{Schema: schema, Name: "invoices_bak"},
+			}, nil
+		},
+		getTables: func(ctx context.Context, schema string) ([]*Table, error) {
+			loaded = append(loaded, schema)
+			return []*Table{{Schema: schema, Name: "invoices"}}, nil
+		},
+		getTable: func(ctx context.Context, schema, name string) (*Table, error) {
+			loaded = append(loaded, schema+"."+name)
+			return &Table{Schema: schema, Name: name}, nil
+		},
+	}
+
+	tables, err := filterTables(
+		context.Background(),
+		&TableFilter{IncludeSchemas: []string{"billing_*"}, ExcludeTables: []string{"*_bak"}},
+		[]string{"billing_eu", "shop", "billing_us"},
+		loader,
+	)
+	if err != nil {
+		t.Fatalf("Failed to filter tables: %s", err)
+	}
+
+	// Only the tables of matching schemas are listed and only the matching tables are loaded.
+	// A schema is loaded at once if all of its tables match
+	if diff := cmp.Diff(listed, []string{"billing_eu", "billing_us"}); diff != "" {
+		t.Errorf("Mismatch of listed schemas (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(loaded, []string{"billing_eu.invoices", "billing_us"}); diff != "" {
+		t.Errorf("Mismatch of loaded tables (-want +got):\n%s", diff)
+	}
+	expected := []*Table{
+		{Schema: "billing_eu", Name: "invoices"},
+		{Schema: "billing_us", Name: "invoices"},
+	}
+	if diff := cmp.Diff(tables, expected); diff != "" {
+		t.Errorf("Mismatch of tables (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+package ddl
+
+import (
+	"context"
+	"sync"
+)
+
+// LazyTable is a listed table whose columns, indexes and keys are only loaded
+// on the first access. It's safe for concurrent use
+type LazyTable struct {
+
+	// Table with the identity, kind and metadata returned by "ListTables".
+	// It doesn't contain any columns
+	Info *Table
+
+	db DbSystem
+
+	mu     sync.Mutex
+	loaded *Table
+}
+
+// NewLazyTable returns a table that is loaded from the database system on the
+// first access
+func NewLazyTable(db DbSystem, info *Table) *LazyTable {
+	return &LazyTable{
+		Info: info,
+		db:   db,
+	}
+}
+
+// ListLazyTables lists all tables of the schema without loading their columns
+func ListLazyTables(db DbSystemListing, schema string) ([]*LazyTable, error) {
+	tables, err := db.ListTables(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rtc := make([]*LazyTable, len(tables))
+	for i, t := range tables {
+		rtc[i] = NewLazyTable(db, t)
+	}
+
+	return rtc, nil
+}
+
+// Load returns the fully loaded table. The table is only fetched once.
+// If loading fails, the next call tries it again
+func (l *LazyTable) Load() (*Table, error) {
+	return l.LoadContext(context.Background())
+}
+
+// LoadContext is like Load but uses the context for all queries if the
+// database system supports it
+func (l *LazyTable) LoadContext(ctx context.Context) (*Table, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.loaded != nil {
+		return l.loaded, nil
+	}
+
+	var tbl *Table
+	var err error
+	if db, ok := l.db.(DbSystemContext); ok {
+		tbl, err = db.GetTableContext(ctx, l.Info.Schema, l.Info.Name)
+	} else {
+		tbl, err = l.db.GetTable(l.Info.Schema, l.Info.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l.loaded = tbl
+	return tbl, nil
+}
+
+// IsLoaded returns weather the table was already loaded
+func (l *LazyTable) IsLoaded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.loaded != nil
+}
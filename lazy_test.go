@@ -0,0 +1,45 @@
+package ddl
+
+import (
+	"testing"
+)
+
+func TestLazyTable(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db).(DbSystemListing)
+
+	tableName, err := createTable(db, `id INTEGER PRIMARY KEY NOT NULL, txt TEXT`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+
+	tables, err := ListLazyTables(sDb, "main")
+	if err != nil {
+		t.Fatalf("Failed to list tables: %s", err)
+	}
+	if len(tables) != 1 || tables[0].Info.Name != tableName || tables[0].Info.Columns != nil || tables[0].IsLoaded() {
+		t.Fatalf("Expected only the unloaded table %q. Got %+v", tableName, tables)
+	}
+
+	table, err := tables[0].Load()
+	if err != nil {
+		t.Fatalf("Failed to load table: %s", err)
+	}
+	if len(table.Columns) != 2 || !tables[0].IsLoaded() {
+		t.Errorf("Expected the loaded columns. Got %+v", table.Columns)
+	}
+
+	// The table is only loaded once
+	if _, err := db.Exec("DROP TABLE " + tableName); err != nil {
+		t.Fatalf("Failed to drop table: %s", err)
+	}
+	if cached, err := tables[0].Load(); err != nil || cached != table {
+		t.Errorf("Expected the cached table. Got %v", err)
+	}
+
+	// Errors are not cached
+	missing := NewLazyTable(sDb, &Table{Schema: "main", Name: tableName})
+	if _, err := missing.Load(); err == nil || missing.IsLoaded() {
+		t.Errorf("Expected an error for a not existing table")
+	}
+}
@@ -0,0 +1,440 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sqlTokenType is the kind of a lexical token within a SQL script
+type sqlTokenType int
+
+const (
+	// Unquoted identifier or keyword
+	tokenIdent sqlTokenType = iota
+	// Identifier quoted with backticks or double quotes
+	tokenQuotedIdent
+	// String literal. The value contains the unescaped content
+	tokenString
+	// Numeric literal
+	tokenNumber
+	// Any other character like '(' or ','
+	tokenSymbol
+	// End of the script
+	tokenEOF
+)
+
+// sqlToken is a single lexical token of a SQL script
+type sqlToken struct {
+	typ   sqlTokenType
+	value string
+
+	// Byte offsets of the raw token within the script
+	start, end int
+
+	// Line number of the token within the script (starting with 1)
+	line int
+}
+
+// sqlDialect contains the lexical rules of a SQL dialect
+type sqlDialect struct {
+
+	// Weather identifiers can be quoted with backticks
+	backtickIdentifiers bool
+
+	// Weather strings can contain escape sequences with a backslash
+	backslashEscapes bool
+
+	// Weather a "#" starts a comment till the end of the line
+	hashComments bool
+
+	// Weather double quotes delimit strings instead of identifiers like
+	// in MariaDB without the SQL mode "ANSI_QUOTES"
+	doubleQuoteStrings bool
+}
+
+var (
+	mariadbDialect = sqlDialect{backtickIdentifiers: true, backslashEscapes: true, hashComments: true, doubleQuoteStrings: true}
+	oracleDialect  = sqlDialect{}
+	sqliteDialect  = sqlDialect{backtickIdentifiers: true}
+)
+
+// lexSql splits the SQL script into tokens. Comments and whitespaces are
+// not returned
+func lexSql(input string, dialect sqlDialect) ([]sqlToken, error) {
+	tokens := []sqlToken{}
+	line := 1
+
+	for i := 0; i < len(input); {
+		c := input[i]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+
+		// Line comments
+		case strings.HasPrefix(input[i:], "--") || (dialect.hashComments && c == '#'):
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+
+		// Block comments. MariaDB's executable comments "/*! */" are skipped too
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated comment at line %d", line)
+			}
+			line += strings.Count(input[i:i+2+end+2], "\n")
+			i += 2 + end + 2
+
+		// Strings and quoted identifiers
+		case c == '\'' || c == '"' || (dialect.backtickIdentifiers && c == '`'):
+			value, end, err := lexQuoted(input, i, c, dialect.backslashEscapes && c != '`')
+			if err != nil {
+				return nil, fmt.Errorf("%s at line %d", err, line)
+			}
+			typ := tokenQuotedIdent
+			if c == '\'' || (dialect.doubleQuoteStrings && c == '"') {
+				typ = tokenString
+			}
+			tokens = append(tokens, sqlToken{typ: typ, value: value, start: i, end: end, line: line})
+			line += strings.Count(input[i:end], "\n")
+			i = end
+
+		// National character strings like N'value'
+		case (c == 'N' || c == 'n') && i+1 < len(input) && input[i+1] == '\'':
+			value, end, err := lexQuoted(input, i+1, '\'', dialect.backslashEscapes)
+			if err != nil {
+				return nil, fmt.Errorf("%s at line %d", err, line)
+			}
+			tokens = append(tokens, sqlToken{typ: tokenString, value: value, start: i, end: end, line: line})
+			line += strings.Count(input[i:end], "\n")
+			i = end
+
+		case c >= '0' && c <= '9' || (c == '.' && i+1 < len(input) && input[i+1] >= '0' && input[i+1] <= '9'):
+			start := i
+			for i < len(input) && (isDigit(input[i]) || input[i] == '.') {
+				i++
+			}
+			// Exponent
+			if i < len(input) && (input[i] == 'e' || input[i] == 'E') {
+				i++
+				if i < len(input) && (input[i] == '+' || input[i] == '-') {
+					i++
+				}
+				for i < len(input) && isDigit(input[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, sqlToken{typ: tokenNumber, value: input[start:i], start: start, end: i, line: line})
+
+		case isIdentifierChar(rune(c)) || c >= 0x80:
+			start := i
+			for i < len(input) {
+				r, size := utf8.DecodeRuneInString(input[i:])
+				if !isIdentifierChar(r) && !(r >= 0x80 && unicode.IsLetter(r)) {
+					break
+				}
+				i += size
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected character %q at line %d", input[i], line)
+			}
+			tokens = append(tokens, sqlToken{typ: tokenIdent, value: input[start:i], start: start, end: i, line: line})
+
+		default:
+			tokens = append(tokens, sqlToken{typ: tokenSymbol, value: string(c), start: i, end: i + 1, line: line})
+			i++
+		}
+	}
+
+	tokens = append(tokens, sqlToken{typ: tokenEOF, start: len(input), end: len(input), line: line})
+	return tokens, nil
+}
+
+// lexQuoted reads a quoted string starting at the quote character at "start".
+// A doubled quote character is an escaped quote
+func lexQuoted(input string, start int, quote byte, backslashEscapes bool) (value string, end int, err error) {
+	var b strings.Builder
+
+	for i := start + 1; i < len(input); i++ {
+		c := input[i]
+
+		if backslashEscapes && c == '\\' && i+1 < len(input) {
+			i++
+			switch input[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '0':
+				b.WriteByte(0)
+			default:
+				b.WriteByte(input[i])
+			}
+			continue
+		}
+
+		if c == quote {
+			if i+1 < len(input) && input[i+1] == quote {
+				b.WriteByte(quote)
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+		}
+
+		b.WriteByte(c)
+	}
+
+	return "", 0, fmt.Errorf("unterminated quote %q", string(quote))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierChar(r rune) bool {
+	return r == '_' || r == '$' || r == '#' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// sqlParser provides helper functions to walk through the tokens of a script
+type sqlParser struct {
+	input  string
+	tokens []sqlToken
+	pos    int
+}
+
+func newSqlParser(input string, dialect sqlDialect) (*sqlParser, error) {
+	tokens, err := lexSql(input, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlParser{input: input, tokens: tokens}, nil
+}
+
+// peek returns the current token without consuming it
+func (p *sqlParser) peek() sqlToken {
+	return p.tokens[p.pos]
+}
+
+// peekAt returns the token with the offset to the current token
+func (p *sqlParser) peekAt(offset int) sqlToken {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+// next consumes and returns the current token
+func (p *sqlParser) next() sqlToken {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// eof returns weather all tokens were consumed
+func (p *sqlParser) eof() bool {
+	return p.peek().typ == tokenEOF
+}
+
+// isKeyword returns weather the token at the offset is the unquoted keyword
+func (p *sqlParser) isKeyword(offset int, keyword string) bool {
+	t := p.peekAt(offset)
+	return t.typ == tokenIdent && strings.EqualFold(t.value, keyword)
+}
+
+// isKeywords returns weather the next tokens are the provided keywords
+func (p *sqlParser) isKeywords(keywords ...string) bool {
+	for i, k := range keywords {
+		if !p.isKeyword(i, k) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSymbol returns weather the current token is the symbol
+func (p *sqlParser) isSymbol(symbol string) bool {
+	t := p.peek()
+	return t.typ == tokenSymbol && t.value == symbol
+}
+
+// acceptKeywords consumes the keywords if ALL of them are following
+func (p *sqlParser) acceptKeywords(keywords ...string) bool {
+	if !p.isKeywords(keywords...) {
+		return false
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+// acceptSymbol consumes the symbol if it's the current token
+func (p *sqlParser) acceptSymbol(symbol string) bool {
+	if !p.isSymbol(symbol) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// expectKeywords consumes the keywords or returns an error
+func (p *sqlParser) expectKeywords(keywords ...string) error {
+	if !p.acceptKeywords(keywords...) {
+		return p.errorf("expected %q", strings.Join(keywords, " "))
+	}
+	return nil
+}
+
+// expectSymbol consumes the symbol or returns an error
+func (p *sqlParser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.errorf("expected %q", symbol)
+	}
+	return nil
+}
+
+// identifier consumes a quoted or unquoted identifier
+func (p *sqlParser) identifier() (name string, quoted bool, err error) {
+	t := p.peek()
+	if t.typ != tokenIdent && t.typ != tokenQuotedIdent {
+		return "", false, p.errorf("expected identifier")
+	}
+	p.pos++
+
+	return t.value, t.typ == tokenQuotedIdent, nil
+}
+
+// qualifiedName consumes an identifier that is optional prefixed with a schema
+// like "schema.table"
+func (p *sqlParser) qualifiedName() (schema, name string, quotedName bool, err error) {
+	name, quotedName, err = p.identifier()
+	if err != nil {
+		return
+	}
+
+	if p.isSymbol(".") {
+		p.pos++
+		schema = name
+		name, quotedName, err = p.identifier()
+	}
+
+	return
+}
+
+// identifierList consumes a list of identifiers in brackets like "(a, b)".
+// Any length or sort order specified for an identifier is ignored
+func (p *sqlParser) identifierList() ([]sqlToken, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+
+	rtc := []sqlToken{}
+	for {
+		t := p.peek()
+		if t.typ != tokenIdent && t.typ != tokenQuotedIdent {
+			return nil, p.errorf("expected identifier")
+		}
+		p.pos++
+		rtc = append(rtc, t)
+
+		// Skip any options like "col(10) DESC"
+		for !p.isSymbol(",") && !p.isSymbol(")") && !p.eof() {
+			if p.isSymbol("(") {
+				p.skipBrackets()
+			} else {
+				p.pos++
+			}
+		}
+
+		if p.acceptSymbol(")") {
+			return rtc, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// skipBrackets consumes the brackets starting at the current token and returns
+// the raw content between the brackets
+func (p *sqlParser) skipBrackets() (string, error) {
+	if !p.isSymbol("(") {
+		return "", p.errorf("expected %q", "(")
+	}
+	start := p.next()
+
+	depth := 1
+	for !p.eof() {
+		t := p.next()
+		if t.typ != tokenSymbol {
+			continue
+		}
+		switch t.value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(p.input[start.end:t.start]), nil
+			}
+		}
+	}
+
+	return "", p.errorf("missing closing bracket for line %d", start.line)
+}
+
+// expression consumes an expression till one of the stop keywords, a
+// "," or ")" is reached on the top level and returns the raw text
+func (p *sqlParser) expression(stopKeywords ...string) string {
+	start := p.peek().start
+	end := start
+
+	for !p.eof() && !p.isSymbol(",") && !p.isSymbol(")") && !p.isSymbol(";") {
+		stop := false
+		for _, k := range stopKeywords {
+			if p.isKeyword(0, k) {
+				stop = true
+			}
+		}
+		if stop {
+			break
+		}
+
+		if p.isSymbol("(") {
+			p.skipBrackets()
+			end = p.tokens[p.pos-1].end
+		} else {
+			end = p.next().end
+		}
+	}
+
+	return strings.TrimSpace(p.input[start:end])
+}
+
+// skipStatement consumes all tokens till the end of the current statement
+func (p *sqlParser) skipStatement() {
+	for !p.eof() && !p.acceptSymbol(";") {
+		p.pos++
+	}
+}
+
+// errorf returns an error with the line of the current token
+func (p *sqlParser) errorf(format string, a ...any) error {
+	t := p.peek()
+	found := t.value
+	if t.typ == tokenEOF {
+		found = "end of script"
+	}
+
+	return fmt.Errorf("line %d: %s, found %q", t.line, fmt.Sprintf(format, a...), found)
+}
@@ -1,9 +1,12 @@
 package ddl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/RPJoshL/go-logger"
 )
@@ -20,6 +23,10 @@ const (
 )
 
 var _ DbSystem = &Mariadb{}
+var _ DbSystemContext = &Mariadb{}
+var _ DbSystemDiscovery = &Mariadb{}
+var _ DbSystemListing = &Mariadb{}
+var _ DbSystemRoutines = &Mariadb{}
 var _ Columner = &MariadbColumn{}
 
 // Mariadb implements "DbSystem" for a MariaDB database
@@ -38,6 +45,13 @@ type MariadbColumn struct {
 
 	// The internal column key like 'UNI' or 'PRI'
 	KeyType MariadbKeyType
+
+	// Character set and collation of a string column
+	Charset   string
+	Collation string
+
+	// The allowed values of an "enum" or "set" column
+	EnumValues []string
 }
 
 func (c *MariadbColumn) GetExtraInfos() string {
@@ -61,6 +75,143 @@ func NewMariaDb(db *sql.DB) DbSystem {
 }
 
 func (s *Mariadb) GetTable(schema, name string) (*Table, error) {
+	return s.GetTableContext(context.Background(), schema, name)
+}
+
+func (s *Mariadb) GetTableContext(ctx context.Context, schema, name string) (*Table, error) {
+	tables, err := s.getTables(ctx, schema, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// We got no data
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	}
+
+	return tables[0], nil
+}
+
+func (s *Mariadb) GetTables(schema string) ([]*Table, error) {
+	return s.GetTablesContext(context.Background(), schema)
+}
+
+func (s *Mariadb) GetTablesContext(ctx context.Context, schema string) ([]*Table, error) {
+	return s.getTables(ctx, schema, "")
+}
+
+func (s *Mariadb) GetSchemas() ([]string, error) {
+	return s.GetSchemasContext(context.Background())
+}
+
+func (s *Mariadb) GetSchemasContext(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT SCHEMA_NAME FROM INFORMATION_SCHEMA.SCHEMATA ORDER BY SCHEMA_NAME")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema.schemata: %s", err)
+	}
+
+	return scanStrings(rows)
+}
+
+func (s *Mariadb) GetRoutines(schema string) ([]*Routine, error) {
+	return s.GetRoutinesContext(context.Background(), schema)
+}
+
+// GetRoutinesContext is like GetRoutines but uses the context for the query
+func (s *Mariadb) GetRoutinesContext(ctx context.Context, schema string) ([]*Routine, error) {
+	ssql := `
+		SELECT
+			r.ROUTINE_NAME,
+			r.ROUTINE_TYPE,
+			p.ORDINAL_POSITION,
+			COALESCE(p.PARAMETER_MODE, ''),
+			COALESCE(p.PARAMETER_NAME, ''),
+			COALESCE(p.DTD_IDENTIFIER, '')
+		FROM INFORMATION_SCHEMA.ROUTINES r
+		LEFT JOIN INFORMATION_SCHEMA.PARAMETERS p ON p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA
+			AND p.SPECIFIC_NAME = r.ROUTINE_NAME AND p.ROUTINE_TYPE = r.ROUTINE_TYPE
+		WHERE r.ROUTINE_SCHEMA = ? AND r.ROUTINE_TYPE IN ('PROCEDURE', 'FUNCTION')
+		ORDER BY r.ROUTINE_NAME, r.ROUTINE_TYPE, p.ORDINAL_POSITION
+	`
+	rows, err := s.db.QueryContext(ctx, ssql, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema.routines: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Routine{}
+	var routine *Routine
+	for rows.Next() {
+		var name, kind string
+		var position sql.NullInt64
+		param := &Parameter{}
+		if err := rows.Scan(&name, &kind, &position, &param.Direction, &param.Name, &param.InternalType); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		if routine == nil || routine.Name != name || string(routine.Kind) != kind {
+			routine = &Routine{Schema: schema, Name: name, Kind: RoutineKind(kind)}
+			rtc = append(rtc, routine)
+		}
+
+		// Routine without any parameters
+		if !position.Valid {
+			continue
+		}
+		param.Type = s.GetDataType(param.InternalType)
+
+		// The position 0 contains the return value of a function
+		if position.Int64 == 0 {
+			param.Direction = ParameterOut
+			routine.Return = param
+		} else {
+			routine.Parameters = append(routine.Parameters, param)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	sortRoutines(rtc)
+	return rtc, nil
+}
+
+func (s *Mariadb) ListTables(schema string) ([]*Table, error) {
+	return s.ListTablesContext(context.Background(), schema)
+}
+
+// ListTablesContext is like ListTables but uses the context for the query
+func (s *Mariadb) ListTablesContext(ctx context.Context, schema string) ([]*Table, error) {
+	infos, err := s.getTableInfos(ctx, schema, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return listTables(schema, infos), nil
+}
+
+func (s *Mariadb) GetTablesFiltered(filter *TableFilter) ([]*Table, error) {
+	return s.GetTablesFilteredContext(context.Background(), filter)
+}
+
+func (s *Mariadb) GetTablesFilteredContext(ctx context.Context, filter *TableFilter) ([]*Table, error) {
+	schemas, err := s.GetSchemasContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterTables(ctx, filter, schemas, tableLoader{
+		listTables: s.ListTablesContext,
+		getTables:  s.GetTablesContext,
+		getTable:   s.GetTableContext,
+	})
+}
+
+// getTables fetches the columns of all tables within the schema with a single
+// query and groups them by the table. If a name is provided, only the
+// columns of this table are fetched
+func (s *Mariadb) getTables(ctx context.Context, schema, name string) ([]*Table, error) {
 	sql := `
 		SELECT 
 			c.TABLE_SCHEMA,
@@ -71,46 +222,54 @@ func (s *Mariadb) GetTable(schema, name string) (*Table, error) {
 			c.DATA_TYPE,
 			c.COLUMN_TYPE,
 			COALESCE(c.CHARACTER_MAXIMUM_LENGTH, c.NUMERIC_PRECISION, c.DATETIME_PRECISION, 0),
+			COALESCE(c.NUMERIC_SCALE, 0),
+			COALESCE(c.CHARACTER_SET_NAME, ''),
+			COALESCE(c.COLLATION_NAME, ''),
 			c.COLUMN_KEY,
 			c.COLUMN_COMMENT,
 			c.extra,
-			-- Foreign key data
-			COALESCE(con.REFERENCED_TABLE_NAME, ''), COALESCE(con.REFERENCED_TABLE_SCHEMA, ''), COALESCE(con.REFERENCED_COLUMN_NAME, '')
+			COALESCE(c.GENERATION_EXPRESSION, '')
   		FROM INFORMATION_SCHEMA.COLUMNS c
-		LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE con ON
-			con.TABLE_NAME = c.TABLE_NAME AND con.TABLE_SCHEMA = c.TABLE_SCHEMA AND con.COLUMN_NAME = c.COLUMN_NAME 
-				AND con.CONSTRAINT_NAME IN ( SELECT cc.CONSTRAINT_NAME FROM information_schema.TABLE_CONSTRAINTS cc WHERE cc.TABLE_SCHEMA = c.TABLE_SCHEMA AND cc.TABLE_NAME = c.TABLE_NAME AND cc.CONSTRAINT_TYPE = 'FOREIGN KEY' )
-	  	WHERE c.TABLE_SCHEMA = ? AND c.TABLE_NAME = ?
-	  	ORDER BY c.ordinal_position
+	  	WHERE c.TABLE_SCHEMA = ? AND (? = '' OR c.TABLE_NAME = ?)
+	  	ORDER BY c.TABLE_NAME, c.ordinal_position
 	`
-	rows, err := s.db.Query(sql, schema, name)
+	rows, err := s.db.QueryContext(ctx, sql, schema, name, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query information_schema: %s", err)
 	}
 	defer rows.Close()
 
-	table := &Table{}
-	count := 0
+	rtc := []*Table{}
+	var table *Table
 	for rows.Next() {
 		var tableSchema, tableName, isNullable, dataType, extra string
+		var scale int
 		column := s.newColumn()
 
 		if err := rows.Scan(
 			&tableSchema, &tableName,
 			&column.Name, &column.DefaultValue, &isNullable,
-			&dataType, &column.InternalType, &column.DataTypeLenght,
-			&column.KeyType, &column.Comment, &extra,
-			&column.ForeignKeyColumn.Name, &column.ForeignKeyColumn.Schema, &column.ForeignKeyColumn.Column,
+			&dataType, &column.InternalType, &column.DataTypeLenght, &scale,
+			&column.Charset, &column.Collation,
+			&column.KeyType, &column.Comment, &extra, &column.GenerationExpression,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %s", err)
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
 		}
 
 		// Apply data
 		column.CanBeNull = isNullable == "YES"
-		column.Type = s.GetDataType(dataType)
+		column.Type = s.GetDataType(column.InternalType)
 		column.AutoIncrement = strings.Contains(extra, "auto_increment")
+		column.GenerationStored = strings.Contains(extra, "STORED") || strings.Contains(extra, "PERSISTENT")
+		column.Invisible = strings.Contains(extra, "INVISIBLE")
 		column.PrimaryKey = column.KeyType == MariadbKeyPrimary
-		column.ForeignKey = column.ForeignKeyColumn.Column != ""
+		if column.Type == DecimalType {
+			column.NumericPrecision = column.DataTypeLenght
+			column.NumericScale = scale
+		}
+		if column.Type == EnumType || column.Type == SetType {
+			column.EnumValues = parseMariadbEnumValues(column.InternalType)
+		}
 
 		// The default value contains the raw single quotes of the create statement
 		if column.DefaultValue.Valid {
@@ -119,64 +278,417 @@ func (s *Mariadb) GetTable(schema, name string) (*Table, error) {
 		}
 
 		// Initialize new table metadata
-		if count == 0 {
-			table.Schema = tableSchema
-			table.Name = tableName
+		if table == nil || table.Schema != tableSchema || table.Name != tableName {
+			table = &Table{
+				Schema: tableSchema,
+				Name:   tableName,
+				Kind:   TableKindTable,
+			}
+			rtc = append(rtc, table)
 		}
 		table.Columns = append(table.Columns, column.Column)
-		count += 1
 	}
 
-	// We got no data
-	if count == 0 {
-		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	// The context could have been canceled while reading the rows
+	if err := rows.Err(); err != nil {
+		return rtc, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	// Add table metadata
+	infos, err := s.getTableInfos(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		if info, ok := infos[t.Name]; ok {
+			t.applyInfo(info)
+		}
 	}
 
-	return table, nil
+	// Add indexes
+	indexes, err := s.getIndexes(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		t.Indexes = indexes[t.Name]
+	}
+
+	// Add primary and foreign keys
+	primaryKeys, foreignKeys, err := s.getKeys(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		t.PrimaryKey = primaryKeys[t.Name]
+		t.ForeignKeys = foreignKeys[t.Name]
+		t.applyForeignKeys()
+	}
+
+	// Add check constraints
+	checks, err := s.getChecks(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		t.Checks = checks[t.Name]
+		for _, c := range t.Checks {
+			c.Columns = t.expressionColumns(c.Expression, mariadbDialect)
+		}
+		t.applyJsonChecks()
+	}
+
+	return rtc, nil
 }
 
-func (s *Mariadb) GetTables(schema string) ([]*Table, error) {
+// getChecks fetches the check constraints of all tables within the schema with a
+// single query and groups them by the table name. If a name is provided, only the
+// constraints of this table are fetched.
+// The columns of the constraints are not set
+func (s *Mariadb) getChecks(ctx context.Context, schema, name string) (map[string][]*CheckConstraint, error) {
 	sql := `
 		SELECT
-			t.TABLE_SCHEMA,
-    		t.TABLE_NAME
-		FROM information_schema.tables t 
-		WHERE t.table_schema = ?
-		ORDER BY t.TABLE_NAME ASC
+			c.TABLE_NAME,
+			c.CONSTRAINT_NAME,
+			c.CHECK_CLAUSE
+			FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS c
+			WHERE c.CONSTRAINT_SCHEMA = ? AND (? = '' OR c.TABLE_NAME = ?)
+			ORDER BY c.TABLE_NAME, c.CONSTRAINT_NAME
 	`
-	rows, err := s.db.Query(sql, schema)
+	rows, err := s.db.QueryContext(ctx, sql, schema, name, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema.check_constraints: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := map[string][]*CheckConstraint{}
+	for rows.Next() {
+		var tableName string
+		check := &CheckConstraint{}
+		if err := rows.Scan(&tableName, &check.Name, &check.Expression); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+		rtc[tableName] = append(rtc[tableName], check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+// getTableInfos fetches the metadata of all tables within the schema with a
+// single query like the kind, the engine or the comment. The returned tables
+// contain only this information grouped by the table name. If a name is provided,
+// only this table is fetched
+func (s *Mariadb) getTableInfos(ctx context.Context, schema, name string) (map[string]*Table, error) {
+	// Since MariaDB 11.4 the collation name doesn't contain the character set
+	// for some collations like "uca1400_ai_ci"
+	collationName := "COLLATION_NAME"
+	if exists, err := s.hasColumn(ctx, "COLLATION_CHARACTER_SET_APPLICABILITY", "FULL_COLLATION_NAME"); err != nil {
+		return nil, err
+	} else if exists {
+		collationName = "FULL_COLLATION_NAME"
+	}
+
+	ssql := fmt.Sprintf(`
+		SELECT
+			t.TABLE_NAME,
+			t.TABLE_TYPE,
+			COALESCE(v.VIEW_DEFINITION, ''),
+			COALESCE(t.ENGINE, ''),
+			COALESCE(c.CHARACTER_SET_NAME, ''),
+			COALESCE(t.TABLE_COLLATION, ''),
+			COALESCE(t.TABLE_COMMENT, ''),
+			UNIX_TIMESTAMP(t.CREATE_TIME),
+			t.TABLE_ROWS
+			FROM INFORMATION_SCHEMA.TABLES t
+			LEFT JOIN INFORMATION_SCHEMA.VIEWS v ON v.TABLE_SCHEMA = t.TABLE_SCHEMA AND v.TABLE_NAME = t.TABLE_NAME
+			LEFT JOIN INFORMATION_SCHEMA.COLLATION_CHARACTER_SET_APPLICABILITY c ON c.%s = t.TABLE_COLLATION
+			WHERE t.TABLE_SCHEMA = ? AND (? = '' OR t.TABLE_NAME = ?)
+	`, collationName)
+	rows, err := s.db.QueryContext(ctx, ssql, schema, name, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema.tables: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := map[string]*Table{}
+	for rows.Next() {
+		info := &Table{}
+		var tableType string
+		var created sql.NullInt64
+		if err := rows.Scan(
+			&info.Name, &tableType, &info.ViewDefinition,
+			&info.Engine, &info.Charset, &info.Collation, &info.Comment,
+			&created, &info.EstimatedRows,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		switch tableType {
+		case "VIEW":
+			info.Kind = TableKindView
+		case "SYSTEM VIEW":
+			info.Kind = TableKindSystemView
+		default:
+			info.Kind = TableKindTable
+		}
+
+		// The comment of a view is always "VIEW"
+		if info.Kind.IsView() {
+			info.Comment = ""
+		}
+		if created.Valid {
+			info.Created = sql.NullTime{Valid: true, Time: time.Unix(created.Int64, 0)}
+		}
+		rtc[info.Name] = info
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+// getKeys fetches the primary and foreign keys of all tables within the schema
+// with a single query and groups them by the table name. If a name is provided,
+// only the keys of this table are fetched
+func (s *Mariadb) getKeys(ctx context.Context, schema, name string) (map[string]*PrimaryKey, map[string][]*ForeignKey, error) {
+	sql := `
+		SELECT
+			k.TABLE_NAME,
+			k.CONSTRAINT_NAME,
+			k.COLUMN_NAME,
+			COALESCE(k.REFERENCED_TABLE_SCHEMA, ''), COALESCE(k.REFERENCED_TABLE_NAME, ''), COALESCE(k.REFERENCED_COLUMN_NAME, ''),
+			COALESCE(r.DELETE_RULE, ''), COALESCE(r.UPDATE_RULE, '')
+		FROM information_schema.KEY_COLUMN_USAGE k
+		LEFT JOIN information_schema.REFERENTIAL_CONSTRAINTS r ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+			AND r.TABLE_NAME = k.TABLE_NAME AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		WHERE k.TABLE_SCHEMA = ? AND (? = '' OR k.TABLE_NAME = ?)
+			AND (k.CONSTRAINT_NAME = 'PRIMARY' OR k.REFERENCED_TABLE_NAME IS NOT NULL)
+		ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION
+	`
+	rows, err := s.db.QueryContext(ctx, sql, schema, name, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query information_schema: %s", err)
+	}
+	defer rows.Close()
+
+	primaryKeys := map[string]*PrimaryKey{}
+	foreignKeys := map[string][]*ForeignKey{}
+	for rows.Next() {
+		var tableName, constraintName, columnName, refSchema, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(
+			&tableName, &constraintName, &columnName,
+			&refSchema, &refTable, &refColumn,
+			&onDelete, &onUpdate,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Every column of a key is returned as an own row
+		if refTable == "" {
+			if primaryKeys[tableName] == nil {
+				primaryKeys[tableName] = &PrimaryKey{Name: constraintName}
+			}
+			primaryKeys[tableName].Columns = append(primaryKeys[tableName].Columns, columnName)
+			continue
+		}
+
+		fks := foreignKeys[tableName]
+		if len(fks) == 0 || fks[len(fks)-1].Name != constraintName {
+			fks = append(fks, &ForeignKey{
+				Name:             constraintName,
+				ReferencedSchema: refSchema,
+				ReferencedTable:  refTable,
+				OnDelete:         onDelete,
+				OnUpdate:         onUpdate,
+			})
+			foreignKeys[tableName] = fks
+		}
+		fk := fks[len(fks)-1]
+		fk.Columns = append(fk.Columns, columnName)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, refColumn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return primaryKeys, foreignKeys, nil
+}
+
+// getIndexes fetches the indexes of all tables within the schema with a single
+// query and groups them by the table name. If a name is provided, only the
+// indexes of this table are fetched
+func (s *Mariadb) getIndexes(ctx context.Context, schema, name string) (map[string][]*Index, error) {
+	// Only MySQL returns the expressions of functional key parts
+	expression := "NULL"
+	if exists, err := s.hasColumn(ctx, "STATISTICS", "EXPRESSION"); err != nil {
+		return nil, err
+	} else if exists {
+		expression = "s.EXPRESSION"
+	}
+
+	// Expressions of key parts have to be enclosed in brackets
+	sql := fmt.Sprintf(`
+		SELECT
+			s.TABLE_NAME,
+			s.INDEX_NAME,
+			s.NON_UNIQUE,
+			COALESCE(s.COLUMN_NAME, CONCAT('(', %s, ')'), ''),
+			s.INDEX_TYPE
+		FROM information_schema.STATISTICS s
+		WHERE s.TABLE_SCHEMA = ? AND (? = '' OR s.TABLE_NAME = ?)
+		ORDER BY s.TABLE_NAME, s.INDEX_NAME <> 'PRIMARY', s.INDEX_NAME, s.SEQ_IN_INDEX
+	`, expression)
+	rows, err := s.db.QueryContext(ctx, sql, schema, name, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query information_schema: %s", err)
 	}
 	defer rows.Close()
 
-	rtc := []*Table{}
+	rtc := map[string][]*Index{}
+	var index *Index
 	for rows.Next() {
-		var tableSchema, tableName string
-		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+		var tableName, indexName, columnName, indexType string
+		var nonUnique int
+		if err := rows.Scan(&tableName, &indexName, &nonUnique, &columnName, &indexType); err != nil {
 			return rtc, fmt.Errorf("failed to scan row: %s", err)
 		}
 
-		t, err := s.GetTable(tableSchema, tableName)
-		if err != nil {
-			return rtc, fmt.Errorf("failed to get data for %s.%s: %s", tableSchema, tableName, err)
+		// Every column of an index is returned as an own row
+		indexes := rtc[tableName]
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != indexName {
+			index = &Index{
+				Name:   indexName,
+				Unique: nonUnique == 0,
+				Type:   indexType,
+			}
+			rtc[tableName] = append(indexes, index)
 		}
-		rtc = append(rtc, t)
+		index.Columns = append(index.Columns, columnName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return rtc, fmt.Errorf("failed to read rows: %s", err)
 	}
 
 	return rtc, nil
 }
 
+// hasColumn returns weather the table of the information_schema contains the column.
+// The columns differ between the versions of MariaDB and MySQL
+func (s *Mariadb) hasColumn(ctx context.Context, table, column string) (bool, error) {
+	var count int
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = 'information_schema' AND TABLE_NAME = ? AND COLUMN_NAME = ?
+	`, table, column).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to query information_schema.columns: %s", err)
+	}
+
+	return count != 0, nil
+}
+
+// parseMariadbEnumValues returns the allowed values of a column type like
+// "enum('a','b')" or "set('a','b')"
+func parseMariadbEnumValues(columnType string) []string {
+	p, err := newSqlParser(columnType, mariadbDialect)
+	if err != nil {
+		logger.Warning("MariaDb: failed to parse values of %q: %s", columnType, err)
+		return nil
+	}
+
+	// Skip the type name
+	p.next()
+	if !p.acceptSymbol("(") {
+		return nil
+	}
+
+	rtc := []string{}
+	for !p.eof() && !p.acceptSymbol(")") {
+		if t := p.next(); t.typ == tokenString {
+			rtc = append(rtc, t.value)
+		}
+	}
+
+	return rtc
+}
+
+// mariadbJsonCheck matches the check constraint MariaDB adds to columns of the type "json"
+var mariadbJsonCheck = regexp.MustCompile("(?i)^json_valid\\(\\s*`?([^`()]+?)`?\\s*\\)$")
+
+// applyJsonChecks sets the data type of text columns that are validated by "json_valid(col)".
+// MariaDB stores the data type "json" as "longtext" with this check constraint
+func (t *Table) applyJsonChecks() {
+	for _, c := range t.Columns {
+		if c.Type == JsonType && strings.EqualFold(c.InternalType, "longtext") {
+			c.Type = TextType
+		}
+	}
+
+	for _, check := range t.Checks {
+		match := mariadbJsonCheck.FindStringSubmatch(strings.TrimSpace(check.Expression))
+		if match == nil {
+			continue
+		}
+		if c := t.GetColumn(match[1]); c != nil && c.Type == TextType {
+			c.Type = JsonType
+		}
+	}
+}
+
+// GetDataType returns the generic data type of the column type. The type can
+// be provided with or without arguments ("tinyint(1)" or "tinyint"), but
+// booleans are only detected by the full column type "tinyint(1)"
 func (s *Mariadb) GetDataType(internalType string) DataType {
-	switch strings.ToLower(internalType) {
-	case "varchar", "text", "tinytext", "mediumtext", "longtext", "char":
+	internalType = strings.ToLower(strings.TrimSpace(internalType))
+	if strings.HasPrefix(internalType, "tinyint(1)") {
+		return BoolType
+	}
+
+	// Remove the arguments and attributes like "unsigned"
+	if end := strings.IndexAny(internalType, "( "); end != -1 {
+		internalType = internalType[:end]
+	}
+
+	switch internalType {
+	case "varchar", "char":
 		return StringType
-	case "int", "tinyint", "smallint", "bigint":
+	case "text", "tinytext", "mediumtext", "longtext":
+		return TextType
+	case "int", "tinyint", "smallint", "mediumint", "bigint":
 		return IntType
-	case "decimal", "number", "float", "double":
+	case "bool", "boolean":
+		return BoolType
+	case "decimal", "numeric", "number":
+		return DecimalType
+	case "float", "double", "real":
 		return DoubleType
-	case "datetime", "date":
+	case "datetime", "date", "timestamp":
 		return DateType
+	case "time":
+		return TimeType
+	case "year":
+		return YearType
+	case "bit":
+		return BitType
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return BinaryType
+	case "json":
+		// MariaDB stores JSON as "longtext" with a check constraint (see "applyJsonChecks").
+		// Only MySQL returns "json"
+		return JsonType
+	case "enum":
+		return EnumType
+	case "set":
+		return SetType
+	case "uuid":
+		return UuidType
 	case "point":
 		return GeoType
 	default:
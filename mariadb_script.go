@@ -0,0 +1,1554 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var _ DbSystem = &MariadbScript{}
+
+// MariadbScript implements "DbSystem" for SQL scripts of a MariaDB or MySQL
+// database like migration files or the output of "mysqldump --no-data".
+// The statements "CREATE TABLE", "ALTER TABLE", "CREATE INDEX", "RENAME TABLE",
+// "DROP TABLE" and "USE" are applied in the order they are parsed. Any other
+// statement is ignored
+type MariadbScript struct {
+
+	// Schema used for tables that are not qualified with a schema
+	schema string
+
+	tables []*mariadbScriptTable
+}
+
+// mariadbScriptTable contains the table with all constraints
+// that are required to build the column information
+type mariadbScriptTable struct {
+	*Table
+
+	primaryKey  []string
+	indexes     []*mariadbScriptIndex
+	foreignKeys []*mariadbScriptForeignKey
+	checks      []*CheckConstraint
+
+	// Number of check constraints that were named automatically
+	unnamedChecks int
+}
+
+type mariadbScriptIndex struct {
+	name    string
+	columns []string
+	unique  bool
+	typ     string
+}
+
+type mariadbScriptForeignKey struct {
+	name       string
+	columns    []string
+	refSchema  string
+	refTable   string
+	refColumns []string
+	onDelete   string
+	onUpdate   string
+}
+
+// NewMariadbScript initializes a new parser for SQL scripts of a MariaDB database.
+// The schema is used for all tables without an explicit schema until it's
+// changed with a "USE" statement
+func NewMariadbScript(schema string) *MariadbScript {
+	return &MariadbScript{
+		schema: schema,
+	}
+}
+
+// ParseFile parses the SQL script of the file and applies all statements
+// to the existing tables
+func (s *MariadbScript) ParseFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %q: %s", path, err)
+	}
+
+	if err := s.Parse(string(content)); err != nil {
+		return fmt.Errorf("failed to parse file %q: %s", path, err)
+	}
+	return nil
+}
+
+// Parse parses the SQL script and applies all statements to the existing tables
+func (s *MariadbScript) Parse(script string) error {
+	p, err := newSqlParser(script, mariadbDialect)
+	if err != nil {
+		return err
+	}
+
+	for !p.eof() {
+		if p.acceptSymbol(";") {
+			continue
+		}
+
+		var err error
+		switch {
+		case p.isKeyword(0, "CREATE"):
+			err = s.parseCreate(p)
+		case p.isKeyword(0, "ALTER"):
+			err = s.parseAlterTable(p)
+		case p.isKeyword(0, "DROP"):
+			err = s.parseDrop(p)
+		case p.isKeywords("RENAME", "TABLE"):
+			err = s.parseRenameTable(p)
+		case p.isKeyword(0, "USE"):
+			p.next()
+			var schema string
+			if schema, _, err = p.identifier(); err == nil {
+				s.schema = schema
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		p.skipStatement()
+	}
+
+	return nil
+}
+
+func (s *MariadbScript) GetTable(schema, name string) (*Table, error) {
+	if t := s.findTable(schema, name); t != nil {
+		return t.Table, nil
+	}
+
+	return nil, fmt.Errorf("%s.%s was not found", schema, name)
+}
+
+func (s *MariadbScript) GetTables(schema string) ([]*Table, error) {
+	rtc := []*Table{}
+	for _, t := range s.tables {
+		if t.Schema == schema {
+			rtc = append(rtc, t.Table)
+		}
+	}
+
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+	return rtc, nil
+}
+
+func (s *MariadbScript) findTable(schema, name string) *mariadbScriptTable {
+	for _, t := range s.tables {
+		if t.Schema == schema && t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// tableByName returns the table for the optional schema and the name or an error
+// if the table does not exist
+func (s *MariadbScript) tableByName(p *sqlParser, schema, name string) (*mariadbScriptTable, error) {
+	if schema == "" {
+		schema = s.schema
+	}
+
+	if t := s.findTable(schema, name); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("line %d: table %s.%s was not found", p.peek().line, schema, name)
+}
+
+func (s *MariadbScript) parseCreate(p *sqlParser) error {
+	p.next()
+	p.acceptKeywords("OR", "REPLACE")
+	p.acceptKeywords("TEMPORARY")
+
+	switch {
+	case p.acceptKeywords("TABLE"):
+		return s.parseCreateTable(p)
+	case p.isKeyword(0, "INDEX") || p.isKeywords("UNIQUE", "INDEX") || p.isKeywords("FULLTEXT", "INDEX") || p.isKeywords("SPATIAL", "INDEX"):
+		return s.parseCreateIndex(p)
+	}
+
+	// Any other object is ignored
+	return nil
+}
+
+func (s *MariadbScript) parseCreateTable(p *sqlParser) error {
+	ifNotExists := p.acceptKeywords("IF", "NOT", "EXISTS")
+
+	schema, name, _, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if schema == "" {
+		schema = s.schema
+	}
+
+	// Remove an existing table ("CREATE OR REPLACE")
+	if existing := s.findTable(schema, name); existing != nil {
+		if ifNotExists {
+			return nil
+		}
+		s.removeTable(existing)
+	}
+
+	tbl := &mariadbScriptTable{
+		Table: &Table{
+			Name:   name,
+			Schema: schema,
+			Kind:   TableKindTable,
+		},
+	}
+
+	// Copy the structure of another table
+	if p.acceptKeywords("LIKE") || (p.isSymbol("(") && p.isKeyword(1, "LIKE")) {
+		brackets := p.acceptSymbol("(")
+		p.acceptKeywords("LIKE")
+
+		likeSchema, likeName, _, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		like, err := s.tableByName(p, likeSchema, likeName)
+		if err != nil {
+			return err
+		}
+		if brackets {
+			if err := p.expectSymbol(")"); err != nil {
+				return err
+			}
+		}
+
+		s.copyTable(like, tbl)
+		s.tables = append(s.tables, tbl)
+		return nil
+	}
+
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for {
+		if err := s.parseTableElement(p, tbl); err != nil {
+			return err
+		}
+
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+
+	s.tables = append(s.tables, tbl)
+	tbl.apply()
+
+	// Table options are ignored
+	return nil
+}
+
+// parseTableElement parses a column or constraint definition within
+// a "CREATE TABLE" statement
+func (s *MariadbScript) parseTableElement(p *sqlParser, tbl *mariadbScriptTable) error {
+	if isConstraint, err := s.parseConstraint(p, tbl); isConstraint || err != nil {
+		return err
+	}
+
+	column, err := s.parseColumn(p, tbl)
+	if err != nil {
+		return err
+	}
+	tbl.Columns = append(tbl.Columns, column.Column)
+
+	return nil
+}
+
+// parseConstraint parses an index or constraint definition. If the current tokens
+// are not the start of a constraint, false is returned
+func (s *MariadbScript) parseConstraint(p *sqlParser, tbl *mariadbScriptTable) (bool, error) {
+	constraintName := ""
+	if p.acceptKeywords("CONSTRAINT") {
+		// The name is optional
+		if !p.isKeyword(0, "PRIMARY") && !p.isKeyword(0, "UNIQUE") && !p.isKeyword(0, "FOREIGN") && !p.isKeyword(0, "CHECK") {
+			name, _, err := p.identifier()
+			if err != nil {
+				return true, err
+			}
+			constraintName = name
+		}
+	}
+
+	switch {
+	case p.acceptKeywords("PRIMARY", "KEY"):
+		s.parseIndexType(p)
+		columns, err := p.identifierList()
+		if err != nil {
+			return true, err
+		}
+		tbl.primaryKey = tokenValues(columns)
+	case p.acceptKeywords("UNIQUE"):
+		if !p.acceptKeywords("KEY") {
+			p.acceptKeywords("INDEX")
+		}
+		return true, s.parseIndex(p, tbl, constraintName, true, "BTREE")
+	case p.acceptKeywords("KEY") || p.acceptKeywords("INDEX"):
+		return true, s.parseIndex(p, tbl, constraintName, false, "BTREE")
+	case p.isKeyword(0, "FULLTEXT") || p.isKeyword(0, "SPATIAL"):
+		typ := strings.ToUpper(p.next().value)
+		if !p.acceptKeywords("KEY") {
+			p.acceptKeywords("INDEX")
+		}
+		return true, s.parseIndex(p, tbl, constraintName, false, typ)
+	case p.acceptKeywords("FOREIGN", "KEY"):
+		// The index name is used if no constraint name is given
+		if !p.isSymbol("(") {
+			name, _, err := p.identifier()
+			if err != nil {
+				return true, err
+			}
+			if constraintName == "" {
+				constraintName = name
+			}
+		}
+		columns, err := p.identifierList()
+		if err != nil {
+			return true, err
+		}
+
+		fk, err := s.parseReference(p, tbl)
+		if err != nil {
+			return true, err
+		}
+		fk.name = constraintName
+		fk.columns = tokenValues(columns)
+		tbl.foreignKeys = append(tbl.foreignKeys, fk)
+	case p.acceptKeywords("CHECK"):
+		expression, err := p.skipBrackets()
+		if err != nil {
+			return true, err
+		}
+
+		// Table constraints without a name are numbered
+		if constraintName == "" {
+			tbl.unnamedChecks++
+			constraintName = fmt.Sprintf("CONSTRAINT_%d", tbl.unnamedChecks)
+		}
+		tbl.checks = append(tbl.checks, &CheckConstraint{Name: constraintName, Expression: expression})
+	case p.isKeywords("PERIOD", "FOR"):
+		p.expression()
+	default:
+		if constraintName != "" {
+			return true, p.errorf("expected constraint definition")
+		}
+		return false, nil
+	}
+
+	// Skip index options
+	p.expression()
+	return true, nil
+}
+
+// parseIndex parses the name and the columns of an index
+func (s *MariadbScript) parseIndex(p *sqlParser, tbl *mariadbScriptTable, name string, unique bool, typ string) error {
+	if !p.isSymbol("(") && !p.isKeyword(0, "USING") {
+		n, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		name = n
+	}
+	if t := s.parseIndexType(p); t != "" {
+		typ = t
+	}
+
+	columns, err := p.identifierList()
+	if err != nil {
+		return err
+	}
+
+	// The index type can also be specified after the columns
+	if t := s.parseIndexType(p); t != "" {
+		typ = t
+	}
+
+	tbl.addIndex(&mariadbScriptIndex{
+		name:    name,
+		columns: tokenValues(columns),
+		unique:  unique,
+		typ:     typ,
+	})
+
+	// Skip index options
+	p.expression()
+	return nil
+}
+
+// parseIndexType parses the optional index type "USING BTREE". An empty
+// string is returned if no type is specified
+func (s *MariadbScript) parseIndexType(p *sqlParser) string {
+	if p.acceptKeywords("USING") {
+		return strings.ToUpper(p.next().value)
+	}
+	return ""
+}
+
+// parseReference parses the reference definition of a foreign key starting
+// at "REFERENCES"
+func (s *MariadbScript) parseReference(p *sqlParser, tbl *mariadbScriptTable) (*mariadbScriptForeignKey, error) {
+	if err := p.expectKeywords("REFERENCES"); err != nil {
+		return nil, err
+	}
+
+	refSchema, refTable, _, err := p.qualifiedName()
+	if err != nil {
+		return nil, err
+	}
+	if refSchema == "" {
+		refSchema = tbl.Schema
+	}
+
+	refColumns, err := p.identifierList()
+	if err != nil {
+		return nil, err
+	}
+
+	fk := &mariadbScriptForeignKey{
+		refSchema:  refSchema,
+		refTable:   refTable,
+		refColumns: tokenValues(refColumns),
+		onDelete:   "RESTRICT",
+		onUpdate:   "RESTRICT",
+	}
+
+	// Skip the match type and parse the referential actions
+	for {
+		switch {
+		case p.acceptKeywords("MATCH"):
+			p.next()
+		case p.acceptKeywords("ON", "DELETE"):
+			fk.onDelete = s.parseReferenceOption(p)
+		case p.acceptKeywords("ON", "UPDATE"):
+			fk.onUpdate = s.parseReferenceOption(p)
+		default:
+			return fk, nil
+		}
+	}
+}
+
+// parseReferenceOption parses the action of an "ON DELETE" or "ON UPDATE" clause
+func (s *MariadbScript) parseReferenceOption(p *sqlParser) string {
+	for _, action := range [][]string{{"SET", "NULL"}, {"SET", "DEFAULT"}, {"NO", "ACTION"}} {
+		if p.acceptKeywords(action...) {
+			return strings.Join(action, " ")
+		}
+	}
+
+	return strings.ToUpper(p.next().value)
+}
+
+// parseColumn parses a column definition
+func (s *MariadbScript) parseColumn(p *sqlParser, tbl *mariadbScriptTable) (*MariadbColumn, error) {
+	column := (&Mariadb{}).newColumn()
+
+	name, _, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	column.Name = name
+	column.CanBeNull = true
+
+	// MariaDB validates the alias "json" with a check constraint named after the column
+	if p.isKeyword(0, "JSON") {
+		tbl.dropCheck(column.Name)
+		tbl.checks = append(tbl.checks, &CheckConstraint{Name: column.Name, Expression: "json_valid(" + DialectMariadb.Quote(column.Name) + ")"})
+	}
+	if _, err := s.parseDataType(p, column); err != nil {
+		return nil, err
+	}
+	column.Type = (&Mariadb{}).GetDataType(column.InternalType)
+	if column.Type == DecimalType {
+		column.NumericPrecision = column.DataTypeLenght
+	}
+	if column.Type == EnumType || column.Type == SetType {
+		column.EnumValues = parseMariadbEnumValues(column.InternalType)
+	}
+
+	// Column attributes till the end of the definition or the position of an "ALTER TABLE"
+	for !p.isSymbol(",") && !p.isSymbol(")") && !p.isSymbol(";") && !p.eof() && !p.isKeyword(0, "FIRST") && !p.isKeyword(0, "AFTER") {
+		switch {
+		case p.acceptKeywords("NOT", "NULL"):
+			column.CanBeNull = false
+		case p.acceptKeywords("NULL"):
+			column.CanBeNull = true
+		case p.acceptKeywords("DEFAULT"):
+			column.DefaultValue = s.parseDefault(p)
+		case p.acceptKeywords("AUTO_INCREMENT"):
+			column.AutoIncrement = true
+		case p.acceptKeywords("PRIMARY", "KEY"), p.acceptKeywords("KEY"):
+			tbl.primaryKey = []string{column.Name}
+			column.CanBeNull = false
+		case p.acceptKeywords("UNIQUE"):
+			p.acceptKeywords("KEY")
+			tbl.addIndex(&mariadbScriptIndex{columns: []string{column.Name}, unique: true, typ: "BTREE"})
+		case p.acceptKeywords("COMMENT"):
+			t := p.next()
+			if t.typ != tokenString {
+				return nil, p.errorf("expected comment string")
+			}
+			column.Comment = t.value
+		case p.acceptKeywords("ON", "UPDATE"):
+			s.parseDefault(p)
+		case p.acceptKeywords("GENERATED", "ALWAYS", "AS"), p.acceptKeywords("AS"):
+			expression, err := p.skipBrackets()
+			if err != nil {
+				return nil, err
+			}
+			column.GenerationExpression = expression
+			if !p.acceptKeywords("VIRTUAL") {
+				column.GenerationStored = p.acceptKeywords("PERSISTENT") || p.acceptKeywords("STORED")
+			}
+		case p.acceptKeywords("INVISIBLE"):
+			column.Invisible = true
+		case p.acceptKeywords("CONSTRAINT"):
+			if !p.isKeyword(0, "CHECK") {
+				p.next()
+			}
+		case p.acceptKeywords("CHECK"):
+			expression, err := p.skipBrackets()
+			if err != nil {
+				return nil, err
+			}
+
+			// Column constraints are always named after the column
+			tbl.dropCheck(column.Name)
+			tbl.checks = append(tbl.checks, &CheckConstraint{Name: column.Name, Expression: expression})
+		case p.isKeyword(0, "REFERENCES"):
+			fk, err := s.parseReference(p, tbl)
+			if err != nil {
+				return nil, err
+			}
+			fk.columns = []string{column.Name}
+			tbl.foreignKeys = append(tbl.foreignKeys, fk)
+		case p.acceptKeywords("COLLATE"), p.acceptKeywords("CHARACTER", "SET"), p.acceptKeywords("CHARSET"),
+			p.acceptKeywords("COLUMN_FORMAT"), p.acceptKeywords("STORAGE"):
+			p.next()
+		case p.acceptKeywords("SERIAL", "DEFAULT", "VALUE"),
+			p.acceptKeywords("WITH", "SYSTEM", "VERSIONING"), p.acceptKeywords("WITHOUT", "SYSTEM", "VERSIONING"):
+		default:
+			return nil, p.errorf("unknown attribute for column %q", column.Name)
+		}
+	}
+
+	return column, nil
+}
+
+// mariadbDisplayWidth contains the default display width of the integer types
+// for signed and unsigned columns
+var mariadbDisplayWidth = map[string][2]int{
+	"tinyint":   {4, 3},
+	"smallint":  {6, 5},
+	"mediumint": {9, 8},
+	"int":       {11, 10},
+	"bigint":    {20, 20},
+}
+
+// mariadbNumericPrecision contains the numeric precision of the integer types
+var mariadbNumericPrecision = map[string]int{
+	"tinyint":   3,
+	"smallint":  5,
+	"mediumint": 7,
+	"int":       10,
+	"bigint":    19,
+	"float":     12,
+	"double":    22,
+}
+
+// mariadbTextLength contains the maximum length of the text and blob types
+var mariadbTextLength = map[string]int{
+	"tinytext":   255,
+	"tinyblob":   255,
+	"text":       65535,
+	"blob":       65535,
+	"mediumtext": 16777215,
+	"mediumblob": 16777215,
+	"longtext":   4294967295,
+	"longblob":   4294967295,
+}
+
+// mariadbTypeAliases maps the synonyms of data types to the name used by MariaDB
+var mariadbTypeAliases = map[string]string{
+	"integer":   "int",
+	"int1":      "tinyint",
+	"int2":      "smallint",
+	"int3":      "mediumint",
+	"middleint": "mediumint",
+	"int4":      "int",
+	"int8":      "bigint",
+	"dec":       "decimal",
+	"numeric":   "decimal",
+	"fixed":     "decimal",
+	"real":      "double",
+	"character": "char",
+	"nchar":     "char",
+	"nvarchar":  "varchar",
+	"long":      "mediumtext",
+	"json":      "longtext",
+}
+
+// parseDataType parses the data type of a column and sets the internal type and
+// length of the column like it's returned by the information_schema.
+// The returned data type is the name of the type without any arguments
+func (s *MariadbScript) parseDataType(p *sqlParser, column *MariadbColumn) (string, error) {
+	t := p.next()
+	if t.typ != tokenIdent {
+		return "", p.errorf("expected data type for column %q", column.Name)
+	}
+	dataType := strings.ToLower(t.value)
+
+	// Data types consisting of multiple words
+	switch {
+	case dataType == "double" && p.acceptKeywords("PRECISION"):
+	case (dataType == "character" || dataType == "char") && p.acceptKeywords("VARYING"):
+		dataType = "varchar"
+	case dataType == "national":
+		if t := p.next(); strings.EqualFold(t.value, "varchar") {
+			dataType = "varchar"
+		} else {
+			dataType = "char"
+		}
+	case dataType == "long" && p.acceptKeywords("VARCHAR"):
+	case dataType == "bool" || dataType == "boolean":
+		column.InternalType = "tinyint(1)"
+		column.DataTypeLenght = 3
+		return "tinyint", nil
+	}
+	if alias, ok := mariadbTypeAliases[dataType]; ok {
+		dataType = alias
+	}
+
+	// Arguments of the data type
+	args := []string{}
+	if p.acceptSymbol("(") {
+		for !p.acceptSymbol(")") {
+			t := p.next()
+			switch {
+			case t.typ == tokenEOF:
+				return "", p.errorf("missing closing bracket for data type")
+			case t.typ == tokenString:
+				args = append(args, "'"+strings.ReplaceAll(t.value, "'", "''")+"'")
+			case t.typ == tokenNumber:
+				args = append(args, t.value)
+			}
+		}
+	}
+
+	// Attributes of the data type
+	unsigned, zerofill := false, false
+	for {
+		switch {
+		case p.acceptKeywords("UNSIGNED"):
+			unsigned = true
+		case p.acceptKeywords("ZEROFILL"):
+			zerofill = true
+			unsigned = true
+		case p.acceptKeywords("SIGNED"), p.acceptKeywords("BINARY"), p.acceptKeywords("ASCII"), p.acceptKeywords("UNICODE"):
+		case p.acceptKeywords("CHARACTER", "SET"), p.acceptKeywords("CHARSET"), p.acceptKeywords("COLLATE"):
+			p.next()
+		default:
+			column.InternalType = s.formatDataType(dataType, args, unsigned, zerofill)
+			column.DataTypeLenght = s.getDataTypeLength(dataType, args, unsigned)
+			if dataType == "decimal" && len(args) > 1 {
+				column.NumericScale, _ = strconv.Atoi(args[1])
+			}
+			return dataType, nil
+		}
+	}
+}
+
+// formatDataType returns the full column type like it's returned by the
+// information_schema ("int(10) unsigned")
+func (s *MariadbScript) formatDataType(dataType string, args []string, unsigned, zerofill bool) string {
+	switch dataType {
+	case "tinyint", "smallint", "mediumint", "int", "bigint":
+		if len(args) == 0 {
+			width := mariadbDisplayWidth[dataType]
+			if unsigned {
+				args = []string{strconv.Itoa(width[1])}
+			} else {
+				args = []string{strconv.Itoa(width[0])}
+			}
+		}
+	case "decimal":
+		if len(args) == 0 {
+			args = []string{"10"}
+		}
+		if len(args) == 1 {
+			args = append(args, "0")
+		}
+	case "char", "binary", "bit":
+		if len(args) == 0 {
+			args = []string{"1"}
+		}
+	case "year":
+		args = []string{"4"}
+	}
+
+	rtc := dataType
+	if len(args) != 0 {
+		rtc += "(" + strings.Join(args, ",") + ")"
+	}
+	if unsigned {
+		rtc += " unsigned"
+	}
+	if zerofill {
+		rtc += " zerofill"
+	}
+
+	return rtc
+}
+
+// getDataTypeLength returns the character length or the numeric precision of the
+// data type
+func (s *MariadbScript) getDataTypeLength(dataType string, args []string, unsigned bool) int {
+	firstArg := 0
+	if len(args) != 0 {
+		firstArg, _ = strconv.Atoi(args[0])
+	}
+
+	switch dataType {
+	case "tinyint", "smallint", "mediumint", "int":
+		return mariadbNumericPrecision[dataType]
+	case "bigint":
+		if unsigned {
+			return 20
+		}
+		return 19
+	case "float", "double":
+		if firstArg != 0 {
+			return firstArg
+		}
+		return mariadbNumericPrecision[dataType]
+	case "decimal":
+		if firstArg != 0 {
+			return firstArg
+		}
+		return 10
+	case "char", "binary", "bit":
+		if firstArg != 0 {
+			return firstArg
+		}
+		return 1
+	case "enum", "set":
+		// The maximum length of a value (enum) or all values seperated by a comma (set)
+		length := 0
+		for _, a := range args {
+			l := len([]rune(strings.ReplaceAll(a[1:len(a)-1], "''", "'")))
+			if dataType == "enum" {
+				length = max(length, l)
+			} else {
+				length += l
+			}
+		}
+		if dataType == "set" && len(args) > 1 {
+			length += len(args) - 1
+		}
+		return length
+	}
+
+	if length, ok := mariadbTextLength[dataType]; ok {
+		return length
+	}
+	return firstArg
+}
+
+// parseDefault parses the default value of a column like it's returned
+// by the information_schema
+func (s *MariadbScript) parseDefault(p *sqlParser) sql.NullString {
+	t := p.peek()
+
+	switch {
+	case t.typ == tokenString:
+		p.next()
+		return sql.NullString{Valid: true, String: t.value}
+	case p.acceptKeywords("NULL"):
+		return sql.NullString{}
+	case p.isSymbol("("):
+		expr, _ := p.skipBrackets()
+		return sql.NullString{Valid: true, String: expr}
+	case p.isSymbol("-") || p.isSymbol("+"):
+		p.next()
+		number := p.next()
+		return sql.NullString{Valid: true, String: strings.TrimPrefix(t.value+number.value, "+")}
+	case t.typ == tokenIdent:
+		p.next()
+		value := strings.ToLower(t.value)
+		if p.isSymbol("(") {
+			args, _ := p.skipBrackets()
+			value += "(" + args + ")"
+		}
+
+		// MariaDB returns the function for the synonyms of the current timestamp
+		switch value {
+		case "current_timestamp", "current_timestamp()", "now()", "localtime", "localtime()", "localtimestamp", "localtimestamp()":
+			return sql.NullString{Valid: true, String: "current_timestamp()"}
+		}
+		return sql.NullString{Valid: true, String: value}
+	default:
+		p.next()
+		return sql.NullString{Valid: true, String: t.value}
+	}
+}
+
+func (s *MariadbScript) parseCreateIndex(p *sqlParser) error {
+	unique := p.acceptKeywords("UNIQUE")
+	typ := "BTREE"
+	if p.isKeyword(0, "FULLTEXT") || p.isKeyword(0, "SPATIAL") {
+		typ = strings.ToUpper(p.next().value)
+	}
+	if err := p.expectKeywords("INDEX"); err != nil {
+		return err
+	}
+	p.acceptKeywords("IF", "NOT", "EXISTS")
+
+	name, _, err := p.identifier()
+	if err != nil {
+		return err
+	}
+	if t := s.parseIndexType(p); t != "" {
+		typ = t
+	}
+	if err := p.expectKeywords("ON"); err != nil {
+		return err
+	}
+
+	schema, tableName, _, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	tbl, err := s.tableByName(p, schema, tableName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.parseIndex(p, tbl, name, unique, typ); err != nil {
+		return err
+	}
+	tbl.apply()
+
+	return nil
+}
+
+func (s *MariadbScript) parseDrop(p *sqlParser) error {
+	p.next()
+	p.acceptKeywords("TEMPORARY")
+
+	switch {
+	case p.acceptKeywords("TABLE"):
+		ifExists := p.acceptKeywords("IF", "EXISTS")
+		for {
+			schema, name, _, err := p.qualifiedName()
+			if err != nil {
+				return err
+			}
+			tbl, err := s.tableByName(p, schema, name)
+			if err != nil && !ifExists {
+				return err
+			} else if err == nil {
+				s.removeTable(tbl)
+			}
+
+			if !p.acceptSymbol(",") {
+				return nil
+			}
+		}
+	case p.acceptKeywords("INDEX"):
+		ifExists := p.acceptKeywords("IF", "EXISTS")
+		name, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		if err := p.expectKeywords("ON"); err != nil {
+			return err
+		}
+		schema, tableName, _, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		tbl, err := s.tableByName(p, schema, tableName)
+		if err != nil {
+			return err
+		}
+
+		if !tbl.dropIndex(name) && !ifExists {
+			return fmt.Errorf("line %d: index %q was not found", p.peek().line, name)
+		}
+		tbl.apply()
+	}
+
+	return nil
+}
+
+func (s *MariadbScript) parseRenameTable(p *sqlParser) error {
+	p.acceptKeywords("RENAME", "TABLE")
+
+	for {
+		schema, name, _, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		tbl, err := s.tableByName(p, schema, name)
+		if err != nil {
+			return err
+		}
+
+		if err := p.expectKeywords("TO"); err != nil {
+			return err
+		}
+		newSchema, newName, _, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		s.renameTable(tbl, newSchema, newName)
+
+		if !p.acceptSymbol(",") {
+			return nil
+		}
+	}
+}
+
+func (s *MariadbScript) parseAlterTable(p *sqlParser) error {
+	p.next()
+	if !p.acceptKeywords("ONLINE") {
+		p.acceptKeywords("IGNORE")
+	}
+	if !p.acceptKeywords("TABLE") {
+		// Any other object is ignored
+		return nil
+	}
+	ifExists := p.acceptKeywords("IF", "EXISTS")
+
+	schema, name, _, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	tbl, err := s.tableByName(p, schema, name)
+	if err != nil {
+		if ifExists {
+			return nil
+		}
+		return err
+	}
+
+	for !p.eof() && !p.isSymbol(";") {
+		if err := s.parseAlterSpecification(p, tbl); err != nil {
+			return err
+		}
+		tbl.apply()
+
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+
+	return nil
+}
+
+// parseAlterSpecification parses a single modification of an "ALTER TABLE" statement
+func (s *MariadbScript) parseAlterSpecification(p *sqlParser, tbl *mariadbScriptTable) error {
+	switch {
+	case p.acceptKeywords("ADD"):
+		// Constraints
+		if isConstraint, err := s.parseConstraint(p, tbl); isConstraint || err != nil {
+			return err
+		}
+
+		p.acceptKeywords("COLUMN")
+		p.acceptKeywords("IF", "NOT", "EXISTS")
+
+		// Multiple columns within brackets
+		if p.acceptSymbol("(") {
+			for {
+				if err := s.parseTableElement(p, tbl); err != nil {
+					return err
+				}
+				if p.acceptSymbol(")") {
+					return nil
+				}
+				if err := p.expectSymbol(","); err != nil {
+					return err
+				}
+			}
+		}
+
+		column, err := s.parseColumn(p, tbl)
+		if err != nil {
+			return err
+		}
+		if tbl.column(column.Name) != nil {
+			return fmt.Errorf("line %d: column %q does already exist", p.peek().line, column.Name)
+		}
+		return s.placeColumn(p, tbl, column.Column, len(tbl.Columns))
+
+	case p.acceptKeywords("DROP"):
+		switch {
+		case p.acceptKeywords("PRIMARY", "KEY"):
+			tbl.primaryKey = nil
+		case p.acceptKeywords("FOREIGN", "KEY"), p.acceptKeywords("INDEX"), p.acceptKeywords("KEY"),
+			p.acceptKeywords("CONSTRAINT"), p.acceptKeywords("CHECK"):
+			ifExists := p.acceptKeywords("IF", "EXISTS")
+			name, _, err := p.identifier()
+			if err != nil {
+				return err
+			}
+			if !tbl.dropIndex(name) && !tbl.dropCheck(name) && !ifExists {
+				// Constraints that are not known are ignored
+				return nil
+			}
+		default:
+			p.acceptKeywords("COLUMN")
+			ifExists := p.acceptKeywords("IF", "EXISTS")
+			name, _, err := p.identifier()
+			if err != nil {
+				return err
+			}
+			if !tbl.dropColumn(name) && !ifExists {
+				return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+			}
+		}
+
+	case p.acceptKeywords("MODIFY"):
+		p.acceptKeywords("COLUMN")
+		p.acceptKeywords("IF", "EXISTS")
+		column, err := s.parseColumn(p, tbl)
+		if err != nil {
+			return err
+		}
+		return s.replaceColumn(p, tbl, column.Name, column)
+
+	case p.acceptKeywords("CHANGE"):
+		p.acceptKeywords("COLUMN")
+		p.acceptKeywords("IF", "EXISTS")
+		oldName, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		column, err := s.parseColumn(p, tbl)
+		if err != nil {
+			return err
+		}
+		return s.replaceColumn(p, tbl, oldName, column)
+
+	case p.acceptKeywords("RENAME", "COLUMN"):
+		oldName, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		if err := p.expectKeywords("TO"); err != nil {
+			return err
+		}
+		newName, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		col := tbl.column(oldName)
+		if col == nil {
+			return fmt.Errorf("line %d: column %q was not found", p.peek().line, oldName)
+		}
+		col.Name = newName
+		tbl.renameColumnReferences(oldName, newName)
+
+	case p.acceptKeywords("RENAME", "INDEX"), p.acceptKeywords("RENAME", "KEY"):
+		oldName, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		if err := p.expectKeywords("TO"); err != nil {
+			return err
+		}
+		newName, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		for _, idx := range tbl.indexes {
+			if strings.EqualFold(idx.name, oldName) {
+				idx.name = newName
+			}
+		}
+
+	case p.acceptKeywords("RENAME"):
+		if !p.acceptKeywords("TO") {
+			p.acceptKeywords("AS")
+		}
+		schema, name, _, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		s.renameTable(tbl, schema, name)
+
+	case p.acceptKeywords("ALTER"):
+		p.acceptKeywords("COLUMN")
+		name, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		col := tbl.column(name)
+		if col == nil {
+			return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+		}
+
+		switch {
+		case p.acceptKeywords("SET", "DEFAULT"):
+			col.DefaultValue = s.parseDefault(p)
+		case p.acceptKeywords("DROP", "DEFAULT"):
+			col.DefaultValue = sql.NullString{}
+		default:
+			p.expression()
+		}
+
+	default:
+		// Table options and other modifications without an effect on the columns
+		p.expression()
+	}
+
+	return nil
+}
+
+// replaceColumn replaces the column identified by the name with the new column
+func (s *MariadbScript) replaceColumn(p *sqlParser, tbl *mariadbScriptTable, name string, column *MariadbColumn) error {
+	for i, c := range tbl.Columns {
+		if strings.EqualFold(c.Name, name) {
+			tbl.Columns = append(tbl.Columns[:i], tbl.Columns[i+1:]...)
+			tbl.renameColumnReferences(name, column.Name)
+			return s.placeColumn(p, tbl, column.Column, i)
+		}
+	}
+
+	return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+}
+
+// placeColumn inserts the column at the position specified with "FIRST" or "AFTER".
+// If no position is specified, the column is inserted at the provided index
+func (s *MariadbScript) placeColumn(p *sqlParser, tbl *mariadbScriptTable, column *Column, index int) error {
+	if p.acceptKeywords("FIRST") {
+		index = 0
+	} else if p.acceptKeywords("AFTER") {
+		name, _, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		index = -1
+		for i, c := range tbl.Columns {
+			if strings.EqualFold(c.Name, name) {
+				index = i + 1
+			}
+		}
+		if index == -1 {
+			return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+		}
+	}
+
+	tbl.Columns = append(tbl.Columns[:index], append([]*Column{column}, tbl.Columns[index:]...)...)
+	return nil
+}
+
+func (s *MariadbScript) removeTable(tbl *mariadbScriptTable) {
+	for i, t := range s.tables {
+		if t == tbl {
+			s.tables = append(s.tables[:i], s.tables[i+1:]...)
+			return
+		}
+	}
+}
+
+// renameTable renames the table and updates all foreign keys that points to it
+func (s *MariadbScript) renameTable(tbl *mariadbScriptTable, schema, name string) {
+	if schema == "" {
+		schema = tbl.Schema
+	}
+
+	for _, t := range s.tables {
+		for _, fk := range t.foreignKeys {
+			if fk.refSchema == tbl.Schema && fk.refTable == tbl.Name {
+				fk.refSchema = schema
+				fk.refTable = name
+			}
+		}
+	}
+	tbl.Schema = schema
+	tbl.Name = name
+
+	for _, t := range s.tables {
+		t.apply()
+	}
+}
+
+// copyTable copies all columns and indexes from the source table ("CREATE TABLE ... LIKE")
+func (s *MariadbScript) copyTable(source *mariadbScriptTable, target *mariadbScriptTable) {
+	for _, c := range source.Columns {
+		col := (&Mariadb{}).newColumn()
+		*col = *c.Extras.(*MariadbColumn)
+		colCopy := *c
+		col.Column = &colCopy
+		col.Column.Extras = col
+		target.Columns = append(target.Columns, col.Column)
+	}
+
+	target.primaryKey = append(target.primaryKey, source.primaryKey...)
+	for _, c := range source.checks {
+		target.checks = append(target.checks, &CheckConstraint{Name: c.Name, Expression: c.Expression})
+	}
+	target.unnamedChecks = source.unnamedChecks
+	for _, idx := range source.indexes {
+		target.indexes = append(target.indexes, &mariadbScriptIndex{
+			name:    idx.name,
+			columns: append([]string{}, idx.columns...),
+			unique:  idx.unique,
+			typ:     idx.typ,
+		})
+	}
+	target.apply()
+}
+
+// dropCheck removes the check constraint with the name
+func (t *mariadbScriptTable) dropCheck(name string) bool {
+	for i, c := range t.checks {
+		if strings.EqualFold(c.Name, name) {
+			t.checks = append(t.checks[:i], t.checks[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// column returns the column with the name or nil if it does not exist
+func (t *mariadbScriptTable) column(name string) *Column {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// addIndex adds the index to the table. Indexes without a name are named
+// after the first column like MariaDB does
+func (t *mariadbScriptTable) addIndex(index *mariadbScriptIndex) {
+	if index.name == "" {
+		index.name = index.columns[0]
+		for i := 2; t.hasIndex(index.name); i++ {
+			index.name = fmt.Sprintf("%s_%d", index.columns[0], i)
+		}
+	}
+
+	t.indexes = append(t.indexes, index)
+}
+
+// hasIndex returns weather an index with the name exists
+func (t *mariadbScriptTable) hasIndex(name string) bool {
+	for _, idx := range t.indexes {
+		if strings.EqualFold(idx.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropIndex removes the index or foreign key with the provided name
+func (t *mariadbScriptTable) dropIndex(name string) bool {
+	found := false
+
+	for i := len(t.indexes) - 1; i >= 0; i-- {
+		if strings.EqualFold(t.indexes[i].name, name) {
+			t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+			found = true
+		}
+	}
+	for i := len(t.foreignKeys) - 1; i >= 0; i-- {
+		if strings.EqualFold(t.foreignKeys[i].name, name) {
+			t.foreignKeys = append(t.foreignKeys[:i], t.foreignKeys[i+1:]...)
+			found = true
+		}
+	}
+
+	return found
+}
+
+// dropColumn removes the column and all references within the indexes
+func (t *mariadbScriptTable) dropColumn(name string) bool {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+
+			t.primaryKey = removeString(t.primaryKey, name)
+			for j := len(t.indexes) - 1; j >= 0; j-- {
+				t.indexes[j].columns = removeString(t.indexes[j].columns, name)
+				if len(t.indexes[j].columns) == 0 {
+					t.indexes = append(t.indexes[:j], t.indexes[j+1:]...)
+				}
+			}
+			for j := len(t.foreignKeys) - 1; j >= 0; j-- {
+				for _, c := range t.foreignKeys[j].columns {
+					if strings.EqualFold(c, name) {
+						t.foreignKeys = append(t.foreignKeys[:j], t.foreignKeys[j+1:]...)
+						break
+					}
+				}
+			}
+			t.dropCheck(name)
+			return true
+		}
+	}
+
+	return false
+}
+
+// renameColumnReferences renames the column within all indexes and constraints
+func (t *mariadbScriptTable) renameColumnReferences(oldName, newName string) {
+	rename := func(columns []string) {
+		for i, c := range columns {
+			if strings.EqualFold(c, oldName) {
+				columns[i] = newName
+			}
+		}
+	}
+
+	rename(t.primaryKey)
+	for _, idx := range t.indexes {
+		rename(idx.columns)
+	}
+	for _, fk := range t.foreignKeys {
+		rename(fk.columns)
+	}
+}
+
+// apply sets the key information of all columns based on the constraints
+// of the table
+func (t *mariadbScriptTable) apply() {
+	// A unique index without nullable columns is shown as primary
+	// key when no primary key exists
+	primaryKey := t.primaryKey
+	if len(primaryKey) == 0 {
+		for _, idx := range t.indexes {
+			if !idx.unique {
+				continue
+			}
+
+			notNull := true
+			for _, name := range idx.columns {
+				if c := t.column(name); c == nil || c.CanBeNull {
+					notNull = false
+				}
+			}
+			if notNull {
+				primaryKey = idx.columns
+				break
+			}
+		}
+	}
+
+	for _, c := range t.Columns {
+		col := c.Extras.(*MariadbColumn)
+
+		// Key type with the priority PRI, UNI, MUL
+		col.KeyType = ""
+		if containsString(primaryKey, c.Name) {
+			col.KeyType = MariadbKeyPrimary
+		} else {
+			for _, idx := range t.indexes {
+				if !strings.EqualFold(idx.columns[0], c.Name) {
+					continue
+				}
+				if idx.unique && len(idx.columns) == 1 {
+					col.KeyType = MariadbKeyUnique
+					break
+				}
+				col.KeyType = MariadbKeyMultipleIndex
+			}
+
+			// Foreign keys always have an index
+			for _, fk := range t.foreignKeys {
+				if col.KeyType == "" && strings.EqualFold(fk.columns[0], c.Name) {
+					col.KeyType = MariadbKeyMultipleIndex
+				}
+			}
+		}
+		c.PrimaryKey = col.KeyType == MariadbKeyPrimary
+
+		// Primary keys can never be null
+		if containsString(t.primaryKey, c.Name) {
+			c.CanBeNull = false
+		}
+
+	}
+
+	t.applyKeys()
+	t.applyIndexes()
+}
+
+// applyKeys sets the primary key, foreign key and check constraints of the table like they
+// are returned by the database. Foreign keys without a name are named
+// "<table>_ibfk_<n>"
+func (t *mariadbScriptTable) applyKeys() {
+	t.Table.PrimaryKey = nil
+	if len(t.primaryKey) != 0 {
+		t.Table.PrimaryKey = &PrimaryKey{
+			Name:    "PRIMARY",
+			Columns: t.columnNames(t.primaryKey),
+		}
+	}
+
+	t.ForeignKeys = nil
+	unnamed := 0
+	for _, fk := range t.foreignKeys {
+		name := fk.name
+		if name == "" {
+			unnamed++
+			name = fmt.Sprintf("%s_ibfk_%d", t.Name, unnamed)
+		}
+
+		t.ForeignKeys = append(t.ForeignKeys, &ForeignKey{
+			Name:              name,
+			Columns:           t.columnNames(fk.columns),
+			ReferencedSchema:  fk.refSchema,
+			ReferencedTable:   fk.refTable,
+			ReferencedColumns: append([]string{}, fk.refColumns...),
+			OnDelete:          fk.onDelete,
+			OnUpdate:          fk.onUpdate,
+		})
+	}
+	sort.SliceStable(t.ForeignKeys, func(i, j int) bool {
+		return strings.ToLower(t.ForeignKeys[i].Name) < strings.ToLower(t.ForeignKeys[j].Name)
+	})
+
+	t.applyForeignKeys()
+
+	t.Checks = nil
+	for _, c := range t.checks {
+		t.Checks = append(t.Checks, &CheckConstraint{
+			Name:       c.Name,
+			Expression: c.Expression,
+			Columns:    t.expressionColumns(c.Expression, mariadbDialect),
+		})
+	}
+	sort.SliceStable(t.Checks, func(i, j int) bool {
+		return strings.ToLower(t.Checks[i].Name) < strings.ToLower(t.Checks[j].Name)
+	})
+	t.applyJsonChecks()
+}
+
+// columnNames returns the names of the columns with the same case as
+// they were defined in the table
+func (t *mariadbScriptTable) columnNames(names []string) []string {
+	rtc := make([]string, len(names))
+	for i, name := range names {
+		rtc[i] = name
+		if c := t.column(name); c != nil {
+			rtc[i] = c.Name
+		}
+	}
+
+	return rtc
+}
+
+// applyIndexes sets the indexes of the table like they are returned by
+// the database. The primary key is always the first index
+func (t *mariadbScriptTable) applyIndexes() {
+	t.Indexes = nil
+	if len(t.primaryKey) != 0 {
+		t.Indexes = append(t.Indexes, &Index{
+			Name:    "PRIMARY",
+			Columns: append([]string{}, t.primaryKey...),
+			Unique:  true,
+			Type:    "BTREE",
+		})
+	}
+
+	indexes := []*Index{}
+	for _, idx := range t.indexes {
+		indexes = append(indexes, &Index{
+			Name:    idx.name,
+			Columns: append([]string{}, idx.columns...),
+			Unique:  idx.unique,
+			Type:    idx.typ,
+		})
+	}
+
+	// An index is created for foreign keys that are not already indexed
+	for _, fk := range t.foreignKeys {
+		if t.isIndexed(fk.columns) {
+			continue
+		}
+
+		name := fk.name
+		if name == "" {
+			name = fk.columns[0]
+		}
+		indexes = append(indexes, &Index{
+			Name:    name,
+			Columns: append([]string{}, fk.columns...),
+			Type:    "BTREE",
+		})
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return strings.ToLower(indexes[i].Name) < strings.ToLower(indexes[j].Name)
+	})
+	t.Indexes = append(t.Indexes, indexes...)
+}
+
+// isIndexed returns weather the columns are the leading columns of an index
+func (t *mariadbScriptTable) isIndexed(columns []string) bool {
+	startsWith := func(indexColumns []string) bool {
+		if len(indexColumns) < len(columns) {
+			return false
+		}
+		for i, c := range columns {
+			if !strings.EqualFold(indexColumns[i], c) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if startsWith(t.primaryKey) {
+		return true
+	}
+	for _, idx := range t.indexes {
+		if startsWith(idx.columns) {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenValues(tokens []sqlToken) []string {
+	rtc := make([]string, len(tokens))
+	for i, t := range tokens {
+		rtc[i] = t.value
+	}
+	return rtc
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	rtc := []string{}
+	for _, v := range values {
+		if !strings.EqualFold(v, value) {
+			rtc = append(rtc, v)
+		}
+	}
+	return rtc
+}
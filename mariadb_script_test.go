@@ -0,0 +1,640 @@
+package ddl
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestParseMariadbScriptSimple tests the construction of a Table struct
+// from a create statement with the same columns used for the database tests
+func TestParseMariadbScriptSimple(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ddl_test (
+			id 		INT(10) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+			txt 	VARCHAR(100) DEFAULT 'Ich bins, der Tim!',
+			dte		DATETIME NOT NULL
+				COMMENT 'Hallo ihr da!\nZeilenumbrüche'
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "ddl_test")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   "ddl_test",
+		Schema: "ddl",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+	}
+	columns := []*MariadbColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int(10)",
+			},
+			AutoIncrement:  true,
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyPrimary,
+		},
+		{
+			Column: &Column{
+				Name:         "txt",
+				PrimaryKey:   false,
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "varchar(100)",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "Ich bins, der Tim!",
+				},
+			},
+			AutoIncrement:  false,
+			DataTypeLenght: 100,
+		},
+		{
+			Column: &Column{
+				Name:         "dte",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         DateType,
+				InternalType: "datetime",
+				Comment:      "Hallo ihr da!\nZeilenumbrüche",
+			},
+			AutoIncrement:  false,
+			DataTypeLenght: 0,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptDoubleQuotes tests string literals within double quotes.
+// They are no identifiers without the SQL mode "ANSI_QUOTES"
+func TestParseMariadbScriptDoubleQuotes(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE quotes (
+			state ENUM("on", "off") DEFAULT "on" COMMENT "It's a ""state""",
+			txt   VARCHAR(10) DEFAULT "Tim" COMMENT "Text"
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "quotes")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	state := table.GetColumn("state")
+	if diff := cmp.Diff([]string{"on", "off"}, state.Extras.(*MariadbColumn).EnumValues); diff != "" {
+		t.Errorf("Mismatch of enum values (-want +got):\n%s", diff)
+	}
+	if state.Type != EnumType || state.DefaultValue != (sql.NullString{Valid: true, String: "on"}) || state.Comment != `It's a "state"` {
+		t.Errorf("Unexpected column %+v", state)
+	}
+	if txt := table.GetColumn("txt"); txt.DefaultValue.String != "Tim" || txt.Comment != "Text" {
+		t.Errorf("Unexpected column %+v", txt)
+	}
+}
+
+// TestParseMariadbScriptFK tests the construction of a Table struct
+// that references another table
+func TestParseMariadbScriptFK(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ref (
+			id_to_ref   INT(10) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+			rand        VARCHAR(10) NOT NULL
+		);
+		CREATE TABLE tbl (
+			id 		 INT(10) PRIMARY KEY NOT NULL AUTO_INCREMENT,
+			other_id INT(10) NOT NULL,
+			CONSTRAINT fk_test_constraint_for_you FOREIGN KEY(other_id) REFERENCES ref(id_to_ref) ON DELETE SET NULL
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   "tbl",
+		Schema: "ddl",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+			{Name: "fk_test_constraint_for_you", Columns: []string{"other_id"}, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "fk_test_constraint_for_you",
+				Columns:           []string{"other_id"},
+				ReferencedSchema:  "ddl",
+				ReferencedTable:   "ref",
+				ReferencedColumns: []string{"id_to_ref"},
+				OnDelete:          "SET NULL",
+				OnUpdate:          "RESTRICT",
+			},
+		},
+	}
+	columns := []*MariadbColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int(10)",
+			},
+			AutoIncrement:  true,
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyPrimary,
+		},
+		{
+			Column: &Column{
+				Name:         "other_id",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int(10)",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   "ref",
+					Schema: "ddl",
+					Column: "id_to_ref",
+				},
+			},
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyMultipleIndex,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptCompositeFK tests keys over multiple columns
+func TestParseMariadbScriptCompositeFK(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ref (
+			tenant  INT(10) NOT NULL,
+			id      INT(10) NOT NULL,
+			PRIMARY KEY (tenant, id)
+		);
+		CREATE TABLE tbl (
+			other_id INT(10) NOT NULL,
+			tenant   INT(10) NOT NULL,
+			PRIMARY KEY (TENANT, other_id),
+			FOREIGN KEY (tenant, other_id) REFERENCES ref (tenant, id) ON UPDATE CASCADE ON DELETE NO ACTION
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	// The column names are returned in the defined case
+	expectedPk := &PrimaryKey{Name: "PRIMARY", Columns: []string{"tenant", "other_id"}}
+	if diff := cmp.Diff(table.PrimaryKey, expectedPk); diff != "" {
+		t.Errorf("Mismatch of primary key (-want +got):\n%s", diff)
+	}
+
+	expectedFks := []*ForeignKey{
+		{
+			Name:              "tbl_ibfk_1",
+			Columns:           []string{"tenant", "other_id"},
+			ReferencedSchema:  "ddl",
+			ReferencedTable:   "ref",
+			ReferencedColumns: []string{"tenant", "id"},
+			OnDelete:          "NO ACTION",
+			OnUpdate:          "CASCADE",
+		},
+	}
+	if diff := cmp.Diff(table.ForeignKeys, expectedFks); diff != "" {
+		t.Errorf("Mismatch of foreign keys (-want +got):\n%s", diff)
+	}
+
+	// Every column references its counterpart
+	expectedRefs := map[string]string{"other_id": "id", "tenant": "tenant"}
+	for _, c := range table.Columns {
+		if !c.PrimaryKey || !c.ForeignKey || c.ForeignKeyColumn.Column != expectedRefs[c.Name] {
+			t.Errorf("Wrong key information of column %q: %+v", c.Name, c)
+		}
+	}
+}
+
+// TestParseMariadbScriptDump tests the parsing of a file created by "mysqldump --no-data"
+// TestParseMariadbScriptChecks tests the naming of check constraints and their columns
+func TestParseMariadbScriptChecks(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE tbl (
+			amount INT CHECK (amount > 0),
+			min    INT,
+			max    INT,
+			txt    VARCHAR(10),
+			CHECK (min <= max),
+			CONSTRAINT chk_txt CHECK (LENGTH(` + "`txt`" + `) > 2),
+			CHECK (max < 100)
+		);
+		ALTER TABLE tbl DROP CONSTRAINT CONSTRAINT_2;
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := []*CheckConstraint{
+		{Name: "amount", Expression: "amount > 0", Columns: []string{"amount"}},
+		{Name: "chk_txt", Expression: "LENGTH(`txt`) > 2", Columns: []string{"txt"}},
+		{Name: "CONSTRAINT_1", Expression: "min <= max", Columns: []string{"min", "max"}},
+	}
+	if diff := cmp.Diff(table.Checks, expected); diff != "" {
+		t.Errorf("Mismatch of checks (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptJson tests the detection of JSON columns by their "json_valid" check
+func TestParseMariadbScriptJson(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE tbl (
+			data JSON,
+			doc  LONGTEXT CHECK (json_valid(doc)),
+			txt  TEXT
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	for name, expected := range map[string]DataType{"data": JsonType, "doc": JsonType, "txt": TextType} {
+		if c := table.GetColumn(name); c == nil || c.Type != expected {
+			t.Errorf("Expected the data type %q for column %q. Got %+v", expected, name, c)
+		}
+	}
+
+	expected := []*CheckConstraint{
+		{Name: "data", Expression: "json_valid(`data`)", Columns: []string{"data"}},
+		{Name: "doc", Expression: "json_valid(doc)", Columns: []string{"doc"}},
+	}
+	if diff := cmp.Diff(table.Checks, expected); diff != "" {
+		t.Errorf("Mismatch of checks (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptGenerated tests the parsing of generated and invisible columns
+func TestParseMariadbScriptGenerated(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE tbl (
+			price    DECIMAL(10,2),
+			amount   INT,
+			total    DECIMAL(20,2) AS (price * amount) PERSISTENT,
+			totalTxt VARCHAR(30) GENERATED ALWAYS AS (CONCAT(total, ' EUR')) VIRTUAL,
+			created  DATETIME INVISIBLE DEFAULT NOW()
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	type generated struct {
+		Expression string
+		Stored     bool
+		Invisible  bool
+	}
+	expected := []generated{
+		{}, {},
+		{Expression: "price * amount", Stored: true},
+		{Expression: "CONCAT(total, ' EUR')"},
+		{Invisible: true},
+	}
+	got := []generated{}
+	for _, c := range table.Columns {
+		got = append(got, generated{c.GenerationExpression, c.GenerationStored, c.Invisible})
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Mismatch of generated columns (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseMariadbScriptDump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	if err := os.WriteFile(path, []byte("-- MariaDB dump 10.19\n"+
+		"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;\n"+
+		"DROP TABLE IF EXISTS `user`;\n"+
+		"/*!40101 SET @saved_cs_client     = @@character_set_client */;\n"+
+		"CREATE TABLE `user` (\n"+
+		"  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'It''s the name',\n"+
+		"  `state` enum('active','disabled') NOT NULL DEFAULT 'active',\n"+
+		"  `created` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),\n"+
+		"  `score` decimal(10,2) DEFAULT NULL,\n"+
+		"  `flag` tinyint(1) NOT NULL DEFAULT 0,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `name` (`name`),\n"+
+		"  KEY `idx_state` (`state`,`created`)\n"+
+		") ENGINE=InnoDB AUTO_INCREMENT=5 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;\n"+
+		"/*!40101 SET character_set_client = @saved_cs_client */;\n",
+	), 0600); err != nil {
+		t.Fatalf("Failed to write dump: %s", err)
+	}
+
+	s := NewMariadbScript("ddl")
+	if err := s.ParseFile(path); err != nil {
+		t.Fatalf("Failed to parse file: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "user")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   "user",
+		Schema: "ddl",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+			{Name: "idx_state", Columns: []string{"state", "created"}, Type: "BTREE"},
+			{Name: "name", Columns: []string{"name"}, Unique: true, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+	}
+	columns := []*MariadbColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				Type:         IntType,
+				InternalType: "int(10) unsigned",
+			},
+			AutoIncrement:  true,
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyPrimary,
+		},
+		{
+			Column: &Column{
+				Name:         "name",
+				Type:         StringType,
+				InternalType: "varchar(50)",
+				Comment:      "It's the name",
+			},
+			DataTypeLenght: 50,
+			KeyType:        MariadbKeyUnique,
+		},
+		{
+			Column: &Column{
+				Name:         "state",
+				Type:         EnumType,
+				InternalType: "enum('active','disabled')",
+				DefaultValue: sql.NullString{Valid: true, String: "active"},
+			},
+			DataTypeLenght: 8,
+			KeyType:        MariadbKeyMultipleIndex,
+			EnumValues:     []string{"active", "disabled"},
+		},
+		{
+			Column: &Column{
+				Name:         "created",
+				Type:         DateType,
+				InternalType: "timestamp",
+				DefaultValue: sql.NullString{Valid: true, String: "current_timestamp()"},
+			},
+		},
+		{
+			Column: &Column{
+				Name:             "score",
+				CanBeNull:        true,
+				Type:             DecimalType,
+				InternalType:     "decimal(10,2)",
+				NumericPrecision: 10,
+				NumericScale:     2,
+			},
+			DataTypeLenght: 10,
+		},
+		{
+			Column: &Column{
+				Name:         "flag",
+				Type:         BoolType,
+				InternalType: "tinyint(1)",
+				DefaultValue: sql.NullString{Valid: true, String: "0"},
+			},
+			DataTypeLenght: 3,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptAlter tests the modification of tables with
+// migration scripts
+func TestParseMariadbScriptAlter(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	migrations := []string{
+		`CREATE TABLE workout (id INT NOT NULL, name VARCHAR(10));
+		 CREATE TABLE tmp (id INT);`,
+		`ALTER TABLE workout
+			ADD PRIMARY KEY (id),
+			MODIFY id INT(10) NOT NULL AUTO_INCREMENT,
+			ADD COLUMN created DATE NOT NULL AFTER id,
+			CHANGE name title VARCHAR(100) NOT NULL DEFAULT '',
+			ENGINE=InnoDB;
+		 DROP TABLE tmp;`,
+		`CREATE TABLE detail (
+			id INT(10) NOT NULL,
+			workout_id INT(10) NOT NULL,
+			old INT
+		 );
+		 ALTER TABLE detail ADD CONSTRAINT fk_workout FOREIGN KEY (workout_id) REFERENCES workout (id),
+			DROP COLUMN old;
+		 CREATE UNIQUE INDEX uq_detail ON detail (id);
+		 RENAME TABLE workout TO training;`,
+	}
+	for _, m := range migrations {
+		if err := s.Parse(m); err != nil {
+			t.Fatalf("Failed to parse script: %s", err)
+		}
+	}
+
+	tables, err := s.GetTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	if len(tables) != 2 || tables[0].Name != "detail" || tables[1].Name != "training" {
+		t.Fatalf("Expected the tables detail and training. Got %s", DumpStruct(tables))
+	}
+
+	// Training table
+	expectedTraining := []*MariadbColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				Type:         IntType,
+				InternalType: "int(10)",
+			},
+			AutoIncrement:  true,
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyPrimary,
+		},
+		{
+			Column: &Column{
+				Name:         "created",
+				Type:         DateType,
+				InternalType: "date",
+			},
+		},
+		{
+			Column: &Column{
+				Name:         "title",
+				Type:         StringType,
+				InternalType: "varchar(100)",
+				DefaultValue: sql.NullString{Valid: true},
+			},
+			DataTypeLenght: 100,
+		},
+	}
+	expected := &Table{
+		Name:   "training",
+		Schema: "ddl",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+	}
+	for _, c := range expectedTraining {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+	if diff := cmp.Diff(tables[1], expected); diff != "" {
+		t.Errorf("Mismatch of training (-want +got):\n%s", diff)
+	}
+
+	// Detail table
+	expectedDetail := []*MariadbColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				Type:         IntType,
+				InternalType: "int(10)",
+			},
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyPrimary,
+		},
+		{
+			Column: &Column{
+				Name:         "workout_id",
+				Type:         IntType,
+				InternalType: "int(10)",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   "training",
+					Schema: "ddl",
+					Column: "id",
+				},
+			},
+			DataTypeLenght: 10,
+			KeyType:        MariadbKeyMultipleIndex,
+		},
+	}
+	expected = &Table{
+		Name:   "detail",
+		Schema: "ddl",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "fk_workout", Columns: []string{"workout_id"}, Type: "BTREE"},
+			{Name: "uq_detail", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "fk_workout",
+				Columns:           []string{"workout_id"},
+				ReferencedSchema:  "ddl",
+				ReferencedTable:   "training",
+				ReferencedColumns: []string{"id"},
+				OnDelete:          "RESTRICT",
+				OnUpdate:          "RESTRICT",
+			},
+		},
+	}
+	for _, c := range expectedDetail {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+	if diff := cmp.Diff(tables[0], expected); diff != "" {
+		t.Errorf("Mismatch of detail (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseMariadbScriptErrors tests that invalid statements are reported
+func TestParseMariadbScriptErrors(t *testing.T) {
+	for _, script := range []string{
+		"CREATE TABLE tbl (id INT NOT NULL",
+		"CREATE TABLE tbl (id INT NOT NULL BLUB)",
+		"ALTER TABLE not_existing ADD COLUMN id INT",
+		"CREATE TABLE tbl (txt VARCHAR(10) DEFAULT 'not closed)",
+	} {
+		if err := NewMariadbScript("ddl").Parse(script); err == nil {
+			t.Errorf("Expected an error for script %q", script)
+		}
+	}
+}
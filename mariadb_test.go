@@ -1,9 +1,12 @@
 package ddl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/RPJoshL/go-logger"
 	_ "github.com/go-sql-driver/mysql"
@@ -39,6 +42,11 @@ func TestGetTableSimple(t *testing.T) {
 	expected := &Table{
 		Name:   tableName,
 		Schema: RequireEnvString("MARIADB_DB", t),
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
 	}
 	columns := []*MariadbColumn{
 		{
@@ -87,7 +95,7 @@ func TestGetTableSimple(t *testing.T) {
 	}
 
 	// Compare struct
-	if diff := cmp.Diff(table, expected); diff != "" {
+	if diff := cmp.Diff(table, expected, ignoreServerDefaults); diff != "" {
 		t.Errorf("TestGetTable() mismatch (-want +got):\n%s", diff)
 	}
 }
@@ -127,6 +135,23 @@ func TestGetTableFK(t *testing.T) {
 	expected := &Table{
 		Name:   tableName,
 		Schema: RequireEnvString("MARIADB_DB", t),
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+			{Name: "fk_test_constraint_for_you", Columns: []string{"other_id"}, Type: "BTREE"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "fk_test_constraint_for_you",
+				Columns:           []string{"other_id"},
+				ReferencedSchema:  RequireEnvString("MARIADB_DB", t),
+				ReferencedTable:   referenceTableName,
+				ReferencedColumns: []string{"id_to_ref"},
+				OnDelete:          "RESTRICT",
+				OnUpdate:          "RESTRICT",
+			},
+		},
 	}
 	columns := []*MariadbColumn{
 		{
@@ -165,12 +190,78 @@ func TestGetTableFK(t *testing.T) {
 	}
 
 	// Compare struct
-	if diff := cmp.Diff(table, expected); diff != "" {
+	if diff := cmp.Diff(table, expected, ignoreServerDefaults); diff != "" {
 		t.Errorf("TestGetTableFK() mismatch (-want +got):\n%s", diff)
 	}
 
 }
 
+// TestGetTableCompositeFK tests a table with a primary and foreign key
+// over multiple columns
+func TestGetTableCompositeFK(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db)
+
+	// Create table we reference to
+	referenceTableName, err := createTable(db, `
+		tenant  INT(10) NOT NULL,
+		id      INT(10) NOT NULL,
+		PRIMARY KEY (tenant, id)`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, referenceTableName)
+
+	// Create table with reference. The column order differs from the key order
+	tableName, err := createTable(db, `
+		other_id  INT(10) NOT NULL,
+		tenant    INT(10) NOT NULL,
+		PRIMARY KEY (tenant, other_id),
+		CONSTRAINT fk_composite FOREIGN KEY(tenant, other_id) REFERENCES `+referenceTableName+`(tenant, id)
+			ON DELETE CASCADE ON UPDATE NO ACTION
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := mDb.GetTable(RequireEnvString("MARIADB_DB", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expectedPk := &PrimaryKey{Name: "PRIMARY", Columns: []string{"tenant", "other_id"}}
+	if diff := cmp.Diff(table.PrimaryKey, expectedPk); diff != "" {
+		t.Errorf("TestGetTableCompositeFK() mismatch of primary key (-want +got):\n%s", diff)
+	}
+
+	expectedFks := []*ForeignKey{
+		{
+			Name:              "fk_composite",
+			Columns:           []string{"tenant", "other_id"},
+			ReferencedSchema:  RequireEnvString("MARIADB_DB", t),
+			ReferencedTable:   referenceTableName,
+			ReferencedColumns: []string{"tenant", "id"},
+			OnDelete:          "CASCADE",
+			OnUpdate:          "NO ACTION",
+		},
+	}
+	if diff := cmp.Diff(table.ForeignKeys, expectedFks); diff != "" {
+		t.Errorf("TestGetTableCompositeFK() mismatch of foreign keys (-want +got):\n%s", diff)
+	}
+
+	// The columns reference their counterpart
+	for _, c := range table.Columns {
+		if !c.PrimaryKey || !c.ForeignKey || c.ForeignKeyColumn.Name != referenceTableName {
+			t.Errorf("Column %q is not marked as a primary and foreign key: %+v", c.Name, c)
+		}
+	}
+	if col := table.Columns[0].ForeignKeyColumn.Column; col != "id" {
+		t.Errorf("Expected 'other_id' to reference 'id'. Got %q", col)
+	}
+}
+
 // TestGetTableSimple tests the selecting of multiple tables to a []Table array
 func TestGetTables(t *testing.T) {
 	db := ConnectToMariadb(t)
@@ -204,6 +295,7 @@ func TestGetTables(t *testing.T) {
 			expected := &Table{
 				Name:   tt.Name,
 				Schema: RequireEnvString("MARIADB_DB", t),
+				Kind:   TableKindTable,
 			}
 			columns := []*MariadbColumn{
 				{
@@ -222,7 +314,7 @@ func TestGetTables(t *testing.T) {
 			}
 
 			// Compare struct
-			if diff := cmp.Diff(tt, expected); diff != "" {
+			if diff := cmp.Diff(tt, expected, ignoreServerDefaults); diff != "" {
 				t.Errorf("TestGetTables() mismatch of tab1: (-want +got):\n%s", diff)
 			}
 		}
@@ -234,6 +326,7 @@ func TestGetTables(t *testing.T) {
 			expected := &Table{
 				Name:   tt.Name,
 				Schema: RequireEnvString("MARIADB_DB", t),
+				Kind:   TableKindTable,
 			}
 			columns := []*MariadbColumn{
 				{
@@ -252,7 +345,7 @@ func TestGetTables(t *testing.T) {
 			}
 
 			// Compare struct
-			if diff := cmp.Diff(tt, expected); diff != "" {
+			if diff := cmp.Diff(tt, expected, ignoreServerDefaults); diff != "" {
 				t.Errorf("TestGetTables() mismatch of tab2: (-want +got):\n%s", diff)
 			}
 		}
@@ -267,7 +360,368 @@ func TestGetTables(t *testing.T) {
 	}
 }
 
-func ConnectToMariadb(t *testing.T) *sql.DB {
+// TestGetTablesContext tests that no tables are returned for a canceled context
+func TestGetTablesContext(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db).(DbSystemContext)
+
+	tableName, err := createTable(db, `id INT(10) NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := mDb.GetTableContext(ctx, RequireEnvString("MARIADB_DB", t), tableName); err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	cancel()
+	if tables, err := mDb.GetTablesContext(ctx, RequireEnvString("MARIADB_DB", t)); err == nil {
+		t.Errorf("Expected an error for a canceled context. Got %d tables", len(tables))
+	}
+}
+
+// BenchmarkGetTables compares the loading of all tables within a single query
+// against fetching every table on its own
+// TestGetTablesView tests that views are included with their kind and definition
+func TestGetTablesView(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db)
+
+	tableName, err := createTable(db, `id INT(10) PRIMARY KEY NOT NULL, txt VARCHAR(100)`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+	if _, err := db.Exec("CREATE VIEW " + tableName + "_view AS SELECT id, txt FROM " + tableName); err != nil {
+		t.Fatalf("Failed to create view: %s", err)
+	}
+	defer db.Exec("DROP VIEW " + tableName + "_view")
+
+	tables, err := mDb.GetTables(RequireEnvString("MARIADB_DB", t))
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+
+	found := 0
+	for _, tbl := range tables {
+		switch tbl.Name {
+		case tableName:
+			found++
+			if tbl.Kind != TableKindTable || tbl.ViewDefinition != "" {
+				t.Errorf("Expected a table without definition. Got %q: %q", tbl.Kind, tbl.ViewDefinition)
+			}
+		case tableName + "_view":
+			found++
+			if tbl.Kind != TableKindView || !strings.Contains(tbl.ViewDefinition, tableName) {
+				t.Errorf("Expected a view selecting from %q. Got %q: %q", tableName, tbl.Kind, tbl.ViewDefinition)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("Expected the table and the view. Found %d", found)
+	}
+}
+
+// TestGetTableInfo tests the metadata of a table and the charset of its columns
+func TestGetTableInfo(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db)
+
+	name, _ := GenerateRandomString(8)
+	name = "ddl_test_" + name
+	if _, err := db.Exec("CREATE TABLE " + name + ` (
+		id  INT(10) PRIMARY KEY NOT NULL,
+		txt VARCHAR(100) CHARACTER SET latin1 COLLATE latin1_german1_ci
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT='All users'`); err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, name)
+
+	table, err := mDb.GetTable(RequireEnvString("MARIADB_DB", t), name)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	if table.Comment != "All users" || table.Engine != "InnoDB" || table.Charset != "utf8mb4" || table.Collation != "utf8mb4_bin" {
+		t.Errorf("Unexpected metadata of table: %q %q %q %q", table.Comment, table.Engine, table.Charset, table.Collation)
+	}
+	if !table.Created.Valid || time.Since(table.Created.Time) > time.Hour || !table.EstimatedRows.Valid {
+		t.Errorf("Expected a creation time and row estimate. Got %+v %+v", table.Created, table.EstimatedRows)
+	}
+
+	id, txt := table.Columns[0].Extras.(*MariadbColumn), table.Columns[1].Extras.(*MariadbColumn)
+	if id.Charset != "" || id.Collation != "" || txt.Charset != "latin1" || txt.Collation != "latin1_german1_ci" {
+		t.Errorf("Unexpected charset of columns: %q %q %q %q", id.Charset, id.Collation, txt.Charset, txt.Collation)
+	}
+}
+
+// TestGetTablesFiltered tests the loading of tables with a filter
+func TestGetTablesFiltered(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db).(DbSystemDiscovery)
+	schema := RequireEnvString("MARIADB_DB", t)
+
+	tableName, err := createTable(db, `id INT(10) PRIMARY KEY NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+	if _, err := db.Exec("CREATE TABLE " + tableName + "_bak LIKE " + tableName); err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName+"_bak")
+
+	schemas, err := mDb.GetSchemas()
+	if err != nil {
+		t.Fatalf("Failed to get schemas: %s", err)
+	}
+	if !containsString(schemas, schema) {
+		t.Errorf("Expected schema %q in %q", schema, schemas)
+	}
+
+	tables, err := mDb.GetTablesFiltered(&TableFilter{
+		IncludeSchemas: []string{schema},
+		IncludeTables:  []string{tableName + "*"},
+		ExcludeTables:  []string{"*_bak"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	if len(tables) != 1 || tables[0].Name != tableName {
+		t.Errorf("Expected only the table %q. Got %d tables", tableName, len(tables))
+	}
+}
+
+// TestListTables tests the listing of tables without columns
+func TestListTables(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db).(DbSystemListing)
+
+	tableName, err := createTable(db, `id INT(10) PRIMARY KEY NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	tables, err := mDb.ListTables(RequireEnvString("MARIADB_DB", t))
+	if err != nil {
+		t.Fatalf("Failed to list tables: %s", err)
+	}
+	for _, tbl := range tables {
+		if tbl.Name == tableName {
+			if tbl.Kind != TableKindTable || tbl.Columns != nil {
+				t.Errorf("Expected a table without columns. Got %+v", tbl)
+			}
+			return
+		}
+	}
+	t.Errorf("Table %q was not listed", tableName)
+}
+
+// TestGetTableChecks tests the selecting of check constraints
+func TestGetTableChecks(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db)
+
+	tableName, err := createTable(db, `
+		amount INT(10) NOT NULL CHECK (amount > 0),
+		min    INT(10),
+		max    INT(10),
+		CONSTRAINT chk_range CHECK (min <= max)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := mDb.GetTable(RequireEnvString("MARIADB_DB", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := []*CheckConstraint{
+		{Name: "amount", Expression: "`amount` > 0", Columns: []string{"amount"}},
+		{Name: "chk_range", Expression: "`min` <= `max`", Columns: []string{"min", "max"}},
+	}
+	if diff := cmp.Diff(table.Checks, expected); diff != "" {
+		t.Errorf("Mismatch of checks (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableGenerated tests the selecting of generated and invisible columns
+func TestGetTableGenerated(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db)
+
+	tableName, err := createTable(db, `
+		amount  INT(10),
+		double  INT(10) AS (amount * 2) PERSISTENT,
+		triple  INT(10) AS (amount * 3) VIRTUAL,
+		created DATETIME INVISIBLE
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := mDb.GetTable(RequireEnvString("MARIADB_DB", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	type generated struct {
+		Expression string
+		Stored     bool
+		Invisible  bool
+	}
+	expected := []generated{
+		{},
+		{Expression: "`amount` * 2", Stored: true},
+		{Expression: "`amount` * 3"},
+		{Invisible: true},
+	}
+	got := []generated{}
+	for _, c := range table.Columns {
+		got = append(got, generated{c.GenerationExpression, c.GenerationStored, c.Invisible})
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Mismatch of generated columns (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetDataTypeMariadb tests the mapping of the column types to the generic data types
+func TestGetDataTypeMariadb(t *testing.T) {
+	s := &Mariadb{}
+	for typ, expected := range map[string]DataType{
+		"int(10) unsigned":        IntType,
+		"tinyint":                 IntType,
+		"tinyint(4)":              IntType,
+		"tinyint(1)":              BoolType,
+		"varchar(100)":            StringType,
+		"longtext":                TextType,
+		"decimal(10,2)":           DecimalType,
+		"double":                  DoubleType,
+		"timestamp":               DateType,
+		"time":                    TimeType,
+		"year(4)":                 YearType,
+		"bit(8)":                  BitType,
+		"varbinary(16)":           BinaryType,
+		"blob":                    BinaryType,
+		"json":                    JsonType,
+		"enum('active','closed')": EnumType,
+		"set('a','b')":            SetType,
+		"uuid":                    UuidType,
+		"point":                   GeoType,
+	} {
+		if got := s.GetDataType(typ); got != expected {
+			t.Errorf("Expected data type %q for %q. Got %q", expected, typ, got)
+		}
+	}
+}
+
+// TestParseMariadbEnumValues tests the extraction of the allowed values of enum and set columns
+func TestParseMariadbEnumValues(t *testing.T) {
+	for typ, expected := range map[string][]string{
+		"enum('active','closed')": {"active", "closed"},
+		"set('a','b','c')":        {"a", "b", "c"},
+		"enum('it''s','a\\\\b')":  {"it's", "a\\b"},
+		"enum('')":                {""},
+	} {
+		if diff := cmp.Diff(parseMariadbEnumValues(typ), expected); diff != "" {
+			t.Errorf("Mismatch of values for %q (-want +got):\n%s", typ, diff)
+		}
+	}
+}
+
+func BenchmarkGetTables(b *testing.B) {
+	db := ConnectToMariadb(b)
+	mDb := NewMariaDb(db)
+	schema := RequireEnvString("MARIADB_DB", b)
+
+	for i := 0; i < 25; i++ {
+		tableName, err := createTable(db, `id INT(10) PRIMARY KEY NOT NULL, txt VARCHAR(100), dte DATETIME NOT NULL`)
+		if err != nil {
+			b.Fatalf("Failed to create table: %s", err)
+		}
+		defer dropTable(db, tableName)
+	}
+
+	b.ResetTimer()
+
+	b.Run("Bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := mDb.GetTables(schema); err != nil {
+				b.Fatalf("Failed to get tables: %s", err)
+			}
+		}
+	})
+	// Baseline of the previous N+1 implementation: one query to list the tables
+	// and one GetTable call per table. GetTable runs the queries filtered by the
+	// name of the table like before the bulk loading
+	b.Run("NPlusOne", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tables, err := mDb.(DbSystemListing).ListTables(schema)
+			if err != nil {
+				b.Fatalf("Failed to list tables: %s", err)
+			}
+			for _, t := range tables {
+				if _, err := mDb.GetTable(t.Schema, t.Name); err != nil {
+					b.Fatalf("Failed to get table: %s", err)
+				}
+			}
+		}
+	})
+}
+
+// TestGetRoutines tests the selecting of procedures and functions with their parameters
+func TestGetRoutines(t *testing.T) {
+	db := ConnectToMariadb(t)
+	mDb := NewMariaDb(db).(DbSystemRoutines)
+
+	suffix, _ := GenerateRandomString(8)
+	procName, funcName := "ddl_test_proc_"+suffix, "ddl_test_func_"+suffix
+	if _, err := db.Exec(fmt.Sprintf(`
+		CREATE PROCEDURE %s(IN id INT, INOUT name VARCHAR(20), OUT amount DECIMAL(10,2))
+		BEGIN
+			SET amount = id * 2;
+		END`, procName,
+	)); err != nil {
+		t.Fatalf("Failed to create procedure: %s", err)
+	}
+	defer db.Exec("DROP PROCEDURE " + procName)
+	if _, err := db.Exec(fmt.Sprintf("CREATE FUNCTION %s() RETURNS DATETIME RETURN NOW()", funcName)); err != nil {
+		t.Fatalf("Failed to create function: %s", err)
+	}
+	defer db.Exec("DROP FUNCTION " + funcName)
+
+	schema := RequireEnvString("MARIADB_DB", t)
+	routines, err := mDb.GetRoutines(schema)
+	if err != nil {
+		t.Fatalf("Failed to get routines: %s", err)
+	}
+
+	expected := []*Routine{
+		{Schema: schema, Name: funcName, Kind: RoutineFunction, Return: &Parameter{Direction: ParameterOut, Type: DateType, InternalType: "datetime"}},
+		{Schema: schema, Name: procName, Kind: RoutineProcedure, Parameters: []*Parameter{
+			{Name: "id", Direction: ParameterIn, Type: IntType, InternalType: "int(11)"},
+			{Name: "name", Direction: ParameterInOut, Type: StringType, InternalType: "varchar(20)"},
+			{Name: "amount", Direction: ParameterOut, Type: DecimalType, InternalType: "decimal(10,2)"},
+		}},
+	}
+	got := []*Routine{}
+	for _, r := range routines {
+		if strings.HasSuffix(r.Name, suffix) {
+			got = append(got, r)
+		}
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of routines (-want +got):\n%s", diff)
+	}
+}
+
+func ConnectToMariadb(t testing.TB) *sql.DB {
 	db, err := sql.Open("mysql", fmt.Sprintf(
 		"%s:%s@tcp(%s)/%s",
 		RequireEnvString("MARIADB_USER", t), RequireEnvString("MARIADB_PASSWORD", t), RequireEnvString("MARIADB_ADDRESS", t), RequireEnvString("MARIADB_DB", t),
@@ -0,0 +1,293 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+var _ DbSystem = &MssqlDb{}
+var _ Columner = &MssqlColumn{}
+
+// MssqlDb implements "DbSystem" for a Microsoft SQL Server database
+type MssqlDb struct {
+	db *sql.DB
+}
+
+type MssqlColumn struct {
+	*Column
+
+	// Weather this column is an identity column
+	AutoIncrement bool
+
+	// The first value of the identity column
+	IdentitySeed int64
+
+	// The value that is added to the last identity value
+	IdentityIncrement int64
+
+	// Weather this column is a computed column.
+	// The value can't be written
+	Computed bool
+
+	// The expression of the computed column like "([price]*[quantity])"
+	ComputedDefinition string
+
+	// Weather the computed column is physically stored ("PERSISTED")
+	Persisted bool
+
+	// Character lenght or numeric precision on the LEFT side
+	// of the dot
+	DataTypeLenght int
+
+	// Decimal precision on the RIGHT side of the dot
+	Scale int
+}
+
+func (c *MssqlColumn) GetExtraInfos() string {
+	return "MSSQL!"
+}
+func (c *MssqlColumn) GetSpecificInfos() any {
+	return c
+}
+func (s *MssqlDb) newColumn() *MssqlColumn {
+	c := &MssqlColumn{}
+	c.Column = &Column{}
+	c.Column.Extras = c
+	return c
+}
+
+// NewMssqlDb initializes a new database parser for a Microsoft SQL Server database
+func NewMssqlDb(db *sql.DB) *MssqlDb {
+	return &MssqlDb{
+		db: db,
+	}
+}
+
+func (s *MssqlDb) GetTable(schema, name string) (*Table, error) {
+	ssql := `
+		SELECT
+			sch.name,
+			obj.name,
+			col.name,
+			dc.definition,
+			col.is_nullable,
+			typ.name,
+			CASE
+				WHEN typ.name IN ('nvarchar', 'nchar') AND col.max_length > 0 THEN col.max_length / 2
+				WHEN typ.name IN ('decimal', 'numeric') THEN col.precision
+				WHEN typ.name IN ('varchar', 'char', 'varbinary', 'binary') THEN col.max_length
+				ELSE 0
+			END,
+			col.scale,
+			col.is_identity,
+			CAST(COALESCE(ic.seed_value, 0) AS BIGINT),
+			CAST(COALESCE(ic.increment_value, 0) AS BIGINT),
+			col.is_computed,
+			COALESCE(cc.definition, ''),
+			COALESCE(cc.is_persisted, 0),
+			CAST(COALESCE(ep.value, '') AS NVARCHAR(4000)),
+			CASE WHEN EXISTS (
+				SELECT 1 FROM sys.index_columns ixc
+				JOIN sys.indexes ix ON ix.object_id = ixc.object_id AND ix.index_id = ixc.index_id
+				WHERE ix.is_primary_key = 1 AND ixc.object_id = col.object_id AND ixc.column_id = col.column_id
+			) THEN 1 ELSE 0 END,
+			-- Foreign key data
+			COALESCE(fk.ref_schema, ''), COALESCE(fk.ref_table, ''), COALESCE(fk.ref_column, '')
+		FROM sys.columns col
+		JOIN sys.objects obj ON obj.object_id = col.object_id AND obj.type IN ('U', 'V')
+		JOIN sys.schemas sch ON sch.schema_id = obj.schema_id
+		JOIN sys.types typ ON typ.user_type_id = col.user_type_id
+		LEFT JOIN sys.default_constraints dc ON dc.object_id = col.default_object_id
+		LEFT JOIN sys.identity_columns ic ON ic.object_id = col.object_id AND ic.column_id = col.column_id
+		LEFT JOIN sys.computed_columns cc ON cc.object_id = col.object_id AND cc.column_id = col.column_id
+		LEFT JOIN sys.extended_properties ep ON ep.class = 1 AND ep.major_id = col.object_id
+			AND ep.minor_id = col.column_id AND ep.name = 'MS_Description'
+		OUTER APPLY (
+			SELECT TOP 1 rsch.name AS ref_schema, robj.name AS ref_table, rcol.name AS ref_column
+			FROM sys.foreign_key_columns fkc
+			JOIN sys.objects robj ON robj.object_id = fkc.referenced_object_id
+			JOIN sys.schemas rsch ON rsch.schema_id = robj.schema_id
+			JOIN sys.columns rcol ON rcol.object_id = fkc.referenced_object_id AND rcol.column_id = fkc.referenced_column_id
+			WHERE fkc.parent_object_id = col.object_id AND fkc.parent_column_id = col.column_id
+		) fk
+		WHERE sch.name = @p1 AND obj.name = @p2
+		ORDER BY col.column_id
+	`
+	rows, err := s.db.Query(ssql, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sys.columns: %s", err)
+	}
+	defer rows.Close()
+
+	table := &Table{}
+	count := 0
+	for rows.Next() {
+		var tableSchema, tableName string
+		var isNullable, isPrimaryKey bool
+		column := s.newColumn()
+
+		if err := rows.Scan(
+			&tableSchema, &tableName,
+			&column.Name, &column.DefaultValue, &isNullable,
+			&column.InternalType, &column.DataTypeLenght, &column.Scale,
+			&column.AutoIncrement, &column.IdentitySeed, &column.IdentityIncrement,
+			&column.Computed, &column.ComputedDefinition, &column.Persisted,
+			&column.Comment, &isPrimaryKey,
+			&column.ForeignKeyColumn.Schema, &column.ForeignKeyColumn.Name, &column.ForeignKeyColumn.Column,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Apply data
+		column.CanBeNull = isNullable
+		column.PrimaryKey = isPrimaryKey
+		column.Type = s.GetDataType(column.InternalType, column)
+		if column.Type == DecimalType {
+			column.NumericPrecision = column.DataTypeLenght
+			column.NumericScale = column.Scale
+		}
+		column.ForeignKey = column.ForeignKeyColumn.Column != ""
+		column.InternalType = mssqlInternalType(column)
+
+		// Computed columns are generated by their expression
+		if column.Computed {
+			column.GenerationExpression = column.ComputedDefinition
+			column.GenerationStored = column.Persisted
+		}
+
+		// The default value is wrapped in brackets and contains the raw single quotes
+		// of the create statement: "(N'value')" or "((0))"
+		if column.DefaultValue.Valid {
+			column.DefaultValue.String = trimMssqlDefault(column.DefaultValue.String)
+		}
+
+		// Initialize new table metadata
+		if count == 0 {
+			table.Schema = tableSchema
+			table.Name = tableName
+		}
+		table.Columns = append(table.Columns, column.Column)
+		count += 1
+	}
+
+	// We got no data
+	if count == 0 {
+		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	}
+
+	return table, nil
+}
+
+func (s *MssqlDb) GetTables(schema string) ([]*Table, error) {
+	sql := `
+		SELECT
+			sch.name,
+			t.name
+		FROM sys.tables t
+		JOIN sys.schemas sch ON sch.schema_id = t.schema_id
+		WHERE sch.name = @p1
+		ORDER BY t.name ASC
+	`
+	rows, err := s.db.Query(sql, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sys.tables: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Table{}
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		t, err := s.GetTable(tableSchema, tableName)
+		if err != nil {
+			return rtc, fmt.Errorf("failed to get data for %s.%s: %s", tableSchema, tableName, err)
+		}
+		rtc = append(rtc, t)
+	}
+
+	return rtc, nil
+}
+
+func (s *MssqlDb) GetDataType(internalType string, col *MssqlColumn) DataType {
+	switch strings.ToLower(internalType) {
+	case "varchar", "nvarchar", "char", "nchar", "text", "ntext", "sysname":
+		return StringType
+	case "int", "tinyint", "smallint", "bigint":
+		return IntType
+	case "float", "real":
+		return DoubleType
+	case "money", "smallmoney":
+		return DecimalType
+	case "decimal", "numeric":
+		if col.Scale == 0 {
+			return IntType
+		}
+		return DecimalType
+	case "date", "datetime", "datetime2", "smalldatetime", "datetimeoffset":
+		return DateType
+	default:
+		logger.Warning("MssqlDb: received unknown data type column: %s", internalType)
+		return UnknownType
+	}
+}
+
+// mssqlInternalType returns the data type of the column with its lenght
+// like "nvarchar(50)", "nvarchar(max)" or "decimal(10,2)"
+func mssqlInternalType(c *MssqlColumn) string {
+	switch strings.ToLower(c.InternalType) {
+	case "varchar", "nvarchar", "char", "nchar", "varbinary", "binary":
+		// The lenght of "max" is returned as zero
+		if c.DataTypeLenght <= 0 {
+			return c.InternalType + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.DataTypeLenght)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d,%d)", c.InternalType, c.DataTypeLenght, c.Scale)
+	case "datetime2", "datetimeoffset", "time":
+		return fmt.Sprintf("%s(%d)", c.InternalType, c.Scale)
+	}
+
+	return c.InternalType
+}
+
+// trimMssqlDefault removes the surrounding brackets and quotes of a
+// default definition
+func trimMssqlDefault(def string) string {
+	for strings.HasPrefix(def, "(") && strings.HasSuffix(def, ")") && isWrappedInBrackets(def) {
+		def = def[1 : len(def)-1]
+	}
+
+	if strings.HasPrefix(def, "N'") {
+		def = def[1:]
+	}
+	if strings.HasPrefix(def, "'") && strings.HasSuffix(def, "'") && len(def) >= 2 {
+		def = strings.ReplaceAll(def[1:len(def)-1], "''", "'")
+	}
+
+	return def
+}
+
+// isWrappedInBrackets returns weather the first bracket of the expression
+// is closed by the last character
+func isWrappedInBrackets(expr string) bool {
+	depth := 0
+	for i, c := range expr {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(expr)-1
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,326 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/RPJoshL/go-logger"
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+// TestGetTableSimpleMssql tests the construction of a Table struct
+// with all supported data types and fields
+func TestGetTableSimpleMssql(t *testing.T) {
+	db := ConnectToMssql(t)
+	mDb := NewMssqlDb(db)
+
+	// Create test table
+	tableName, err := createTable(db,
+		`
+		id 		INT IDENTITY(5,2) PRIMARY KEY NOT NULL,
+		txt 	NVARCHAR(100) DEFAULT N'Ich bins, der Tim!',
+		price	DECIMAL(10,2) NOT NULL,
+		amount	INT NOT NULL DEFAULT 1,
+		total	AS (price * amount) PERSISTED,
+		dte		DATETIME2 NOT NULL
+		`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	// Comment table
+	if err := addMssqlComment(db, RequireEnvString("MSSQL_SCHEMA", t), tableName, "dte", "Hallo ihr da!\nZeilenumbrüche"); err != nil {
+		t.Fatalf("Failed to comment table: %s", err)
+	}
+
+	// Get columns
+	table, err := mDb.GetTable(RequireEnvString("MSSQL_SCHEMA", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   tableName,
+		Schema: RequireEnvString("MSSQL_SCHEMA", t),
+	}
+	columns := []*MssqlColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int",
+			},
+			AutoIncrement:     true,
+			IdentitySeed:      5,
+			IdentityIncrement: 2,
+		},
+		{
+			Column: &Column{
+				Name:         "txt",
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "nvarchar(100)",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "Ich bins, der Tim!",
+				},
+			},
+			DataTypeLenght: 100,
+		},
+		{
+			Column: &Column{
+				Name:             "price",
+				CanBeNull:        false,
+				Type:             DecimalType,
+				InternalType:     "decimal(10,2)",
+				NumericPrecision: 10,
+				NumericScale:     2,
+			},
+			DataTypeLenght: 10,
+			Scale:          2,
+		},
+		{
+			Column: &Column{
+				Name:         "amount",
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "1",
+				},
+			},
+		},
+		{
+			Column: &Column{
+				Name:                 "total",
+				CanBeNull:            true,
+				Type:                 DecimalType,
+				InternalType:         "decimal(21,2)",
+				NumericPrecision:     21,
+				NumericScale:         2,
+				GenerationExpression: "([price]*[amount])",
+				GenerationStored:     true,
+			},
+			Computed:           true,
+			ComputedDefinition: "([price]*[amount])",
+			Persisted:          true,
+			DataTypeLenght:     21,
+			Scale:              2,
+		},
+		{
+			Column: &Column{
+				Name:         "dte",
+				CanBeNull:    false,
+				Type:         DateType,
+				InternalType: "datetime2(7)",
+				Comment:      "Hallo ihr da!\nZeilenumbrüche",
+			},
+			Scale: 7,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch of columns (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableMssqlFK tests the construction of a Table struct
+// that references another table
+func TestGetTableMssqlFK(t *testing.T) {
+	db := ConnectToMssql(t)
+	mDb := NewMssqlDb(db)
+
+	// Create table we reference to
+	referenceTableName, err := createTable(db, `
+		id_to_ref   INT PRIMARY KEY NOT NULL,
+		rand        VARCHAR(10) NOT NULL`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, referenceTableName)
+
+	// Create table with reference
+	tableName, err := createTable(db, `
+		id 		 INT PRIMARY KEY NOT NULL,
+		other_id INT NOT NULL,
+		CONSTRAINT fk_test_constraint_for_you FOREIGN KEY(other_id) REFERENCES `+referenceTableName+`(id_to_ref)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := mDb.GetTable(RequireEnvString("MSSQL_SCHEMA", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   tableName,
+		Schema: RequireEnvString("MSSQL_SCHEMA", t),
+	}
+	columns := []*MssqlColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int",
+			},
+		},
+		{
+			Column: &Column{
+				Name:         "other_id",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "int",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   referenceTableName,
+					Schema: RequireEnvString("MSSQL_SCHEMA", t),
+					Column: "id_to_ref",
+				},
+			},
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTablesMssql tests the selecting of multiple tables to a []Table array
+func TestGetTablesMssql(t *testing.T) {
+	db := ConnectToMssql(t)
+	mDb := NewMssqlDb(db)
+
+	// Create two simple tables
+	tableName1, err := createTable(db, `idTab1 INT NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName1)
+
+	tableName2, err := createTable(db, `idTab2 INT NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName2)
+
+	tables, err := mDb.GetTables(RequireEnvString("MSSQL_SCHEMA", t))
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+
+	found1 := 0
+	found2 := 0
+	for _, tt := range tables {
+		if tt.Name == tableName1 {
+			found1 = found1 + 1
+		}
+		if tt.Name == tableName2 {
+			found2 = found2 + 1
+
+			// Compare table
+			expected := &Table{
+				Name:   tt.Name,
+				Schema: RequireEnvString("MSSQL_SCHEMA", t),
+			}
+			columns := []*MssqlColumn{
+				{
+					Column: &Column{
+						Name:         "idTab2",
+						CanBeNull:    false,
+						Type:         IntType,
+						InternalType: "int",
+					},
+				},
+			}
+			for _, c := range columns {
+				c.Extras = c
+				expected.Columns = append(expected.Columns, c.Column)
+			}
+
+			// Compare struct
+			if diff := cmp.Diff(tt, expected); diff != "" {
+				t.Errorf("Mismatch of tab2: (-want +got):\n%s", diff)
+			}
+		}
+	}
+
+	// We expected to find exactly one single table
+	if found1 != 1 {
+		t.Errorf("Found %d instances of tab1. Expected 1 (len(rtc) = %d)", found1, len(tables))
+	}
+	if found2 != 1 {
+		t.Errorf("Found %d instances of tab2. Expected 1 (len(rtc) = %d)", found2, len(tables))
+	}
+}
+
+func TestTrimMssqlDefault(t *testing.T) {
+	for def, expected := range map[string]string{
+		"((0))":              "0",
+		"(N'it''s me')":      "it's me",
+		"('value')":          "value",
+		"(getdate())":        "getdate()",
+		"((1)+(2))":          "(1)+(2)",
+		"(NEXT VALUE FOR x)": "NEXT VALUE FOR x",
+	} {
+		if got := trimMssqlDefault(def); got != expected {
+			t.Errorf("Expected %q for %q. Got %q", expected, def, got)
+		}
+	}
+}
+
+func addMssqlComment(db *sql.DB, schema string, tbl string, column string, comment string) error {
+	sql := `EXEC sp_addextendedproperty
+		@name = N'MS_Description', @value = @p1,
+		@level0type = N'SCHEMA', @level0name = @p2,
+		@level1type = N'TABLE',  @level1name = @p3,
+		@level2type = N'COLUMN', @level2name = @p4`
+	_, err := db.Exec(sql, comment, schema, tbl, column)
+	if err != nil {
+		logger.Debug("Statement for create comment:\n%s", sql)
+	}
+	return err
+}
+
+func ConnectToMssql(t *testing.T) *sql.DB {
+	query := url.Values{}
+	query.Add("database", RequireEnvString("MSSQL_DB", t))
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(RequireEnvString("MSSQL_USER", t), RequireEnvString("MSSQL_PASSWORD", t)),
+		Host:     RequireEnvString("MSSQL_ADDRESS", t),
+		RawQuery: query.Encode(),
+	}
+
+	db, err := sql.Open("sqlserver", u.String())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to open DB connection: %s", err))
+	}
+
+	return db
+}
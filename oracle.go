@@ -1,8 +1,11 @@
 package ddl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/RPJoshL/go-logger"
@@ -11,11 +14,17 @@ import (
 type OracleTableType string
 
 const (
-	OracleTable OracleTableType = "TABLE"
-	OracleView  OracleTableType = "VIEW"
+	OracleTable            OracleTableType = "TABLE"
+	OracleView             OracleTableType = "VIEW"
+	OracleMaterializedView OracleTableType = "MATERIALIZED VIEW"
 )
 
 var _ DbSystem = &OracleDb{}
+var _ DbSystemContext = &OracleDb{}
+var _ DbSystemDiscovery = &OracleDb{}
+var _ DbSystemListing = &OracleDb{}
+var _ DbSystemSequences = &OracleDb{}
+var _ DbSystemRoutines = &OracleDb{}
 var _ Columner = &OracleColumn{}
 
 // OracleDb implements "DbSystem" for an oracle database
@@ -26,15 +35,35 @@ type OracleDb struct {
 type OracleColumn struct {
 	*Column
 
-	// Weather this column has the auto_increment flag
+	// Weather the values of this column are generated by an identity or by a
+	// sequence. Sequences are detected within the default value and "BEFORE INSERT"
+	// triggers that assign "seq.NEXTVAL" to the column
 	AutoIncrement bool
 
+	// Generation of an identity column like "ALWAYS" or "BY DEFAULT".
+	// It's empty for all other columns
+	IdentityGeneration string
+
+	// Sequence that generates the values of an auto increment column.
+	// The name of the internal sequence of an identity column is not known
+	// for scripts
+	Sequence *Sequence
+
 	// Character lenght or numeric precision on the LEFT side
 	// of the dot
 	DataTypeLenght int
 
+	// Lenght in characters of string columns with character semantics like
+	// "VARCHAR2(10 CHAR)" and of national character columns. The "DataTypeLenght"
+	// of these columns is in bytes. It's zero for byte semantics
+	CharLenght int
+
 	// Decimal precision on the RIGHT side of the dot
 	Scale int
+
+	// The allowed values of a string column restricted by a check constraint
+	// like "COL IN ('A', 'B')"
+	EnumValues []string
 }
 
 func (c *OracleColumn) GetExtraInfos() string {
@@ -58,6 +87,91 @@ func NewOracleDb(db *sql.DB) *OracleDb {
 }
 
 func (s *OracleDb) GetTable(schema, name string) (*Table, error) {
+	return s.GetTableContext(context.Background(), schema, name)
+}
+
+func (s *OracleDb) GetTableContext(ctx context.Context, schema, name string) (*Table, error) {
+	tables, err := s.getTables(ctx, schema, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// We got no data
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	}
+
+	return tables[0], nil
+}
+
+func (s *OracleDb) GetTables(schema string) ([]*Table, error) {
+	return s.GetTablesByTypeContext(context.Background(), schema, OracleTable)
+}
+
+func (s *OracleDb) GetTablesContext(ctx context.Context, schema string) ([]*Table, error) {
+	return s.GetTablesByTypeContext(ctx, schema, OracleTable)
+}
+
+func (s *OracleDb) GetTablesByType(schema string, typ OracleTableType) ([]*Table, error) {
+	return s.GetTablesByTypeContext(context.Background(), schema, typ)
+}
+
+func (s *OracleDb) GetTablesByTypeContext(ctx context.Context, schema string, typ OracleTableType) ([]*Table, error) {
+	return s.getTables(ctx, schema, "", typ)
+}
+
+func (s *OracleDb) GetSchemas() ([]string, error) {
+	return s.GetSchemasContext(context.Background())
+}
+
+func (s *OracleDb) GetSchemasContext(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT USERNAME FROM all_users ORDER BY USERNAME")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query all_users: %s", err)
+	}
+
+	return scanStrings(rows)
+}
+
+func (s *OracleDb) ListTables(schema string) ([]*Table, error) {
+	return s.ListTablesContext(context.Background(), schema)
+}
+
+// ListTablesContext is like ListTables but uses the context for the query
+func (s *OracleDb) ListTablesContext(ctx context.Context, schema string) ([]*Table, error) {
+	infos, err := s.getTableInfos(ctx, schema, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return listTables(schema, infos), nil
+}
+
+func (s *OracleDb) GetTablesFiltered(filter *TableFilter) ([]*Table, error) {
+	return s.GetTablesFilteredContext(context.Background(), filter)
+}
+
+// GetTablesFilteredContext returns the tables, views and materialized views of
+// all schemas that are matched by the filter
+func (s *OracleDb) GetTablesFilteredContext(ctx context.Context, filter *TableFilter) ([]*Table, error) {
+	schemas, err := s.GetSchemasContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterTables(ctx, filter, schemas, tableLoader{
+		listTables: s.ListTablesContext,
+		getTables: func(ctx context.Context, schema string) ([]*Table, error) {
+			return s.getTables(ctx, schema, "", "")
+		},
+		getTable: s.GetTableContext,
+	})
+}
+
+// getTables fetches the columns of all tables within the schema with a single
+// query and groups them by the table. The tables can be filtered by the name
+// and object type
+func (s *OracleDb) getTables(ctx context.Context, schema, name string, typ OracleTableType) ([]*Table, error) {
 	ssql := `
 		SELECT 
 			col.OWNER,
@@ -67,44 +181,57 @@ func (s *OracleDb) GetTable(schema, name string) (*Table, error) {
 			col.NULLABLE,
 			col.DATA_TYPE,
 			COALESCE(col.DATA_PRECISION, col.DATA_LENGTH, 0), col.DATA_SCALE,
-			col.IDENTITY_COLUMN, con.CONSTRAINT_TYPE, 
-			coms.COMMENTS,
-			-- Foreign key data
-			act.OWNER, act.table_name, act.COLUMN_NAME
-			FROM all_tab_columns col
-			LEFT JOIN all_cons_columns cc ON cc.TABLE_NAME = col.TABLE_NAME AND col.COLUMN_NAME = cc.COLUMN_NAME
-			LEFT JOIN all_constraints con ON cc.CONSTRAINT_NAME = con.CONSTRAINT_NAME
-			LEFT JOIN all_cons_columns act ON con.r_owner = act.owner
-	   			AND con.r_constraint_name = act.constraint_name
+			DECODE(col.CHAR_USED, 'C', col.CHAR_LENGTH, 0),
+			ident.GENERATION_TYPE,
+			ident.SEQUENCE_NAME,
+			ident.IDENTITY_OPTIONS,
+			col.VIRTUAL_COLUMN,
+			col.HIDDEN_COLUMN,
+			coms.COMMENTS
+			FROM all_tab_cols col
 			LEFT JOIN dba_col_comments coms ON coms.OWNER = col.OWNER AND coms.TABLE_NAME = col.TABLE_NAME
 				AND coms.COLUMN_NAME = col.COLUMN_NAME
-			WHERE col.table_name = UPPER(:0)
-	  			AND col.OWNER = UPPER(:1)
-			ORDER BY col.column_id
+			LEFT JOIN all_tab_identity_cols ident ON ident.OWNER = col.OWNER AND ident.TABLE_NAME = col.TABLE_NAME
+				AND ident.COLUMN_NAME = col.COLUMN_NAME
+			WHERE col.OWNER = UPPER(:0) AND col.USER_GENERATED = 'YES'
+				AND col.TABLE_NAME NOT LIKE 'BIN$%'
 	`
-	rows, err := s.db.Query(ssql, name, schema)
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += fmt.Sprintf(" AND col.table_name = UPPER(:%d)", len(args)-1)
+	}
+	if typ != "" {
+		args = append(args, string(typ))
+		ssql += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM all_objects o
+			WHERE o.OWNER = col.OWNER AND o.OBJECT_NAME = col.TABLE_NAME AND o.OBJECT_TYPE = :%d
+		)`, len(args)-1)
+	}
+	// Invisible columns don't have a column id
+	ssql += " ORDER BY col.table_name, col.column_id NULLS LAST, col.internal_column_id"
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all_tab_columns: %s", err)
 	}
 	defer rows.Close()
 
-	lastColumnName := ""
-	table := &Table{}
-	count := 0
+	rtc := []*Table{}
+	var table *Table
 	for rows.Next() {
-		var tableSchema, tableName, isNullable, identity string
-		var fkOwner, fkTable, fkColumn, keyType, comment sql.NullString
+		var tableSchema, tableName, isNullable, virtual, hidden string
+		var comment, identity, identitySequence, identityOptions sql.NullString
 		var scale sql.NullInt64
 		column := s.newColumn()
 
 		if err := rows.Scan(
 			&tableSchema, &tableName,
 			&column.Name, &column.DefaultValue, &isNullable,
-			&column.InternalType, &column.DataTypeLenght, &scale,
-			&identity, &keyType, &comment,
-			&fkOwner, &fkTable, &fkColumn,
+			&column.InternalType, &column.DataTypeLenght, &scale, &column.CharLenght,
+			&identity, &identitySequence, &identityOptions, &virtual, &hidden, &comment,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %s", err)
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
 		}
 
 		// Set scales
@@ -121,12 +248,25 @@ func (s *OracleDb) GetTable(schema, name string) (*Table, error) {
 		// Apply data
 		column.CanBeNull = isNullable == "Y"
 		column.Type = s.GetDataType(column.InternalType, column)
-		column.PrimaryKey = identity == "YES" || (keyType.Valid && keyType.String == "P")
-		if fkColumn.Valid {
-			column.ForeignKey = true
-			column.ForeignKeyColumn.Column = fkColumn.String
-			column.ForeignKeyColumn.Name = fkTable.String
-			column.ForeignKeyColumn.Schema = fkOwner.String
+		if column.Type == DecimalType {
+			column.NumericPrecision = column.DataTypeLenght
+			column.NumericScale = column.Scale
+		}
+		column.Invisible = hidden == "YES"
+
+		// Identity columns are generated by an internal sequence
+		if identity.Valid {
+			column.AutoIncrement = true
+			column.IdentityGeneration = identity.String
+			column.Sequence = parseOracleIdentityOptions(identityOptions.String)
+			column.Sequence.Schema = tableSchema
+			column.Sequence.Name = identitySequence.String
+		}
+
+		// The default value contains the expression of virtual columns
+		if virtual == "YES" {
+			column.GenerationExpression = strings.TrimSpace(column.DefaultValue.String)
+			column.DefaultValue = sql.NullString{}
 		}
 
 		// The default value contains the raw single quotes of the create statement
@@ -141,70 +281,780 @@ func (s *OracleDb) GetTable(schema, name string) (*Table, error) {
 		}
 
 		// Initialize new table metadata
-		if count == 0 {
-			table.Schema = tableSchema
-			table.Name = tableName
-		}
-
-		// It's possible that we get the same column twice for different keyTypes.
-		// Always prefer the primary or foreign key constraint
-		if lastColumnName == column.Name {
-			if column.ForeignKey || column.PrimaryKey {
-				// Don't skip, but remove the last one
-				table.Columns = table.Columns[:len(table.Columns)-1]
-			} else {
-				// We use the primary key or foreign key
+		if table == nil || table.Schema != tableSchema || table.Name != tableName {
+			table = &Table{
+				Schema: tableSchema,
+				Name:   tableName,
+				Kind:   TableKindTable,
+			}
+			rtc = append(rtc, table)
+		}
+		table.Columns = append(table.Columns, column.Column)
+	}
+
+	// The context could have been canceled while reading the rows
+	if err := rows.Err(); err != nil {
+		return rtc, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	// Add table metadata
+	infos, err := s.getTableInfos(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		if info, ok := infos[t.Name]; ok {
+			t.applyInfo(info)
+		}
+	}
+
+	// Add indexes
+	indexes, err := s.getIndexes(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		t.Indexes = indexes[t.Name]
+	}
+
+	// Add primary and foreign keys
+	primaryKeys, foreignKeys, err := s.getKeys(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		t.PrimaryKey = primaryKeys[t.Name]
+		t.ForeignKeys = foreignKeys[t.Name]
+		t.applyForeignKeys()
+
+		if t.PrimaryKey == nil {
+			continue
+		}
+		for _, c := range t.Columns {
+			c.PrimaryKey = c.PrimaryKey || containsString(t.PrimaryKey.Columns, c.Name)
+		}
+	}
+
+	// Add columns that are filled by a sequence
+	if err := s.applySequences(ctx, schema, name, rtc); err != nil {
+		return rtc, err
+	}
+
+	// Add check constraints and the allowed values of enum like columns
+	checks, err := s.getChecks(ctx, schema, name)
+	if err != nil {
+		return rtc, err
+	}
+	for _, t := range rtc {
+		for _, check := range checks[t.Name] {
+			// NOT NULL constraints are already provided by the column
+			if column, ok := parseOracleNotNullCheck(check.Expression); ok {
+				if c := t.GetColumn(column); c != nil && !c.CanBeNull {
+					continue
+				}
+			}
+
+			check.Columns = t.expressionColumns(check.Expression, oracleDialect)
+			t.Checks = append(t.Checks, check)
+
+			if column, values, ok := parseOracleEnumCheck(check.Expression); ok {
+				if c := t.GetColumn(column); c != nil && c.Type == StringType {
+					c.Extras.(*OracleColumn).EnumValues = values
+				}
+			}
+		}
+	}
+
+	return rtc, nil
+}
+
+// getChecks fetches the check constraints of all tables within the schema with a
+// single query and groups them by the table name. If a name is provided, only the
+// constraints of this table are fetched.
+// The columns of the constraints are not set
+func (s *OracleDb) getChecks(ctx context.Context, schema, name string) (map[string][]*CheckConstraint, error) {
+	ssql := `
+		SELECT con.TABLE_NAME, con.CONSTRAINT_NAME, con.SEARCH_CONDITION_VC
+			FROM all_constraints con
+			WHERE con.OWNER = UPPER(:0)
+				AND con.CONSTRAINT_TYPE = 'C'
+	`
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += " AND con.TABLE_NAME = UPPER(:1)"
+	}
+	ssql += " ORDER BY con.TABLE_NAME, con.CONSTRAINT_NAME"
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check constraints: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := map[string][]*CheckConstraint{}
+	for rows.Next() {
+		var tableName string
+		var condition sql.NullString
+		check := &CheckConstraint{}
+		if err := rows.Scan(&tableName, &check.Name, &condition); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+		check.Expression = condition.String
+		rtc[tableName] = append(rtc[tableName], check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+func (s *OracleDb) GetRoutines(schema string) ([]*Routine, error) {
+	return s.GetRoutinesContext(context.Background(), schema)
+}
+
+// GetRoutinesContext is like GetRoutines but uses the context for the query
+func (s *OracleDb) GetRoutinesContext(ctx context.Context, schema string) ([]*Routine, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+			pr.OWNER,
+			pr.OBJECT_TYPE,
+			pr.OBJECT_NAME,
+			pr.PROCEDURE_NAME,
+			pr.SUBPROGRAM_ID,
+			pr.OVERLOAD,
+			arg.POSITION,
+			arg.ARGUMENT_NAME,
+			arg.IN_OUT,
+			arg.DATA_TYPE,
+			arg.DATA_SCALE,
+			arg.DEFAULTED
+		FROM all_procedures pr
+		LEFT JOIN all_arguments arg ON arg.OBJECT_ID = pr.OBJECT_ID AND arg.SUBPROGRAM_ID = pr.SUBPROGRAM_ID
+			AND arg.DATA_LEVEL = 0 AND arg.DATA_TYPE IS NOT NULL
+		WHERE pr.OWNER = UPPER(:0) AND (
+			pr.OBJECT_TYPE IN ('PROCEDURE', 'FUNCTION') OR
+			(pr.OBJECT_TYPE = 'PACKAGE' AND pr.PROCEDURE_NAME IS NOT NULL)
+		)
+		ORDER BY pr.OBJECT_NAME, pr.SUBPROGRAM_ID, arg.POSITION
+	`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query all_procedures: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Routine{}
+	var routine *Routine
+	lastKey := ""
+	for rows.Next() {
+		var owner, objectType, objectName string
+		var procedureName, overload, argName, inOut, dataType, defaulted sql.NullString
+		var subprogramId int
+		var position, scale sql.NullInt64
+		if err := rows.Scan(
+			&owner, &objectType, &objectName, &procedureName, &subprogramId, &overload,
+			&position, &argName, &inOut, &dataType, &scale, &defaulted,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Initialize new routine. The routines of a package are identified by their subprogram
+		if key := fmt.Sprintf("%s.%s.%d", owner, objectName, subprogramId); key != lastKey {
+			lastKey = key
+			routine = &Routine{Schema: owner, Name: objectName, Kind: RoutineKind(objectType)}
+			if objectType == "PACKAGE" {
+				// The kind of package routines is only known by the return value
+				routine.Package = objectName
+				routine.Name = procedureName.String
+				routine.Kind = RoutineProcedure
+			}
+			routine.Overload, _ = strconv.Atoi(overload.String)
+			rtc = append(rtc, routine)
+		}
+
+		// Routine without any parameters
+		if !position.Valid {
+			continue
+		}
+		param := &Parameter{
+			Name:         argName.String,
+			Direction:    ParameterDirection(strings.ReplaceAll(inOut.String, "/", "")),
+			Type:         s.getArgumentType(dataType.String, scale),
+			InternalType: dataType.String,
+			HasDefault:   defaulted.String == "Y",
+		}
+
+		// The position 0 contains the return value of a function
+		if position.Int64 == 0 {
+			routine.Kind = RoutineFunction
+			routine.Return = param
+		} else {
+			routine.Parameters = append(routine.Parameters, param)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	sortRoutines(rtc)
+	return rtc, nil
+}
+
+// getArgumentType returns the data type of a routine argument. Parameters of
+// PL/SQL types like records, collections or cursors have an unknown type
+func (s *OracleDb) getArgumentType(dataType string, scale sql.NullInt64) DataType {
+	switch dataType {
+	case "PL/SQL BOOLEAN":
+		return BoolType
+	case "BINARY_INTEGER", "PLS_INTEGER", "PL/SQL PLS INTEGER", "PL/SQL BINARY INTEGER":
+		return IntType
+	case "REF CURSOR", "PL/SQL RECORD", "PL/SQL TABLE", "PL/SQL COLLECTION", "TABLE", "VARRAY", "OBJECT", "UNDEFINED":
+		return UnknownType
+	}
+
+	// Numbers without a scale can contain any value
+	col := &OracleColumn{Scale: 64}
+	if scale.Valid {
+		col.Scale = int(scale.Int64)
+	}
+	return s.GetDataType(dataType, col)
+}
+
+func (s *OracleDb) GetSequences(schema string) ([]*Sequence, error) {
+	return s.GetSequencesContext(context.Background(), schema)
+}
+
+// GetSequencesContext is like GetSequences but uses the context for the query
+func (s *OracleDb) GetSequencesContext(ctx context.Context, schema string) ([]*Sequence, error) {
+	sequences, err := s.getSequences(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rtc := make([]*Sequence, 0, len(sequences))
+	for _, seq := range sequences {
+		rtc = append(rtc, seq)
+	}
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+
+	return rtc, nil
+}
+
+// getSequences returns all sequences of the schema without the internal sequences
+// of identity columns. The map is keyed by the name of the sequence.
+// Because the initial value of a sequence is not stored, the first value that is
+// not cached ("LAST_NUMBER") is used as the start
+func (s *OracleDb) getSequences(ctx context.Context, schema string) (map[string]*Sequence, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT seq.SEQUENCE_OWNER, seq.SEQUENCE_NAME, seq.LAST_NUMBER, seq.INCREMENT_BY, seq.CYCLE_FLAG
+			FROM all_sequences seq
+			WHERE seq.SEQUENCE_OWNER = UPPER(:0) AND seq.SEQUENCE_NAME NOT LIKE 'ISEQ$$%'
+	`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query all_sequences: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := map[string]*Sequence{}
+	for rows.Next() {
+		var cycle string
+		seq := &Sequence{}
+		if err := rows.Scan(&seq.Schema, &seq.Name, &seq.Start, &seq.Increment, &cycle); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+		seq.Cycle = cycle == "Y"
+		rtc[seq.Name] = seq
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+// applySequences marks all columns as auto increment that are filled by a sequence
+// within a "BEFORE INSERT" trigger or their default value
+func (s *OracleDb) applySequences(ctx context.Context, schema, name string, tables []*Table) error {
+	ssql := `
+		SELECT trg.TABLE_NAME, trg.TRIGGER_BODY
+			FROM all_triggers trg
+			WHERE trg.TABLE_OWNER = UPPER(:0)
+				AND trg.BASE_OBJECT_TYPE = 'TABLE'
+				AND trg.TRIGGER_TYPE = 'BEFORE EACH ROW'
+				AND trg.TRIGGERING_EVENT LIKE '%INSERT%'
+				AND trg.STATUS = 'ENABLED'
+	`
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += " AND trg.TABLE_NAME = UPPER(:1)"
+	}
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query all_triggers: %s", err)
+	}
+	defer rows.Close()
+
+	// Sequences by the table and column name
+	used := map[string]map[string]*Sequence{}
+	for rows.Next() {
+		var tableName string
+		var body sql.NullString
+		if err := rows.Scan(&tableName, &body); err != nil {
+			return fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		if column, seq, ok := parseOracleSequenceTrigger(body.String); ok {
+			if used[tableName] == nil {
+				used[tableName] = map[string]*Sequence{}
+			}
+			used[tableName][column] = seq
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	// Oracle 12c allows sequences as a default value
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if seq, ok := parseOracleSequenceDefault(c.DefaultValue.String); ok && c.DefaultValue.Valid && !c.Extras.(*OracleColumn).AutoIncrement {
+				if used[t.Name] == nil {
+					used[t.Name] = map[string]*Sequence{}
+				}
+				used[t.Name][c.Name] = seq
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	sequences, err := s.getSequences(ctx, schema)
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		for column, seq := range used[t.Name] {
+			c := t.GetColumn(column)
+			if c == nil {
 				continue
 			}
+
+			if seq.Schema == "" {
+				seq.Schema = t.Schema
+			}
+			if existing, ok := sequences[seq.Name]; ok && seq.Schema == existing.Schema {
+				seq = existing
+			}
+			c.Extras.(*OracleColumn).AutoIncrement = true
+			c.Extras.(*OracleColumn).Sequence = seq
 		}
-		lastColumnName = column.Name
+	}
 
-		table.Columns = append(table.Columns, column.Column)
-		count += 1
+	return nil
+}
+
+// parseOracleIdentityOptions parses the options of an identity column like
+// "START WITH: 1, INCREMENT BY: 1, CYCLE_FLAG: N" into a sequence
+func parseOracleIdentityOptions(options string) *Sequence {
+	rtc := &Sequence{Start: 1, Increment: 1}
+	for _, option := range strings.Split(options, ",") {
+		key, value, _ := strings.Cut(option, ":")
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "START WITH":
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				rtc.Start = v
+			}
+		case "INCREMENT BY":
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				rtc.Increment = v
+			}
+		case "CYCLE_FLAG":
+			rtc.Cycle = value == "Y"
+		}
 	}
 
-	// We got no data
-	if count == 0 {
-		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	return rtc
+}
+
+// parseOracleSequenceTrigger searches the body of a trigger for an assignment of
+// a sequence to a column like ":NEW.ID := seq.NEXTVAL" or "SELECT seq.NEXTVAL
+// INTO :NEW.ID FROM dual". The schema of the returned sequence is empty if it's
+// not qualified. Only the first assignment is returned
+func parseOracleSequenceTrigger(body string) (column string, seq *Sequence, ok bool) {
+	p, err := newSqlParser(body, oracleDialect)
+	if err != nil {
+		return "", nil, false
+	}
+
+	for ; !p.eof(); p.next() {
+		start := p.pos
+
+		// :NEW.ID := seq.NEXTVAL
+		if column, ok := parseOracleNewColumn(p); ok && p.acceptSymbol(":") && p.acceptSymbol("=") {
+			if seq, ok := parseOracleNextval(p); ok {
+				return column, seq, true
+			}
+		}
+		p.pos = start
+
+		// SELECT seq.NEXTVAL INTO :NEW.ID
+		if p.acceptKeywords("SELECT") {
+			if seq, ok := parseOracleNextval(p); ok && p.acceptKeywords("INTO") {
+				if column, ok := parseOracleNewColumn(p); ok {
+					return column, seq, true
+				}
+			}
+		}
+		p.pos = start
 	}
 
-	return table, nil
+	return "", nil, false
 }
 
-func (s *OracleDb) GetTables(schema string) ([]*Table, error) {
-	return s.GetTablesByType(schema, OracleTable)
+// parseOracleSequenceDefault parses a default value like "seq.NEXTVAL" and returns
+// the sequence. The schema of the sequence is empty if it's not qualified
+func parseOracleSequenceDefault(value string) (*Sequence, bool) {
+	p, err := newSqlParser(value, oracleDialect)
+	if err != nil {
+		return nil, false
+	}
+
+	seq, ok := parseOracleNextval(p)
+	return seq, ok && p.eof()
 }
 
-func (s *OracleDb) GetTablesByType(schema string, typ OracleTableType) ([]*Table, error) {
-	sql := `
-		SELECT DISTINCT
-			OWNER,
-    		OBJECT_NAME,
-			OBJECT_TYPE
-		FROM ALL_OBJECTS
-		WHERE  OBJECT_TYPE = :0
-		   AND OWNER <> 'SYS' 
-		   AND OWNER = :1
-		ORDER BY OBJECT_NAME ASC
+// parseOracleNewColumn consumes a reference to the new row like ":NEW.ID"
+// and returns the column
+func parseOracleNewColumn(p *sqlParser) (string, bool) {
+	if !p.acceptSymbol(":") || !p.acceptKeywords("NEW") || !p.acceptSymbol(".") {
+		return "", false
+	}
+
+	column, err := oracleIdentifier(p)
+	return column, err == nil
+}
+
+// parseOracleNextval consumes a call to the next value of a sequence like
+// "schema.seq.NEXTVAL"
+func parseOracleNextval(p *sqlParser) (*Sequence, bool) {
+	names := []string{}
+	for len(names) < 3 {
+		if len(names) != 0 && p.acceptKeywords("NEXTVAL") {
+			break
+		}
+
+		name, err := oracleIdentifier(p)
+		if err != nil || !p.acceptSymbol(".") {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+
+	switch len(names) {
+	case 1:
+		return &Sequence{Name: names[0], Start: 1, Increment: 1}, true
+	case 2:
+		return &Sequence{Schema: names[0], Name: names[1], Start: 1, Increment: 1}, true
+	}
+	return nil, false
+}
+
+// parseOracleNotNullCheck parses the condition of a check constraint like
+// "COL IS NOT NULL" and returns the column
+func parseOracleNotNullCheck(condition string) (column string, ok bool) {
+	p, err := newSqlParser(condition, oracleDialect)
+	if err != nil {
+		return "", false
+	}
+
+	column, quoted, err := p.identifier()
+	if err != nil || !p.acceptKeywords("IS", "NOT", "NULL") || !p.eof() {
+		return "", false
+	}
+	if !quoted {
+		column = strings.ToUpper(column)
+	}
+
+	return column, true
+}
+
+// parseOracleEnumCheck parses the condition of a check constraint like
+// "STATE IN ('A', 'B')". If the condition doesn't restrict a single column to
+// a list of strings, ok is false
+func parseOracleEnumCheck(condition string) (column string, values []string, ok bool) {
+	p, err := newSqlParser(condition, oracleDialect)
+	if err != nil {
+		return "", nil, false
+	}
+
+	column, quoted, err := p.identifier()
+	if err != nil || !p.acceptKeywords("IN") || !p.acceptSymbol("(") {
+		return "", nil, false
+	}
+	if !quoted {
+		column = strings.ToUpper(column)
+	}
+
+	for {
+		t := p.next()
+		if t.typ != tokenString {
+			return "", nil, false
+		}
+		values = append(values, t.value)
+
+		if p.acceptSymbol(")") {
+			break
+		}
+		if !p.acceptSymbol(",") {
+			return "", nil, false
+		}
+	}
+
+	if !p.eof() {
+		return "", nil, false
+	}
+
+	return column, values, true
+}
+
+// getTableInfos fetches the metadata of all tables, views and materialized views
+// within the schema with a single query like the kind, the tablespace or the comment.
+// The returned tables contain only this information grouped by the table name.
+// If a name is provided, only this table is fetched
+func (s *OracleDb) getTableInfos(ctx context.Context, schema, name string) (map[string]*Table, error) {
+	ssql := `
+		SELECT
+			o.OBJECT_NAME,
+			o.OBJECT_TYPE,
+			o.ORACLE_MAINTAINED,
+			o.CREATED,
+			t.TABLESPACE_NAME,
+			t.NUM_ROWS,
+			t.DEFAULT_COLLATION,
+			(SELECT p.VALUE FROM nls_database_parameters p WHERE p.PARAMETER = 'NLS_CHARACTERSET'),
+			com.COMMENTS,
+			v.TEXT_VC,
+			mv.QUERY
+			FROM all_objects o
+			LEFT JOIN all_tables t ON t.OWNER = o.OWNER AND t.TABLE_NAME = o.OBJECT_NAME
+			LEFT JOIN all_tab_comments com ON com.OWNER = o.OWNER AND com.TABLE_NAME = o.OBJECT_NAME
+			LEFT JOIN all_views v ON v.OWNER = o.OWNER AND v.VIEW_NAME = o.OBJECT_NAME
+			LEFT JOIN all_mviews mv ON mv.OWNER = o.OWNER AND mv.MVIEW_NAME = o.OBJECT_NAME
+			WHERE o.OWNER = UPPER(:0)
+				AND o.OBJECT_TYPE IN ('TABLE', 'VIEW', 'MATERIALIZED VIEW')
+				-- Dropped tables within the recycle bin
+				AND o.OBJECT_NAME NOT LIKE 'BIN$%'
 	`
-	rows, err := s.db.Query(sql, string(typ), schema)
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += " AND o.OBJECT_NAME = UPPER(:1)"
+	}
+
+	// A materialized view has an additional object of the type "TABLE" with the same name.
+	// The materialized view has to come first so it isn't overwritten by the table
+	ssql += " ORDER BY o.OBJECT_NAME, o.OBJECT_TYPE"
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all_objects: %s", err)
 	}
 	defer rows.Close()
 
-	rtc := []*Table{}
+	rtc := map[string]*Table{}
+	for rows.Next() {
+		info := &Table{}
+		var objectType, oracleMaintained string
+		var tablespace, collation, charset, comment, text, query sql.NullString
+		if err := rows.Scan(
+			&info.Name, &objectType, &oracleMaintained, &info.Created,
+			&tablespace, &info.EstimatedRows, &collation, &charset,
+			&comment, &text, &query,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+		if _, exists := rtc[info.Name]; exists {
+			continue
+		}
+
+		switch {
+		case objectType == string(OracleMaterializedView):
+			info.Kind = TableKindMaterializedView
+			info.ViewDefinition = query.String
+		case objectType == string(OracleView) && oracleMaintained == "Y":
+			info.Kind = TableKindSystemView
+			info.ViewDefinition = text.String
+		case objectType == string(OracleView):
+			info.Kind = TableKindView
+			info.ViewDefinition = text.String
+		default:
+			info.Kind = TableKindTable
+		}
+		info.Tablespace = tablespace.String
+		info.Collation = collation.String
+		info.Charset = charset.String
+		info.Comment = strings.ReplaceAll(comment.String, "\\n", "\n")
+		rtc[info.Name] = info
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+// getKeys fetches the primary and foreign keys of all tables within the schema
+// with a single query and groups them by the table name. If a name is provided,
+// only the keys of this table are fetched
+func (s *OracleDb) getKeys(ctx context.Context, schema, name string) (map[string]*PrimaryKey, map[string][]*ForeignKey, error) {
+	ssql := `
+		SELECT
+			con.TABLE_NAME,
+			con.CONSTRAINT_NAME,
+			con.CONSTRAINT_TYPE,
+			cc.COLUMN_NAME,
+			ref.OWNER, ref.TABLE_NAME, ref.COLUMN_NAME,
+			con.DELETE_RULE
+			FROM all_constraints con
+			JOIN all_cons_columns cc ON cc.OWNER = con.OWNER AND cc.CONSTRAINT_NAME = con.CONSTRAINT_NAME
+			-- The columns of the referenced constraint are matched by their position
+			LEFT JOIN all_cons_columns ref ON ref.OWNER = con.R_OWNER AND ref.CONSTRAINT_NAME = con.R_CONSTRAINT_NAME
+				AND ref.POSITION = cc.POSITION
+			WHERE con.OWNER = UPPER(:0)
+				AND con.CONSTRAINT_TYPE IN ('P', 'R')
+	`
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += " AND con.TABLE_NAME = UPPER(:1)"
+	}
+	ssql += " ORDER BY con.TABLE_NAME, con.CONSTRAINT_NAME, cc.POSITION"
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query all_constraints: %s", err)
+	}
+	defer rows.Close()
+
+	primaryKeys := map[string]*PrimaryKey{}
+	foreignKeys := map[string][]*ForeignKey{}
 	for rows.Next() {
-		var tableSchema, tableName, tableType string
-		if err := rows.Scan(&tableSchema, &tableName, &tableType); err != nil {
+		var tableName, constraintName, constraintType, columnName string
+		var refOwner, refTable, refColumn, deleteRule sql.NullString
+		if err := rows.Scan(
+			&tableName, &constraintName, &constraintType, &columnName,
+			&refOwner, &refTable, &refColumn,
+			&deleteRule,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Every column of a key is returned as an own row
+		if constraintType == "P" {
+			if primaryKeys[tableName] == nil {
+				primaryKeys[tableName] = &PrimaryKey{Name: constraintName}
+			}
+			primaryKeys[tableName].Columns = append(primaryKeys[tableName].Columns, columnName)
+			continue
+		}
+
+		fks := foreignKeys[tableName]
+		if len(fks) == 0 || fks[len(fks)-1].Name != constraintName {
+			// Oracle doesn't support an "ON UPDATE" action
+			fks = append(fks, &ForeignKey{
+				Name:             constraintName,
+				ReferencedSchema: refOwner.String,
+				ReferencedTable:  refTable.String,
+				OnDelete:         deleteRule.String,
+			})
+			foreignKeys[tableName] = fks
+		}
+		fk := fks[len(fks)-1]
+		fk.Columns = append(fk.Columns, columnName)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, refColumn.String)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return primaryKeys, foreignKeys, nil
+}
+
+// getIndexes fetches the indexes of all tables within the schema with a single
+// query and groups them by the table name. If a name is provided, only the
+// indexes of this table are fetched
+func (s *OracleDb) getIndexes(ctx context.Context, schema, name string) (map[string][]*Index, error) {
+	ssql := `
+		SELECT
+			i.TABLE_NAME,
+			i.INDEX_NAME,
+			i.UNIQUENESS,
+			i.INDEX_TYPE,
+			ic.COLUMN_NAME,
+			ie.COLUMN_EXPRESSION
+			FROM all_indexes i
+			JOIN all_ind_columns ic ON ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME
+			LEFT JOIN all_ind_expressions ie ON ie.INDEX_OWNER = i.OWNER AND ie.INDEX_NAME = i.INDEX_NAME
+				AND ie.COLUMN_POSITION = ic.COLUMN_POSITION
+			WHERE i.TABLE_OWNER = UPPER(:0)
+				AND i.INDEX_TYPE <> 'LOB'
+	`
+	args := []any{schema}
+	if name != "" {
+		args = append(args, name)
+		ssql += " AND i.TABLE_NAME = UPPER(:1)"
+	}
+	ssql += " ORDER BY i.TABLE_NAME, i.INDEX_NAME, ic.COLUMN_POSITION"
+
+	rows, err := s.db.QueryContext(ctx, ssql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query all_indexes: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := map[string][]*Index{}
+	var index *Index
+	for rows.Next() {
+		var tableName, indexName, uniqueness, indexType, columnName string
+		var expression sql.NullString
+		if err := rows.Scan(&tableName, &indexName, &uniqueness, &indexType, &columnName, &expression); err != nil {
 			return rtc, fmt.Errorf("failed to scan row: %s", err)
 		}
 
-		t, err := s.GetTable(tableSchema, tableName)
-		if err != nil {
-			return rtc, fmt.Errorf("failed to get data for %s.%s: %s", tableSchema, tableName, err)
+		// Every column of an index is returned as an own row
+		indexes := rtc[tableName]
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != indexName {
+			index = &Index{
+				Name:   indexName,
+				Unique: uniqueness == "UNIQUE",
+				Type:   indexType,
+			}
+			rtc[tableName] = append(indexes, index)
+		}
+
+		// Function based indexes (also descending columns) use a hidden column
+		if expression.Valid {
+			columnName = expression.String
+			if unquoted := strings.Trim(columnName, `"`); len(unquoted)+2 == len(columnName) && !strings.Contains(unquoted, `"`) {
+				columnName = unquoted
+			}
 		}
-		rtc = append(rtc, t)
+		index.Columns = append(index.Columns, columnName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return rtc, fmt.Errorf("failed to read rows: %s", err)
 	}
 
 	return rtc, nil
@@ -213,25 +1063,47 @@ func (s *OracleDb) GetTablesByType(schema string, typ OracleTableType) ([]*Table
 func (s *OracleDb) GetDataType(internalType string, col *OracleColumn) DataType {
 	internalType = strings.ToLower(internalType)
 
+	// Data types with a precision in the middle of the name
+	switch {
+	case strings.HasPrefix(internalType, "timestamp") && strings.HasSuffix(internalType, "time zone"):
+		return TimestampTzType
+	case strings.HasPrefix(internalType, "interval"):
+		return IntervalType
+	}
+
 	// Remove any data type length (for some datatypes they are returned...)
 	if lastBracket := strings.Index(internalType, "("); lastBracket != -1 {
 		internalType = internalType[:lastBracket]
 	}
 
 	switch internalType {
-	case "varchar", "varchar2", "nvarchar", "nvarchar2":
+	case "varchar", "varchar2", "nvarchar", "nvarchar2", "char", "nchar":
 		return StringType
-	case "double":
+	case "clob", "nclob", "long":
+		return TextType
+	case "blob", "raw", "long raw", "bfile":
+		return BinaryType
+	case "double", "float", "binary_float", "binary_double":
 		return DoubleType
-	case "date", "timestamp", "timestamptz":
+	case "date", "timestamp":
 		return DateType
+	case "timestamptz":
+		return TimestampTzType
+	case "json":
+		return JsonType
+	case "boolean":
+		return BoolType
 	default:
-		// A number can either be a double or a int
+		// A number can either be a int, an exact decimal or a floating point
+		// number if no precision was given
 		if internalType == "number" {
-			if col.Scale == 0 {
+			switch col.Scale {
+			case 0:
 				return IntType
-			} else {
+			case 64:
 				return DoubleType
+			default:
+				return DecimalType
 			}
 		}
 		logger.Warning("OracleDb: received unknown data type column: %s", internalType)
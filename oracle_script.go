@@ -0,0 +1,1511 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var _ DbSystem = &OracleScript{}
+var _ DbSystemSequences = &OracleScript{}
+
+// OracleScript implements "DbSystem" for SQL scripts of an oracle database.
+// The statements "CREATE TABLE", "ALTER TABLE", "COMMENT ON COLUMN", "DROP TABLE",
+// "CREATE INDEX", "DROP INDEX", "CREATE SEQUENCE", "ALTER SEQUENCE", "DROP SEQUENCE",
+// "CREATE TRIGGER", "DROP TRIGGER" and "ALTER SESSION SET CURRENT_SCHEMA" are applied
+// in the order they are parsed.
+// Any other statement is ignored. PL/SQL blocks have to be terminated with
+// a "/" on a single line like it's required by SQL*Plus.
+// SQL*Plus commands like "SET DEFINE OFF" or "PROMPT" are terminated by the end of the line
+type OracleScript struct {
+
+	// Schema used for tables that are not qualified with a schema
+	schema string
+
+	tables    []*oracleScriptTable
+	sequences []*Sequence
+}
+
+// oracleScriptTable contains the table with all constraints
+// that are required to build the column information
+type oracleScriptTable struct {
+	*Table
+
+	constraints []*oracleScriptConstraint
+
+	// "BEFORE INSERT" triggers that fill a column with a sequence
+	triggers []*oracleScriptTrigger
+
+	// Indexes created with "CREATE INDEX"
+	indexes []*Index
+}
+
+type oracleScriptTrigger struct {
+	name     string
+	column   string
+	sequence *Sequence
+}
+
+type oracleScriptConstraint struct {
+	name    string
+	typ     string
+	columns []string
+
+	// Foreign key reference
+	refSchema  string
+	refTable   string
+	refColumns []string
+	onDelete   string
+
+	// Condition of a check constraint and the allowed values if it's
+	// like "COL IN ('A', 'B')"
+	condition  string
+	enumValues []string
+}
+
+// NewOracleScript initializes a new parser for SQL scripts of an oracle database.
+// The schema is used for all tables without an explicit schema until it's
+// changed with "ALTER SESSION SET CURRENT_SCHEMA"
+func NewOracleScript(schema string) *OracleScript {
+	return &OracleScript{
+		schema: strings.ToUpper(schema),
+	}
+}
+
+// ParseFile parses the SQL script of the file and applies all statements
+// to the existing tables
+func (s *OracleScript) ParseFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %q: %s", path, err)
+	}
+
+	if err := s.Parse(string(content)); err != nil {
+		return fmt.Errorf("failed to parse file %q: %s", path, err)
+	}
+	return nil
+}
+
+// Parse parses the SQL script and applies all statements to the existing tables
+func (s *OracleScript) Parse(script string) error {
+	p, err := newSqlParser(removeSqlplusCommands(script), oracleDialect)
+	if err != nil {
+		return err
+	}
+
+	for !p.eof() {
+		if p.acceptSymbol(";") || p.acceptSymbol("/") {
+			continue
+		}
+
+		var err error
+		switch {
+		case p.isKeywords("CREATE", "TABLE"), p.isKeywords("CREATE", "GLOBAL", "TEMPORARY", "TABLE"),
+			p.isKeywords("CREATE", "PRIVATE", "TEMPORARY", "TABLE"):
+			err = s.parseCreateTable(p)
+		case p.isKeywords("ALTER", "TABLE"):
+			err = s.parseAlterTable(p)
+		case p.isKeywords("ALTER", "SESSION", "SET", "CURRENT_SCHEMA"):
+			p.acceptKeywords("ALTER", "SESSION", "SET", "CURRENT_SCHEMA")
+			p.acceptSymbol("=")
+			var schema string
+			if schema, err = oracleIdentifier(p); err == nil {
+				s.schema = schema
+			}
+		case p.isKeywords("COMMENT", "ON", "COLUMN"):
+			err = s.parseComment(p)
+		case p.isKeywords("DROP", "TABLE"):
+			err = s.parseDropTable(p)
+		case p.isKeywords("CREATE", "INDEX"), p.isKeywords("CREATE", "UNIQUE", "INDEX"), p.isKeywords("CREATE", "BITMAP", "INDEX"):
+			err = s.parseCreateIndex(p)
+		case p.isKeywords("DROP", "INDEX"):
+			err = s.parseDropIndex(p)
+		case p.isKeywords("CREATE", "SEQUENCE"), p.isKeywords("ALTER", "SEQUENCE"):
+			err = s.parseSequence(p)
+		case p.isKeywords("DROP", "SEQUENCE"):
+			err = s.parseDropSequence(p)
+		case p.isKeywords("DROP", "TRIGGER"):
+			err = s.parseDropTrigger(p)
+		case s.isTrigger(p):
+			s.parseTrigger(p)
+			continue
+		case s.isPlsqlBlock(p):
+			s.skipPlsqlBlock(p)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		p.skipStatement()
+	}
+
+	s.resolveReferences()
+	return nil
+}
+
+// sqlplusCommands contains the SQL*Plus commands that don't require a terminating semicolon
+var sqlplusCommands = map[string]bool{
+	"SET": true, "PROMPT": true, "PRO": true, "REM": true, "REMARK": true, "SPOOL": true,
+	"WHENEVER": true, "DEFINE": true, "UNDEFINE": true, "EXIT": true, "QUIT": true,
+}
+
+// removeSqlplusCommands replaces the SQL*Plus commands at the start of a statement with an
+// empty line. The number of lines is kept for the errors of the parser
+func removeSqlplusCommands(script string) string {
+	lines := strings.Split(script, "\n")
+
+	statementStart := true
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "", strings.HasPrefix(trimmed, "--"), strings.HasPrefix(trimmed, "/*") && strings.HasSuffix(trimmed, "*/"):
+			continue
+		case statementStart && (strings.HasPrefix(trimmed, "@") || sqlplusCommands[strings.ToUpper(strings.Fields(trimmed)[0])]):
+			lines[i] = ""
+			continue
+		}
+
+		statementStart = strings.HasSuffix(trimmed, ";") || trimmed == "/"
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *OracleScript) GetTable(schema, name string) (*Table, error) {
+	if t := s.findTable(strings.ToUpper(schema), strings.ToUpper(name)); t != nil {
+		return t.Table, nil
+	}
+
+	return nil, fmt.Errorf("%s.%s was not found", schema, name)
+}
+
+func (s *OracleScript) GetTables(schema string) ([]*Table, error) {
+	rtc := []*Table{}
+	for _, t := range s.tables {
+		if t.Schema == strings.ToUpper(schema) {
+			rtc = append(rtc, t.Table)
+		}
+	}
+
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+	return rtc, nil
+}
+
+func (s *OracleScript) GetSequences(schema string) ([]*Sequence, error) {
+	rtc := []*Sequence{}
+	for _, seq := range s.sequences {
+		if seq.Schema == strings.ToUpper(schema) {
+			rtc = append(rtc, seq)
+		}
+	}
+
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+	return rtc, nil
+}
+
+func (s *OracleScript) findSequence(schema, name string) *Sequence {
+	for _, seq := range s.sequences {
+		if seq.Schema == schema && seq.Name == name {
+			return seq
+		}
+	}
+
+	return nil
+}
+
+func (s *OracleScript) findTable(schema, name string) *oracleScriptTable {
+	for _, t := range s.tables {
+		if t.Schema == schema && t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// isPlsqlBlock returns weather the current statement is a PL/SQL block or unit
+func (s *OracleScript) isPlsqlBlock(p *sqlParser) bool {
+	if p.isKeyword(0, "BEGIN") || p.isKeyword(0, "DECLARE") {
+		return true
+	}
+	if !p.isKeyword(0, "CREATE") {
+		return false
+	}
+
+	for i := 1; i < 5; i++ {
+		for _, k := range []string{"PROCEDURE", "FUNCTION", "PACKAGE", "TRIGGER", "TYPE"} {
+			if p.isKeyword(i, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// skipPlsqlBlock skips all tokens till a "/" that stands on a single line
+func (s *OracleScript) skipPlsqlBlock(p *sqlParser) {
+	for !p.eof() {
+		t := p.next()
+		if t.typ == tokenSymbol && t.value == "/" &&
+			p.tokens[p.pos-2].line != t.line && (p.peek().line != t.line || p.eof()) {
+			return
+		}
+	}
+}
+
+// oracleIdentifier consumes an identifier. Unquoted identifiers are case insensitive
+// and stored in upper case
+func oracleIdentifier(p *sqlParser) (string, error) {
+	name, quoted, err := p.identifier()
+	if !quoted {
+		name = strings.ToUpper(name)
+	}
+	return name, err
+}
+
+// qualifiedName consumes an identifier that is optional prefixed with a schema.
+// If no schema is given, the current schema is returned
+func (s *OracleScript) qualifiedName(p *sqlParser) (schema, name string, err error) {
+	schema, name, quoted, err := p.qualifiedName()
+	if err != nil {
+		return
+	}
+
+	if !quoted {
+		name = strings.ToUpper(name)
+	}
+	if schema == "" {
+		schema = s.schema
+	} else if p.tokens[p.pos-3].typ != tokenQuotedIdent {
+		schema = strings.ToUpper(schema)
+	}
+
+	return
+}
+
+// identifierList consumes a list of identifiers in brackets
+func (s *OracleScript) identifierList(p *sqlParser) ([]string, error) {
+	tokens, err := p.identifierList()
+	if err != nil {
+		return nil, err
+	}
+
+	rtc := make([]string, len(tokens))
+	for i, t := range tokens {
+		rtc[i] = t.value
+		if t.typ != tokenQuotedIdent {
+			rtc[i] = strings.ToUpper(t.value)
+		}
+	}
+	return rtc, nil
+}
+
+// tableByName returns the table or an error if it does not exist
+func (s *OracleScript) tableByName(p *sqlParser, schema, name string) (*oracleScriptTable, error) {
+	if t := s.findTable(schema, name); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("line %d: table %s.%s was not found", p.peek().line, schema, name)
+}
+
+func (s *OracleScript) parseCreateTable(p *sqlParser) error {
+	p.acceptKeywords("CREATE")
+	if !p.acceptKeywords("GLOBAL", "TEMPORARY") {
+		p.acceptKeywords("PRIVATE", "TEMPORARY")
+	}
+	p.acceptKeywords("TABLE")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if s.findTable(schema, name) != nil {
+		return fmt.Errorf("line %d: table %s.%s does already exist", p.peek().line, schema, name)
+	}
+
+	// Tables created with "AS SELECT" are not supported
+	if !p.isSymbol("(") {
+		return nil
+	}
+	p.next()
+
+	tbl := &oracleScriptTable{
+		Table: &Table{
+			Name:   name,
+			Schema: schema,
+			Kind:   TableKindTable,
+		},
+	}
+	for {
+		if err := s.parseTableElement(p, tbl); err != nil {
+			return err
+		}
+
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+
+	s.tables = append(s.tables, tbl)
+	tbl.apply()
+
+	// Physical properties are ignored
+	return nil
+}
+
+// parseTableElement parses a column or an out-of-line constraint definition
+func (s *OracleScript) parseTableElement(p *sqlParser, tbl *oracleScriptTable) error {
+	if isConstraint, err := s.parseConstraint(p, tbl); isConstraint || err != nil {
+		return err
+	}
+
+	column, err := s.parseColumn(p, tbl, nil)
+	if err != nil {
+		return err
+	}
+	tbl.Columns = append(tbl.Columns, column.Column)
+
+	return nil
+}
+
+// parseConstraint parses an out-of-line constraint. If the current tokens
+// are not the start of a constraint, false is returned
+func (s *OracleScript) parseConstraint(p *sqlParser, tbl *oracleScriptTable) (bool, error) {
+	constraint := &oracleScriptConstraint{}
+	if p.acceptKeywords("CONSTRAINT") {
+		name, err := oracleIdentifier(p)
+		if err != nil {
+			return true, err
+		}
+		constraint.name = name
+	}
+
+	var err error
+	switch {
+	case p.acceptKeywords("PRIMARY", "KEY"):
+		constraint.typ = "P"
+		constraint.columns, err = s.identifierList(p)
+	case p.acceptKeywords("UNIQUE"):
+		constraint.typ = "U"
+		constraint.columns, err = s.identifierList(p)
+	case p.acceptKeywords("FOREIGN", "KEY"):
+		constraint.typ = "R"
+		if constraint.columns, err = s.identifierList(p); err == nil {
+			err = s.parseReference(p, constraint)
+		}
+	case p.acceptKeywords("CHECK"):
+		constraint.typ = "C"
+		var condition string
+		if condition, err = p.skipBrackets(); err == nil {
+			s.parseEnumCheck(constraint, condition)
+		}
+	default:
+		if constraint.name != "" {
+			return true, p.errorf("expected constraint definition")
+		}
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	tbl.constraints = append(tbl.constraints, constraint)
+
+	// Skip the constraint state like "USING INDEX" or "ENABLE"
+	p.expression()
+	return true, nil
+}
+
+// parseEnumCheck sets the condition of the check constraint. The column and the
+// allowed values are set if the condition restricts a column to a list of values
+func (s *OracleScript) parseEnumCheck(constraint *oracleScriptConstraint, condition string) {
+	constraint.condition = condition
+	if column, values, ok := parseOracleEnumCheck(condition); ok {
+		constraint.columns = []string{column}
+		constraint.enumValues = values
+	}
+}
+
+// parseReference parses the referenced table and columns of a foreign key
+func (s *OracleScript) parseReference(p *sqlParser, constraint *oracleScriptConstraint) error {
+	if err := p.expectKeywords("REFERENCES"); err != nil {
+		return err
+	}
+
+	refSchema, refTable, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	constraint.refSchema = refSchema
+	constraint.refTable = refTable
+
+	// The primary key is referenced if no columns are provided
+	if p.isSymbol("(") {
+		if constraint.refColumns, err = s.identifierList(p); err != nil {
+			return err
+		}
+	}
+
+	constraint.onDelete = "NO ACTION"
+	if p.acceptKeywords("ON", "DELETE") {
+		switch {
+		case p.acceptKeywords("CASCADE"):
+			constraint.onDelete = "CASCADE"
+		case p.acceptKeywords("SET", "NULL"):
+			constraint.onDelete = "SET NULL"
+		}
+	}
+
+	return nil
+}
+
+// parseColumn parses a column definition. For "ALTER TABLE ... MODIFY", the
+// existing column is provided and only the specified attributes are modified
+func (s *OracleScript) parseColumn(p *sqlParser, tbl *oracleScriptTable, existing *OracleColumn) (*OracleColumn, error) {
+	name, err := oracleIdentifier(p)
+	if err != nil {
+		return nil, err
+	}
+
+	column := existing
+	if column == nil {
+		column = (&OracleDb{}).newColumn()
+		column.Name = name
+		column.CanBeNull = true
+	}
+
+	// The data type is optional for virtual columns
+	if !p.isKeyword(0, "GENERATED") && !p.isKeyword(0, "AS") && p.peek().typ == tokenIdent && !s.isColumnAttribute(p) {
+		if err := s.parseDataType(p, column); err != nil {
+			return nil, err
+		}
+		column.Type = (&OracleDb{}).GetDataType(column.InternalType, column)
+		column.NumericPrecision, column.NumericScale = 0, 0
+		if column.Type == DecimalType {
+			column.NumericPrecision = column.DataTypeLenght
+			column.NumericScale = column.Scale
+		}
+	}
+
+	// Column attributes
+	for !p.isSymbol(",") && !p.isSymbol(")") && !p.isSymbol(";") && !p.eof() {
+		constraintName := ""
+		if p.acceptKeywords("CONSTRAINT") {
+			if constraintName, err = oracleIdentifier(p); err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case p.acceptKeywords("NOT", "NULL"):
+			column.CanBeNull = false
+		case p.acceptKeywords("NULL"):
+			column.CanBeNull = true
+		case p.acceptKeywords("DEFAULT"):
+			p.acceptKeywords("ON", "NULL")
+			expr := p.expression(
+				"NOT", "NULL", "CONSTRAINT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "ENABLE", "DISABLE",
+				"VISIBLE", "INVISIBLE", "ENCRYPT",
+			)
+			if expr == "" && p.isKeyword(0, "NULL") {
+				expr = p.next().value
+			}
+			column.DefaultValue = sql.NullString{Valid: true, String: expr}
+
+			// The default value contains the raw single quotes of the create statement
+			column.DefaultValue.String = strings.TrimPrefix(column.DefaultValue.String, "'")
+			column.DefaultValue.String = strings.TrimSuffix(column.DefaultValue.String, "'")
+		case p.acceptKeywords("GENERATED"):
+			generation := "ALWAYS"
+			if p.acceptKeywords("BY", "DEFAULT") {
+				generation = "BY DEFAULT"
+				p.acceptKeywords("ON", "NULL")
+			} else {
+				p.acceptKeywords("ALWAYS")
+			}
+			if p.acceptKeywords("AS", "IDENTITY") {
+				column.IdentityGeneration = generation
+				column.Sequence = &Sequence{Schema: tbl.Schema, Start: 1, Increment: 1}
+				column.CanBeNull = false
+
+				// Identity options
+				if p.isSymbol("(") {
+					options, err := p.skipBrackets()
+					if err != nil {
+						return nil, err
+					}
+					if o, err := newSqlParser(options, oracleDialect); err == nil {
+						s.parseSequenceOptions(o, column.Sequence)
+					}
+				}
+			} else if err := s.parseVirtualColumn(p, column); err != nil {
+				return nil, err
+			}
+		case p.isKeyword(0, "AS"):
+			if err := s.parseVirtualColumn(p, column); err != nil {
+				return nil, err
+			}
+		case p.acceptKeywords("DROP", "IDENTITY"):
+			column.IdentityGeneration = ""
+			column.Sequence = nil
+		case p.acceptKeywords("VISIBLE"):
+			column.Invisible = false
+		case p.acceptKeywords("INVISIBLE"):
+			column.Invisible = true
+		case p.acceptKeywords("PRIMARY", "KEY"):
+			tbl.constraints = append(tbl.constraints, &oracleScriptConstraint{name: constraintName, typ: "P", columns: []string{column.Name}})
+		case p.acceptKeywords("UNIQUE"):
+			tbl.constraints = append(tbl.constraints, &oracleScriptConstraint{name: constraintName, typ: "U", columns: []string{column.Name}})
+		case p.isKeyword(0, "REFERENCES"):
+			constraint := &oracleScriptConstraint{name: constraintName, typ: "R", columns: []string{column.Name}}
+			if err := s.parseReference(p, constraint); err != nil {
+				return nil, err
+			}
+			tbl.constraints = append(tbl.constraints, constraint)
+		case p.acceptKeywords("CHECK"):
+			condition, err := p.skipBrackets()
+			if err != nil {
+				return nil, err
+			}
+			constraint := &oracleScriptConstraint{name: constraintName, typ: "C", columns: []string{column.Name}}
+			s.parseEnumCheck(constraint, condition)
+			tbl.constraints = append(tbl.constraints, constraint)
+		case p.acceptKeywords("SORT"),
+			p.acceptKeywords("ENABLE"), p.acceptKeywords("DISABLE"), p.acceptKeywords("VALIDATE"), p.acceptKeywords("NOVALIDATE"),
+			p.acceptKeywords("RELY"), p.acceptKeywords("NORELY"), p.acceptKeywords("DEFERRABLE"), p.acceptKeywords("NOT", "DEFERRABLE"),
+			p.acceptKeywords("INITIALLY", "DEFERRED"), p.acceptKeywords("INITIALLY", "IMMEDIATE"):
+		case p.acceptKeywords("USING", "INDEX"), p.acceptKeywords("ENCRYPT"), p.acceptKeywords("COLLATE"):
+			p.expression("NOT", "NULL", "CONSTRAINT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "ENABLE", "DISABLE")
+		default:
+			return nil, p.errorf("unknown attribute for column %q", column.Name)
+		}
+	}
+
+	return column, nil
+}
+
+// isColumnAttribute returns weather the current token is the start of a
+// column attribute instead of a data type
+func (s *OracleScript) isColumnAttribute(p *sqlParser) bool {
+	for _, k := range []string{"DEFAULT", "NOT", "NULL", "CONSTRAINT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "VISIBLE", "INVISIBLE"} {
+		if p.isKeyword(0, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVirtualColumn parses the expression of a virtual column
+func (s *OracleScript) parseVirtualColumn(p *sqlParser, column *OracleColumn) error {
+	if err := p.expectKeywords("AS"); err != nil {
+		return err
+	}
+	expression, err := p.skipBrackets()
+	if err != nil {
+		return err
+	}
+	column.GenerationExpression = expression
+	p.acceptKeywords("VIRTUAL")
+
+	return nil
+}
+
+// oracleTypeAliases maps ANSI data types to the name used by oracle
+var oracleTypeAliases = map[string]string{
+	"VARCHAR":  "VARCHAR2",
+	"NUMERIC":  "NUMBER",
+	"DECIMAL":  "NUMBER",
+	"DEC":      "NUMBER",
+	"INTEGER":  "NUMBER",
+	"INT":      "NUMBER",
+	"SMALLINT": "NUMBER",
+}
+
+// parseDataType parses the data type of a column and sets the internal type,
+// length and scale like it's returned by all_tab_columns
+func (s *OracleScript) parseDataType(p *sqlParser, column *OracleColumn) error {
+	typeName := strings.ToUpper(p.next().value)
+
+	// Data types consisting of multiple words
+	switch {
+	case typeName == "DOUBLE" && p.acceptKeywords("PRECISION"):
+		typeName = "DOUBLE PRECISION"
+	case typeName == "LONG" && p.acceptKeywords("RAW"):
+		typeName = "LONG RAW"
+	case (typeName == "CHARACTER" || typeName == "CHAR") && p.acceptKeywords("VARYING"):
+		typeName = "VARCHAR2"
+	case typeName == "NATIONAL":
+		p.acceptKeywords("CHARACTER")
+		p.acceptKeywords("CHAR")
+		typeName = "NCHAR"
+		if p.acceptKeywords("VARYING") {
+			typeName = "NVARCHAR2"
+		}
+	case typeName == "CHARACTER":
+		typeName = "CHAR"
+	}
+
+	// Integers don't have a precision
+	integer := typeName == "INTEGER" || typeName == "INT" || typeName == "SMALLINT"
+	if alias, ok := oracleTypeAliases[typeName]; ok {
+		typeName = alias
+	}
+
+	// Arguments like "(10, 2)" or "(100 CHAR)"
+	args := []string{}
+	charSemantics := false
+	if p.acceptSymbol("(") {
+		for !p.acceptSymbol(")") {
+			t := p.next()
+			switch {
+			case t.typ == tokenEOF:
+				return p.errorf("missing closing bracket for data type")
+			case t.typ == tokenNumber, t.typ == tokenSymbol && t.value == "*":
+				args = append(args, t.value)
+			case t.typ == tokenIdent && strings.EqualFold(t.value, "CHAR"):
+				charSemantics = true
+			}
+		}
+	}
+	arg := func(i int, def int) int {
+		if len(args) > i {
+			if val, err := strconv.Atoi(args[i]); err == nil {
+				return val
+			}
+		}
+		return def
+	}
+
+	column.InternalType = typeName
+	switch typeName {
+	case "NUMBER":
+		switch {
+		case integer:
+			column.DataTypeLenght = 22
+			column.Scale = 0
+		case len(args) == 0:
+			// A number without a scale is a floating point number.
+			// See "OracleDb.GetTable"
+			column.DataTypeLenght = 22
+			column.Scale = 64
+		default:
+			column.DataTypeLenght = arg(0, 22)
+			column.Scale = arg(1, 0)
+		}
+	case "FLOAT", "REAL", "DOUBLE PRECISION":
+		column.InternalType = "FLOAT"
+		column.DataTypeLenght = arg(0, 126)
+		if typeName == "REAL" {
+			column.DataTypeLenght = 63
+		}
+	case "VARCHAR2", "CHAR":
+		column.DataTypeLenght = arg(0, 1)
+
+		// The length is returned in bytes. We expect the database
+		// character set "AL32UTF8" with up to 4 bytes per character
+		if charSemantics {
+			column.CharLenght = column.DataTypeLenght
+			column.DataTypeLenght *= 4
+		}
+	case "NVARCHAR2", "NCHAR":
+		// The national character set "AL16UTF16" uses 2 bytes per character
+		column.CharLenght = arg(0, 1)
+		column.DataTypeLenght = column.CharLenght * 2
+	case "RAW":
+		column.DataTypeLenght = arg(0, 0)
+	case "DATE":
+		column.DataTypeLenght = 7
+	case "TIMESTAMP":
+		precision := arg(0, 6)
+		column.InternalType = fmt.Sprintf("TIMESTAMP(%d)", precision)
+		column.DataTypeLenght = 11
+		if precision == 0 {
+			column.DataTypeLenght = 7
+		}
+		column.Scale = precision
+
+		if p.acceptKeywords("WITH", "TIME", "ZONE") {
+			column.InternalType += " WITH TIME ZONE"
+			column.DataTypeLenght = 13
+		} else if p.acceptKeywords("WITH", "LOCAL", "TIME", "ZONE") {
+			column.InternalType += " WITH LOCAL TIME ZONE"
+		}
+	case "INTERVAL":
+		if p.acceptKeywords("YEAR") {
+			precision := s.parseTypePrecision(p, 2)
+			if err := p.expectKeywords("TO", "MONTH"); err != nil {
+				return err
+			}
+			column.InternalType = fmt.Sprintf("INTERVAL YEAR(%d) TO MONTH", precision)
+			column.DataTypeLenght = precision
+		} else {
+			if err := p.expectKeywords("DAY"); err != nil {
+				return err
+			}
+			precision := s.parseTypePrecision(p, 2)
+			if err := p.expectKeywords("TO", "SECOND"); err != nil {
+				return err
+			}
+			scale := s.parseTypePrecision(p, 6)
+			column.InternalType = fmt.Sprintf("INTERVAL DAY(%d) TO SECOND(%d)", precision, scale)
+			column.DataTypeLenght = precision
+			column.Scale = scale
+		}
+	case "CLOB", "NCLOB", "BLOB", "BFILE":
+		column.DataTypeLenght = 4000
+	case "BINARY_FLOAT":
+		column.DataTypeLenght = 4
+	case "BINARY_DOUBLE":
+		column.DataTypeLenght = 8
+	case "ROWID":
+		column.DataTypeLenght = 10
+	}
+
+	return nil
+}
+
+// parseTypePrecision parses an optional precision in brackets like "(2)"
+func (s *OracleScript) parseTypePrecision(p *sqlParser, def int) int {
+	if !p.isSymbol("(") {
+		return def
+	}
+
+	content, _ := p.skipBrackets()
+	if val, err := strconv.Atoi(strings.TrimSpace(content)); err == nil {
+		return val
+	}
+	return def
+}
+
+// parseComment parses a "COMMENT ON COLUMN" statement
+func (s *OracleScript) parseComment(p *sqlParser) error {
+	p.acceptKeywords("COMMENT", "ON", "COLUMN")
+
+	// The column is either qualified with "table.column" or "schema.table.column"
+	parts := []string{}
+	for {
+		name, err := oracleIdentifier(p)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, name)
+		if !p.acceptSymbol(".") {
+			break
+		}
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		return p.errorf("expected column name qualified with the table")
+	}
+	schema := s.schema
+	if len(parts) == 3 {
+		schema = parts[0]
+	}
+
+	if err := p.expectKeywords("IS"); err != nil {
+		return err
+	}
+	comment := p.next()
+	if comment.typ != tokenString {
+		return p.errorf("expected comment string")
+	}
+
+	tbl, err := s.tableByName(p, schema, parts[len(parts)-2])
+	if err != nil {
+		return err
+	}
+	col := tbl.column(parts[len(parts)-1])
+	if col == nil {
+		return fmt.Errorf("line %d: column %q was not found", comment.line, parts[len(parts)-1])
+	}
+	col.Comment = strings.ReplaceAll(comment.value, "\\n", "\n")
+
+	return nil
+}
+
+func (s *OracleScript) parseDropTable(p *sqlParser) error {
+	p.acceptKeywords("DROP", "TABLE")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	tbl, err := s.tableByName(p, schema, name)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range s.tables {
+		if t == tbl {
+			s.tables = append(s.tables[:i], s.tables[i+1:]...)
+			break
+		}
+	}
+
+	// Drop foreign keys of other tables with "CASCADE CONSTRAINTS"
+	if p.acceptKeywords("CASCADE", "CONSTRAINTS") {
+		for _, t := range s.tables {
+			for i := len(t.constraints) - 1; i >= 0; i-- {
+				if t.constraints[i].refSchema == tbl.Schema && t.constraints[i].refTable == tbl.Name {
+					t.constraints = append(t.constraints[:i], t.constraints[i+1:]...)
+				}
+			}
+			t.apply()
+		}
+	}
+
+	return nil
+}
+
+func (s *OracleScript) parseCreateIndex(p *sqlParser) error {
+	p.acceptKeywords("CREATE")
+	index := &Index{Type: "NORMAL"}
+	if p.acceptKeywords("UNIQUE") {
+		index.Unique = true
+	} else if p.acceptKeywords("BITMAP") {
+		index.Type = "BITMAP"
+	}
+	p.acceptKeywords("INDEX")
+
+	_, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	index.Name = name
+	if err := p.expectKeywords("ON"); err != nil {
+		return err
+	}
+
+	schema, tableName, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	tbl, err := s.tableByName(p, schema, tableName)
+	if err != nil {
+		return err
+	}
+
+	// Columns or expressions of a function based index
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	functionBased := false
+	for {
+		start := p.pos
+		expr := p.expression("ASC", "DESC")
+		if expr == "" {
+			return p.errorf("expected index column")
+		}
+
+		column := expr
+		if p.pos-start == 1 && p.tokens[start].typ == tokenIdent {
+			column = strings.ToUpper(expr)
+		} else if p.pos-start == 1 && p.tokens[start].typ == tokenQuotedIdent {
+			column = p.tokens[start].value
+		} else {
+			functionBased = true
+		}
+		index.Columns = append(index.Columns, column)
+
+		// Descending columns are stored as function based index
+		if p.acceptKeywords("DESC") {
+			functionBased = true
+		} else {
+			p.acceptKeywords("ASC")
+		}
+
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+	if functionBased {
+		index.Type = "FUNCTION-BASED " + index.Type
+	}
+
+	tbl.indexes = append(tbl.indexes, index)
+	tbl.apply()
+
+	// Index options like the tablespace are ignored
+	return nil
+}
+
+func (s *OracleScript) parseDropIndex(p *sqlParser) error {
+	p.acceptKeywords("DROP", "INDEX")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range s.tables {
+		if t.Schema != schema {
+			continue
+		}
+		for i, idx := range t.indexes {
+			if idx.Name == name {
+				t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+				t.apply()
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("line %d: index %s.%s was not found", p.peek().line, schema, name)
+}
+
+// parseSequence parses a "CREATE SEQUENCE" or "ALTER SEQUENCE" statement
+func (s *OracleScript) parseSequence(p *sqlParser) error {
+	create := p.acceptKeywords("CREATE")
+	if !create {
+		p.acceptKeywords("ALTER")
+	}
+	p.acceptKeywords("SEQUENCE")
+	ifNotExists := create && p.acceptKeywords("IF", "NOT", "EXISTS")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+
+	seq := s.findSequence(schema, name)
+	switch {
+	case create && seq != nil && ifNotExists:
+		return nil
+	case create && seq != nil:
+		return fmt.Errorf("line %d: sequence %s.%s does already exist", p.peek().line, schema, name)
+	case create:
+		seq = &Sequence{Schema: schema, Name: name, Start: 1, Increment: 1}
+		s.sequences = append(s.sequences, seq)
+	case seq == nil:
+		return fmt.Errorf("line %d: sequence %s.%s was not found", p.peek().line, schema, name)
+	}
+
+	s.parseSequenceOptions(p, seq)
+	return nil
+}
+
+// parseSequenceOptions parses the options of a sequence or an identity column till
+// the end of the statement. Unsupported options are ignored
+func (s *OracleScript) parseSequenceOptions(p *sqlParser, seq *Sequence) {
+	number := func() int64 {
+		negative := p.acceptSymbol("-")
+		v, _ := strconv.ParseInt(p.next().value, 10, 64)
+		if negative {
+			return -v
+		}
+		return v
+	}
+
+	for !p.eof() && !p.isSymbol(";") {
+		switch {
+		case p.acceptKeywords("START", "WITH"):
+			if !p.isKeyword(0, "LIMIT") {
+				seq.Start = number()
+			}
+		case p.acceptKeywords("INCREMENT", "BY"):
+			seq.Increment = number()
+		case p.acceptKeywords("CYCLE"):
+			seq.Cycle = true
+		case p.acceptKeywords("NOCYCLE"):
+			seq.Cycle = false
+		default:
+			p.next()
+		}
+	}
+}
+
+func (s *OracleScript) parseDropSequence(p *sqlParser) error {
+	p.acceptKeywords("DROP", "SEQUENCE")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+
+	for i, seq := range s.sequences {
+		if seq.Schema == schema && seq.Name == name {
+			s.sequences = append(s.sequences[:i], s.sequences[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("line %d: sequence %s.%s was not found", p.peek().line, schema, name)
+}
+
+// isTrigger returns weather the current statement creates a trigger
+func (s *OracleScript) isTrigger(p *sqlParser) bool {
+	for i := 1; i < 5; i++ {
+		if p.isKeyword(i, "TRIGGER") {
+			return p.isKeyword(0, "CREATE")
+		}
+	}
+	return false
+}
+
+// parseTrigger skips the PL/SQL block of a trigger. If it's a "BEFORE INSERT" trigger
+// for each row that assigns the next value of a sequence to a column, the column
+// is marked as auto increment. Triggers of unknown tables are ignored
+func (s *OracleScript) parseTrigger(p *sqlParser) {
+	start := p.peek().start
+	for !p.eof() && !p.isKeyword(0, "TRIGGER") {
+		p.next()
+	}
+	p.next()
+	_, triggerName, err := s.qualifiedName(p)
+	if err != nil {
+		s.skipPlsqlBlock(p)
+		return
+	}
+
+	// Header till the table
+	before, insert := false, false
+	for !p.eof() && !p.isKeyword(0, "ON") {
+		before = before || p.isKeyword(0, "BEFORE")
+		insert = insert || p.isKeyword(0, "INSERT")
+		p.next()
+	}
+	p.next()
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		s.skipPlsqlBlock(p)
+		return
+	}
+
+	forEachRow := p.isKeywords("FOR", "EACH", "ROW")
+	s.skipPlsqlBlock(p)
+
+	// Replace an existing trigger ("CREATE OR REPLACE")
+	s.dropTrigger(triggerName)
+
+	tbl := s.findTable(schema, name)
+	if !before || !insert || !forEachRow || tbl == nil {
+		return
+	}
+
+	column, seq, ok := parseOracleSequenceTrigger(p.input[start:p.tokens[p.pos-1].start])
+	if !ok || tbl.GetColumn(column) == nil {
+		return
+	}
+	if seq.Schema == "" {
+		seq.Schema = tbl.Schema
+	}
+	tbl.triggers = append(tbl.triggers, &oracleScriptTrigger{name: triggerName, column: column, sequence: seq})
+	tbl.apply()
+}
+
+func (s *OracleScript) parseDropTrigger(p *sqlParser) error {
+	p.acceptKeywords("DROP", "TRIGGER")
+
+	_, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	s.dropTrigger(name)
+
+	return nil
+}
+
+// dropTrigger removes the trigger with the name from all tables
+func (s *OracleScript) dropTrigger(name string) {
+	for _, t := range s.tables {
+		for i, trg := range t.triggers {
+			if trg.name == name {
+				t.triggers = append(t.triggers[:i], t.triggers[i+1:]...)
+				t.apply()
+				return
+			}
+		}
+	}
+}
+
+func (s *OracleScript) parseAlterTable(p *sqlParser) error {
+	p.acceptKeywords("ALTER", "TABLE")
+
+	schema, name, err := s.qualifiedName(p)
+	if err != nil {
+		return err
+	}
+	tbl, err := s.tableByName(p, schema, name)
+	if err != nil {
+		return err
+	}
+
+	for !p.eof() && !p.isSymbol(";") && !p.isSymbol("/") {
+		if err := s.parseAlterSpecification(p, tbl); err != nil {
+			return err
+		}
+		tbl.apply()
+	}
+
+	return nil
+}
+
+// parseAlterSpecification parses a single modification of an "ALTER TABLE" statement
+func (s *OracleScript) parseAlterSpecification(p *sqlParser, tbl *oracleScriptTable) error {
+	switch {
+	case p.acceptKeywords("ADD"):
+		// Multiple columns or constraints within brackets
+		if p.acceptSymbol("(") {
+			for {
+				if err := s.parseTableElement(p, tbl); err != nil {
+					return err
+				}
+				if p.acceptSymbol(")") {
+					return nil
+				}
+				if err := p.expectSymbol(","); err != nil {
+					return err
+				}
+			}
+		}
+		return s.parseTableElement(p, tbl)
+
+	case p.acceptKeywords("MODIFY"):
+		modify := func() error {
+			name, err := oracleIdentifier(p)
+			if err != nil {
+				return err
+			}
+			p.pos--
+
+			col := tbl.column(name)
+			if col == nil {
+				return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+			}
+			_, err = s.parseColumn(p, tbl, col.Extras.(*OracleColumn))
+			return err
+		}
+
+		// Constraint states are ignored
+		if p.isKeyword(0, "CONSTRAINT") || p.isKeywords("PRIMARY", "KEY") || p.isKeyword(0, "UNIQUE") {
+			p.skipStatement()
+			p.pos--
+			return nil
+		}
+
+		if p.acceptSymbol("(") {
+			for {
+				if err := modify(); err != nil {
+					return err
+				}
+				if p.acceptSymbol(")") {
+					return nil
+				}
+				if err := p.expectSymbol(","); err != nil {
+					return err
+				}
+			}
+		}
+		return modify()
+
+	case p.acceptKeywords("DROP"):
+		switch {
+		case p.acceptKeywords("PRIMARY", "KEY"):
+			tbl.dropConstraints(func(c *oracleScriptConstraint) bool { return c.typ == "P" })
+		case p.acceptKeywords("CONSTRAINT"):
+			name, err := oracleIdentifier(p)
+			if err != nil {
+				return err
+			}
+			tbl.dropConstraints(func(c *oracleScriptConstraint) bool { return c.name == name })
+		case p.acceptKeywords("COLUMN"):
+			name, err := oracleIdentifier(p)
+			if err != nil {
+				return err
+			}
+			if !tbl.dropColumn(name) {
+				return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+			}
+		case p.isSymbol("("):
+			columns, err := s.identifierList(p)
+			if err != nil {
+				return err
+			}
+			for _, name := range columns {
+				if !tbl.dropColumn(name) {
+					return fmt.Errorf("line %d: column %q was not found", p.peek().line, name)
+				}
+			}
+		default:
+			return p.errorf("unsupported drop clause")
+		}
+
+		// Skip options like "CASCADE CONSTRAINTS"
+		p.skipStatement()
+		p.pos--
+
+	case p.acceptKeywords("RENAME", "COLUMN"):
+		oldName, err := oracleIdentifier(p)
+		if err != nil {
+			return err
+		}
+		if err := p.expectKeywords("TO"); err != nil {
+			return err
+		}
+		newName, err := oracleIdentifier(p)
+		if err != nil {
+			return err
+		}
+		col := tbl.column(oldName)
+		if col == nil {
+			return fmt.Errorf("line %d: column %q was not found", p.peek().line, oldName)
+		}
+		col.Name = newName
+		tbl.renameColumnReferences(oldName, newName)
+
+	case p.acceptKeywords("RENAME", "TO"):
+		newName, err := oracleIdentifier(p)
+		if err != nil {
+			return err
+		}
+		for _, t := range s.tables {
+			for _, c := range t.constraints {
+				if c.refSchema == tbl.Schema && c.refTable == tbl.Name {
+					c.refTable = newName
+				}
+			}
+		}
+		tbl.Name = newName
+		for _, t := range s.tables {
+			t.apply()
+		}
+
+	default:
+		// Physical attributes and other modifications without an effect on the columns
+		p.skipStatement()
+		p.pos--
+	}
+
+	return nil
+}
+
+// column returns the column with the name or nil if it does not exist
+func (t *oracleScriptTable) column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// dropConstraints removes all constraints for which the function returns true
+func (t *oracleScriptTable) dropConstraints(drop func(c *oracleScriptConstraint) bool) {
+	for i := len(t.constraints) - 1; i >= 0; i-- {
+		if drop(t.constraints[i]) {
+			t.constraints = append(t.constraints[:i], t.constraints[i+1:]...)
+		}
+	}
+}
+
+// dropColumn removes the column and all constraints that reference it
+func (t *oracleScriptTable) dropColumn(name string) bool {
+	for i, c := range t.Columns {
+		if c.Name == name {
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+			for j := len(t.triggers) - 1; j >= 0; j-- {
+				if t.triggers[j].column == name {
+					t.triggers = append(t.triggers[:j], t.triggers[j+1:]...)
+				}
+			}
+			for j := len(t.indexes) - 1; j >= 0; j-- {
+				if containsString(t.indexes[j].Columns, name) {
+					t.indexes = append(t.indexes[:j], t.indexes[j+1:]...)
+				}
+			}
+			t.dropConstraints(func(c *oracleScriptConstraint) bool {
+				for _, col := range c.columns {
+					if col == name {
+						return true
+					}
+				}
+				return false
+			})
+			return true
+		}
+	}
+
+	return false
+}
+
+// renameColumnReferences renames the column within all constraints
+func (t *oracleScriptTable) renameColumnReferences(oldName, newName string) {
+	for _, trg := range t.triggers {
+		if trg.column == oldName {
+			trg.column = newName
+		}
+	}
+	for _, c := range t.constraints {
+		for i, col := range c.columns {
+			if col == oldName {
+				c.columns[i] = newName
+			}
+		}
+	}
+	for _, idx := range t.indexes {
+		for i, col := range idx.Columns {
+			if col == oldName {
+				idx.Columns[i] = newName
+			}
+		}
+	}
+}
+
+// apply sets the key information of the table and all columns based on
+// the constraints of the table. For constraints without a name, the generated
+// name is not known and an empty name is used
+func (t *oracleScriptTable) apply() {
+	t.PrimaryKey = nil
+	t.ForeignKeys = nil
+	t.Checks = nil
+	for _, con := range t.constraints {
+		switch con.typ {
+		case "C":
+			t.Checks = append(t.Checks, &CheckConstraint{
+				Name:       con.name,
+				Expression: con.condition,
+				Columns:    t.expressionColumns(con.condition, oracleDialect),
+			})
+		case "P":
+			t.PrimaryKey = &PrimaryKey{
+				Name:    con.name,
+				Columns: append([]string{}, con.columns...),
+			}
+		case "R":
+			t.ForeignKeys = append(t.ForeignKeys, &ForeignKey{
+				Name:              con.name,
+				Columns:           append([]string{}, con.columns...),
+				ReferencedSchema:  con.refSchema,
+				ReferencedTable:   con.refTable,
+				ReferencedColumns: append([]string{}, con.refColumns...),
+				OnDelete:          con.onDelete,
+			})
+		}
+	}
+	sort.SliceStable(t.ForeignKeys, func(i, j int) bool { return t.ForeignKeys[i].Name < t.ForeignKeys[j].Name })
+	sort.SliceStable(t.Checks, func(i, j int) bool { return t.Checks[i].Name < t.Checks[j].Name })
+	t.applyForeignKeys()
+
+	for _, c := range t.Columns {
+		c.PrimaryKey = false
+		if t.PrimaryKey != nil && containsString(t.PrimaryKey.Columns, c.Name) {
+			c.PrimaryKey = true
+			c.CanBeNull = false
+		}
+		col := c.Extras.(*OracleColumn)
+
+		// Columns filled by an identity or a sequence
+		col.AutoIncrement = col.IdentityGeneration != ""
+		if !col.AutoIncrement {
+			col.Sequence = nil
+			for _, trg := range t.triggers {
+				if trg.column == c.Name {
+					col.AutoIncrement = true
+					col.Sequence = trg.sequence
+				}
+			}
+		}
+		if seq, ok := parseOracleSequenceDefault(c.DefaultValue.String); ok && c.DefaultValue.Valid && !col.AutoIncrement {
+			if seq.Schema == "" {
+				seq.Schema = t.Schema
+			}
+			col.AutoIncrement = true
+			col.Sequence = seq
+		}
+
+		// Enum like check constraints
+		col.EnumValues = nil
+		for _, con := range t.constraints {
+			if con.typ == "C" && con.enumValues != nil && c.Type == StringType && con.columns[0] == c.Name {
+				col.EnumValues = append([]string{}, con.enumValues...)
+			}
+		}
+	}
+
+	t.applyIndexes()
+}
+
+// applyIndexes sets the indexes of the table like they are returned by the
+// database sorted by their name. Primary keys and unique constraints create
+// an index named after the constraint if the columns are not already indexed.
+// For constraints without a name, the generated name is not known and
+// an empty name is used
+func (t *oracleScriptTable) applyIndexes() {
+	indexes := []*Index{}
+	for _, idx := range t.indexes {
+		indexes = append(indexes, &Index{
+			Name:    idx.Name,
+			Columns: append([]string{}, idx.Columns...),
+			Unique:  idx.Unique,
+			Type:    idx.Type,
+		})
+	}
+
+	for _, con := range t.constraints {
+		if con.typ != "P" && con.typ != "U" {
+			continue
+		}
+
+		indexed := false
+		for _, idx := range indexes {
+			if strings.Join(idx.Columns, ",") == strings.Join(con.columns, ",") {
+				indexed = true
+			}
+		}
+		if !indexed {
+			indexes = append(indexes, &Index{
+				Name:    con.name,
+				Columns: append([]string{}, con.columns...),
+				Unique:  true,
+				Type:    "NORMAL",
+			})
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	t.Indexes = nil
+	if len(indexes) != 0 {
+		t.Indexes = indexes
+	}
+}
+
+// resolveReferences sets the referenced column of foreign keys that reference
+// the primary key of a table without specifying the columns
+func (s *OracleScript) resolveReferences() {
+	for _, t := range s.tables {
+		for _, con := range t.constraints {
+			if con.typ != "R" || len(con.refColumns) != 0 {
+				continue
+			}
+
+			if ref := s.findTable(con.refSchema, con.refTable); ref != nil {
+				for _, refCon := range ref.constraints {
+					if refCon.typ == "P" {
+						con.refColumns = append([]string{}, refCon.columns...)
+					}
+				}
+			}
+		}
+		t.apply()
+
+		// Use the created sequences of auto increment columns
+		for _, c := range t.Columns {
+			col := c.Extras.(*OracleColumn)
+			if col.Sequence == nil || col.Sequence.Name == "" {
+				continue
+			}
+			if seq := s.findSequence(col.Sequence.Schema, col.Sequence.Name); seq != nil {
+				col.Sequence = seq
+			}
+		}
+	}
+}
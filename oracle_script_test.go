@@ -0,0 +1,704 @@
+package ddl
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestParseOracleScriptSimple tests the construction of a Table struct
+// from a create statement with the same columns used for the database tests
+func TestParseOracleScriptSimple(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ddl_test (
+			id 		NUMERIC(10,0) PRIMARY KEY NOT NULL,
+			txt 	VARCHAR2(100) DEFAULT 'Ich bins, der Tim!',
+			dte		DATE NOT NULL
+		);
+		COMMENT ON COLUMN ddl_test.dte IS 'Hallo ihr da!\nZeilenumbrüche';
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "ddl_test")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   "DDL_TEST",
+		Schema: "DDL",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Columns: []string{"ID"}, Unique: true, Type: "NORMAL"},
+		},
+		PrimaryKey: &PrimaryKey{Columns: []string{"ID"}},
+	}
+	columns := []*OracleColumn{
+		{
+			Column: &Column{
+				Name:         "ID",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 10,
+			Scale:          0,
+		},
+		{
+			Column: &Column{
+				Name:         "TXT",
+				PrimaryKey:   false,
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "VARCHAR2",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "Ich bins, der Tim!",
+				},
+			},
+			DataTypeLenght: 100,
+		},
+		{
+			Column: &Column{
+				Name:         "DTE",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         DateType,
+				InternalType: "DATE",
+				Comment:      "Hallo ihr da!\nZeilenumbrüche",
+			},
+			DataTypeLenght: 7,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptFK tests the construction of a Table struct
+// that references another table with a constraint added afterwards
+func TestParseOracleScriptFK(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ref (
+			id_to_ref   NUMBER(10,0) PRIMARY KEY NOT NULL,
+			rand        VARCHAR2(10) NOT NULL
+		);
+		CREATE TABLE tbl (
+			id 		 NUMBER(10,0) NOT NULL,
+			other_id NUMBER(10,0) NOT NULL
+		) TABLESPACE users;
+		ALTER TABLE tbl ADD CONSTRAINT pk_tbl PRIMARY KEY (id) USING INDEX ENABLE;
+		ALTER TABLE tbl ADD CONSTRAINT fk_test_constraint_for_you FOREIGN KEY(other_id) REFERENCES ref ON DELETE CASCADE;
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   "TBL",
+		Schema: "DDL",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PK_TBL", Columns: []string{"ID"}, Unique: true, Type: "NORMAL"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PK_TBL", Columns: []string{"ID"}},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "FK_TEST_CONSTRAINT_FOR_YOU",
+				Columns:           []string{"OTHER_ID"},
+				ReferencedSchema:  "DDL",
+				ReferencedTable:   "REF",
+				ReferencedColumns: []string{"ID_TO_REF"},
+				OnDelete:          "CASCADE",
+			},
+		},
+	}
+	columns := []*OracleColumn{
+		{
+			Column: &Column{
+				Name:         "ID",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 10,
+		},
+		{
+			Column: &Column{
+				Name:         "OTHER_ID",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "NUMBER",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   "REF",
+					Schema: "DDL",
+					Column: "ID_TO_REF",
+				},
+			},
+			DataTypeLenght: 10,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptCompositeFK tests keys over multiple columns
+func TestParseOracleScriptCompositeFK(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE ref (
+			tenant  NUMBER(10,0) NOT NULL,
+			id      NUMBER(10,0) NOT NULL,
+			CONSTRAINT pk_ref PRIMARY KEY (tenant, id)
+		);
+		CREATE TABLE tbl (
+			other_id NUMBER(10,0),
+			tenant   NUMBER(10,0),
+			PRIMARY KEY (tenant, other_id),
+			CONSTRAINT fk_ref FOREIGN KEY (tenant, other_id) REFERENCES ref ON DELETE SET NULL
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	// The generated name of the primary key is not known
+	expectedPk := &PrimaryKey{Columns: []string{"TENANT", "OTHER_ID"}}
+	if diff := cmp.Diff(table.PrimaryKey, expectedPk); diff != "" {
+		t.Errorf("Mismatch of primary key (-want +got):\n%s", diff)
+	}
+
+	// The referenced columns are taken from the primary key
+	expectedFks := []*ForeignKey{
+		{
+			Name:              "FK_REF",
+			Columns:           []string{"TENANT", "OTHER_ID"},
+			ReferencedSchema:  "DDL",
+			ReferencedTable:   "REF",
+			ReferencedColumns: []string{"TENANT", "ID"},
+			OnDelete:          "SET NULL",
+		},
+	}
+	if diff := cmp.Diff(table.ForeignKeys, expectedFks); diff != "" {
+		t.Errorf("Mismatch of foreign keys (-want +got):\n%s", diff)
+	}
+
+	expectedRefs := map[string]string{"OTHER_ID": "ID", "TENANT": "TENANT"}
+	for _, c := range table.Columns {
+		if !c.PrimaryKey || c.CanBeNull || !c.ForeignKey || c.ForeignKeyColumn.Column != expectedRefs[c.Name] {
+			t.Errorf("Wrong key information of column %q: %+v", c.Name, c)
+		}
+	}
+}
+
+// TestParseOracleScriptEnum tests the detection of the allowed values of a column
+// with a check constraint
+func TestParseOracleScriptEnum(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE tbl (
+			state   VARCHAR2(10) CHECK (state IN ('ACTIVE', 'CLOSED')),
+			kind    VARCHAR2(10) NOT NULL,
+			amount  NUMBER(10,0) CHECK (amount IN ('1', '2')),
+			txt     VARCHAR2(10) CHECK (txt IS NOT NULL),
+			CONSTRAINT chk_kind CHECK ("KIND" IN ('a', 'b'))
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := map[string][]string{
+		"STATE":  {"ACTIVE", "CLOSED"},
+		"KIND":   {"a", "b"},
+		"AMOUNT": nil,
+		"TXT":    nil,
+	}
+	for _, c := range table.Columns {
+		if diff := cmp.Diff(c.Extras.(*OracleColumn).EnumValues, expected[c.Name]); diff != "" {
+			t.Errorf("Mismatch of values for column %q (-want +got):\n%s", c.Name, diff)
+		}
+	}
+
+	// Check constraints without a name are sorted first
+	expectedChecks := []*CheckConstraint{
+		{Expression: "state IN ('ACTIVE', 'CLOSED')", Columns: []string{"STATE"}},
+		{Expression: "amount IN ('1', '2')", Columns: []string{"AMOUNT"}},
+		{Expression: "txt IS NOT NULL", Columns: []string{"TXT"}},
+		{Name: "CHK_KIND", Expression: `"KIND" IN ('a', 'b')`, Columns: []string{"KIND"}},
+	}
+	if diff := cmp.Diff(table.Checks, expectedChecks); diff != "" {
+		t.Errorf("Mismatch of checks (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptTypes tests the length and scale of the different
+// data types and identity columns
+// TestParseOracleScriptVirtual tests the parsing of virtual and invisible columns
+func TestParseOracleScriptVirtual(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE tbl (
+			price   NUMBER(10,2),
+			amount  NUMBER(10,0),
+			total   NUMBER(20,2) GENERATED ALWAYS AS (price * amount) VIRTUAL,
+			netto   AS (price / 1.19),
+			created DATE INVISIBLE
+		);
+		ALTER TABLE tbl MODIFY (amount INVISIBLE, created VISIBLE);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	type generated struct {
+		Expression string
+		Invisible  bool
+	}
+	expected := []generated{
+		{},
+		{Invisible: true},
+		{Expression: "price * amount"},
+		{Expression: "price / 1.19"},
+		{},
+	}
+	got := []generated{}
+	for _, c := range table.Columns {
+		got = append(got, generated{c.GenerationExpression, c.Invisible})
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Mismatch of virtual columns (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptSequences tests the detection of auto increment columns
+// by identities, triggers and default values
+func TestParseOracleScriptSequences(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		CREATE SEQUENCE seq_tbl START WITH 100 INCREMENT BY 5 CYCLE;
+		CREATE SEQUENCE seq_old;
+		CREATE SEQUENCE seq_nr;
+		CREATE TABLE tbl (
+			id    NUMBER(10) PRIMARY KEY,
+			ident NUMBER(10) GENERATED ALWAYS AS IDENTITY (START WITH 10 INCREMENT BY 2),
+			nr    NUMBER(10) DEFAULT seq_nr.NEXTVAL,
+			txt   VARCHAR2(10)
+		);
+
+		CREATE OR REPLACE TRIGGER trg_tbl
+			BEFORE INSERT ON tbl
+			FOR EACH ROW
+		BEGIN
+			IF :NEW.id IS NULL THEN
+				SELECT seq_old.NEXTVAL INTO :NEW.id FROM dual;
+			END IF;
+		END;
+		/
+
+		CREATE OR REPLACE TRIGGER trg_tbl
+			BEFORE INSERT ON tbl
+			FOR EACH ROW
+		BEGIN
+			:NEW.id := "DDL".seq_tbl.nextval;
+		END;
+		/
+		DROP SEQUENCE seq_old;
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	table, err := s.GetTable("ddl", "tbl")
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	seqTbl := &Sequence{Schema: "DDL", Name: "SEQ_TBL", Start: 100, Increment: 5, Cycle: true}
+	seqNr := &Sequence{Schema: "DDL", Name: "SEQ_NR", Start: 1, Increment: 1}
+	expected := map[string]*OracleColumn{
+		"ID":    {AutoIncrement: true, Sequence: seqTbl},
+		"IDENT": {AutoIncrement: true, IdentityGeneration: "ALWAYS", Sequence: &Sequence{Schema: "DDL", Start: 10, Increment: 2}},
+		"NR":    {AutoIncrement: true, Sequence: seqNr},
+		"TXT":   {},
+	}
+	for _, c := range table.Columns {
+		col := c.Extras.(*OracleColumn)
+		got := &OracleColumn{AutoIncrement: col.AutoIncrement, IdentityGeneration: col.IdentityGeneration, Sequence: col.Sequence}
+		if diff := cmp.Diff(got, expected[c.Name]); diff != "" {
+			t.Errorf("Mismatch of column %q (-want +got):\n%s", c.Name, diff)
+		}
+	}
+
+	// Only the primary key is marked
+	for _, c := range table.Columns {
+		if c.PrimaryKey != (c.Name == "ID") {
+			t.Errorf("Expected column %q to be a primary key: %t", c.Name, c.Name == "ID")
+		}
+	}
+
+	sequences, err := s.GetSequences("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get sequences: %s", err)
+	}
+	if diff := cmp.Diff(sequences, []*Sequence{seqNr, seqTbl}); diff != "" {
+		t.Errorf("Mismatch of sequences (-want +got):\n%s", diff)
+	}
+
+	// Dropping the trigger removes the auto increment
+	if err := s.Parse("DROP TRIGGER trg_tbl;"); err != nil {
+		t.Fatalf("Failed to drop trigger: %s", err)
+	}
+	if col := table.Columns[0].Extras.(*OracleColumn); col.AutoIncrement || col.Sequence != nil {
+		t.Errorf("Expected no auto increment after dropping the trigger. Got %s", DumpStruct(col.Sequence))
+	}
+}
+
+func TestParseOracleScriptTypes(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`
+		ALTER SESSION SET CURRENT_SCHEMA = other;
+		CREATE TABLE "Types" (
+			id    NUMBER(10) GENERATED BY DEFAULT ON NULL AS IDENTITY (START WITH 1 INCREMENT BY 1),
+			price NUMBER(12, 4),
+			flt   NUMBER,
+			cnt   INTEGER,
+			"txt" VARCHAR2(10 CHAR) DEFAULT NULL,
+			ts    TIMESTAMP(3) WITH TIME ZONE DEFAULT SYSTIMESTAMP NOT NULL
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	// Like for the database, quoted names can only be retrieved by GetTables
+	tables, err := s.GetTables("other")
+	if err != nil || len(tables) != 1 {
+		t.Fatalf("Failed to get tables: %s (len = %d)", err, len(tables))
+	}
+	table := tables[0]
+
+	expected := &Table{
+		Name:   "Types",
+		Schema: "OTHER",
+		Kind:   TableKindTable,
+	}
+	columns := []*OracleColumn{
+		{
+			// Identity columns are no primary keys
+			Column: &Column{
+				Name:         "ID",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			AutoIncrement:      true,
+			IdentityGeneration: "BY DEFAULT",
+			Sequence:           &Sequence{Schema: "OTHER", Start: 1, Increment: 1},
+			DataTypeLenght:     10,
+		},
+		{
+			Column: &Column{
+				Name:             "PRICE",
+				CanBeNull:        true,
+				Type:             DecimalType,
+				InternalType:     "NUMBER",
+				NumericPrecision: 12,
+				NumericScale:     4,
+			},
+			DataTypeLenght: 12,
+			Scale:          4,
+		},
+		{
+			Column: &Column{
+				Name:         "FLT",
+				CanBeNull:    true,
+				Type:         DoubleType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 22,
+			Scale:          64,
+		},
+		{
+			Column: &Column{
+				Name:         "CNT",
+				CanBeNull:    true,
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 22,
+		},
+		{
+			Column: &Column{
+				Name:         "txt",
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "VARCHAR2",
+				DefaultValue: sql.NullString{Valid: true, String: "NULL"},
+			},
+			DataTypeLenght: 40,
+			CharLenght:     10,
+		},
+		{
+			Column: &Column{
+				Name:         "TS",
+				CanBeNull:    false,
+				Type:         TimestampTzType,
+				InternalType: "TIMESTAMP(3) WITH TIME ZONE",
+				DefaultValue: sql.NullString{Valid: true, String: "SYSTIMESTAMP"},
+			},
+			DataTypeLenght: 13,
+			Scale:          3,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptAlter tests the modification of tables through
+// multiple migration scripts containing PL/SQL blocks
+func TestParseOracleScriptAlter(t *testing.T) {
+	s := NewOracleScript("ddl")
+	migrations := []string{
+		`CREATE TABLE workout (id NUMBER(10) NOT NULL, name VARCHAR2(10));
+		 CREATE TABLE tmp (id NUMBER(10));
+		 CREATE OR REPLACE TRIGGER trg_workout BEFORE INSERT ON workout FOR EACH ROW
+		 BEGIN
+			:new.id := :new.id / 2;
+			DROP TABLE workout;
+		 END;
+		 /
+		`,
+		`ALTER TABLE workout ADD (
+			created DATE NOT NULL,
+			CONSTRAINT pk_workout PRIMARY KEY (id)
+		 );
+		 ALTER TABLE workout MODIFY (name VARCHAR2(100) DEFAULT 'none' NOT NULL);
+		 ALTER TABLE workout RENAME COLUMN name TO title;
+		 CREATE UNIQUE INDEX uq_title ON workout (UPPER(title));
+		 BEGIN
+			EXECUTE IMMEDIATE 'DROP TABLE not_existing';
+		 EXCEPTION
+			WHEN OTHERS THEN NULL;
+		 END;
+		 /
+		 DROP TABLE tmp CASCADE CONSTRAINTS PURGE;`,
+		`CREATE TABLE detail (
+			id NUMBER(10) NOT NULL,
+			workout_id NUMBER(10) NOT NULL,
+			old NUMBER(10)
+		 );
+		 ALTER TABLE detail ADD CONSTRAINT fk_workout FOREIGN KEY (workout_id) REFERENCES workout (id);
+		 CREATE INDEX idx_workout ON detail (workout_id DESC, "ID") TABLESPACE users;
+		 CREATE BITMAP INDEX idx_old ON detail (old);
+		 CREATE INDEX idx_tmp ON detail (id);
+		 DROP INDEX idx_tmp;
+		 ALTER TABLE detail DROP COLUMN old;
+		 ALTER TABLE workout RENAME TO training;
+		 COMMENT ON COLUMN ddl.training.title IS 'Title';`,
+	}
+	for _, m := range migrations {
+		if err := s.Parse(m); err != nil {
+			t.Fatalf("Failed to parse script: %s", err)
+		}
+	}
+
+	tables, err := s.GetTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	if len(tables) != 2 || tables[0].Name != "DETAIL" || tables[1].Name != "TRAINING" {
+		t.Fatalf("Expected the tables DETAIL and TRAINING. Got %s", DumpStruct(tables))
+	}
+
+	// Training table
+	expectedTraining := []*OracleColumn{
+		{
+			Column: &Column{
+				Name:         "ID",
+				PrimaryKey:   true,
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 10,
+		},
+		{
+			Column: &Column{
+				Name:         "TITLE",
+				Type:         StringType,
+				InternalType: "VARCHAR2",
+				DefaultValue: sql.NullString{Valid: true, String: "none"},
+				Comment:      "Title",
+			},
+			DataTypeLenght: 100,
+		},
+		{
+			Column: &Column{
+				Name:         "CREATED",
+				Type:         DateType,
+				InternalType: "DATE",
+			},
+			DataTypeLenght: 7,
+		},
+	}
+	expected := &Table{
+		Name:   "TRAINING",
+		Schema: "DDL",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "PK_WORKOUT", Columns: []string{"ID"}, Unique: true, Type: "NORMAL"},
+			{Name: "UQ_TITLE", Columns: []string{"UPPER(title)"}, Unique: true, Type: "FUNCTION-BASED NORMAL"},
+		},
+		PrimaryKey: &PrimaryKey{Name: "PK_WORKOUT", Columns: []string{"ID"}},
+	}
+	for _, c := range expectedTraining {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+	if diff := cmp.Diff(tables[1], expected); diff != "" {
+		t.Errorf("Mismatch of training (-want +got):\n%s", diff)
+	}
+
+	// Detail table
+	expectedDetail := []*OracleColumn{
+		{
+			Column: &Column{
+				Name:         "ID",
+				Type:         IntType,
+				InternalType: "NUMBER",
+			},
+			DataTypeLenght: 10,
+		},
+		{
+			Column: &Column{
+				Name:         "WORKOUT_ID",
+				Type:         IntType,
+				InternalType: "NUMBER",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   "TRAINING",
+					Schema: "DDL",
+					Column: "ID",
+				},
+			},
+			DataTypeLenght: 10,
+		},
+	}
+	expected = &Table{
+		Name:   "DETAIL",
+		Schema: "DDL",
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Name: "IDX_WORKOUT", Columns: []string{"WORKOUT_ID", "ID"}, Type: "FUNCTION-BASED NORMAL"},
+		},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "FK_WORKOUT",
+				Columns:           []string{"WORKOUT_ID"},
+				ReferencedSchema:  "DDL",
+				ReferencedTable:   "TRAINING",
+				ReferencedColumns: []string{"ID"},
+				OnDelete:          "NO ACTION",
+			},
+		},
+	}
+	for _, c := range expectedDetail {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+	if diff := cmp.Diff(tables[0], expected); diff != "" {
+		t.Errorf("Mismatch of detail (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptSqlplus tests that SQL*Plus commands without a semicolon are ignored
+func TestParseOracleScriptSqlplus(t *testing.T) {
+	s := NewOracleScript("ddl")
+	if err := s.Parse(`SET DEFINE OFF
+PROMPT Creating table t, don't stop
+REM Exported by SQL Developer
+CREATE TABLE t (id NUMBER(10));
+spool export.log
+@@other.sql
+CREATE TABLE u (
+	id NUMBER(10)
+);
+WHENEVER SQLERROR EXIT SQL.SQLCODE
+CREATE OR REPLACE TRIGGER trg BEFORE INSERT ON u FOR EACH ROW
+BEGIN
+	UPDATE t
+	SET id = 1;
+END;
+/
+CREATE TABLE v (id NUMBER(10));
+`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+
+	tables, _ := s.GetTables("ddl")
+	names := []string{}
+	for _, t := range tables {
+		names = append(names, t.Name)
+	}
+	if diff := cmp.Diff([]string{"T", "U", "V"}, names); diff != "" {
+		t.Errorf("Mismatch of tables (-want +got):\n%s", diff)
+	}
+}
+
+// TestParseOracleScriptErrors tests that invalid statements are reported
+func TestParseOracleScriptErrors(t *testing.T) {
+	for _, script := range []string{
+		"CREATE TABLE tbl (id NUMBER NOT NULL",
+		"CREATE TABLE tbl (id NUMBER NOT NULL BLUB)",
+		"ALTER TABLE not_existing ADD id NUMBER",
+		"CREATE TABLE tbl (id NUMBER); COMMENT ON COLUMN tbl.other IS 'x';",
+		"CREATE TABLE tbl (txt VARCHAR2(10) DEFAULT 'not closed)",
+	} {
+		if err := NewOracleScript("ddl").Parse(script); err == nil {
+			t.Errorf("Expected an error for script %q", script)
+		}
+	}
+}
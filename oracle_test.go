@@ -1,6 +1,7 @@
 package ddl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/RPJoshL/go-logger"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	goOra "github.com/sijms/go-ora/v2"
 )
 
@@ -45,6 +47,11 @@ func TestGetTableSimpleOracle(t *testing.T) {
 	expected := &Table{
 		Name:   strings.ToUpper(tableName),
 		Schema: RequireEnvString("ORACLE_USER", t),
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Columns: []string{"ID"}, Unique: true, Type: "NORMAL"},
+		},
+		PrimaryKey: &PrimaryKey{Columns: []string{"ID"}},
 	}
 	columns := []*OracleColumn{
 		{
@@ -91,8 +98,8 @@ func TestGetTableSimpleOracle(t *testing.T) {
 		expected.Columns = append(expected.Columns, c.Column)
 	}
 
-	// Compare struct
-	if diff := cmp.Diff(table, expected); diff != "" {
+	// Compare struct. The name of the primary key index is generated
+	if diff := cmp.Diff(table, expected, ignoreServerDefaults, cmpopts.IgnoreFields(Index{}, "Name"), cmpopts.IgnoreFields(PrimaryKey{}, "Name")); diff != "" {
 		t.Errorf("Mismatch of columns (-want +got):\n%s", diff)
 	}
 }
@@ -132,6 +139,21 @@ func TestGetTableOracleFK(t *testing.T) {
 	expected := &Table{
 		Name:   strings.ToUpper(tableName),
 		Schema: RequireEnvString("ORACLE_USER", t),
+		Kind:   TableKindTable,
+		Indexes: []*Index{
+			{Columns: []string{"ID"}, Unique: true, Type: "NORMAL"},
+		},
+		PrimaryKey: &PrimaryKey{Columns: []string{"ID"}},
+		ForeignKeys: []*ForeignKey{
+			{
+				Name:              "FK_TEST_CONSTRAINT_FOR_YOU",
+				Columns:           []string{"OTHER_ID"},
+				ReferencedSchema:  RequireEnvString("ORACLE_USER", t),
+				ReferencedTable:   strings.ToUpper(referenceTableName),
+				ReferencedColumns: []string{"ID_TO_REF"},
+				OnDelete:          "NO ACTION",
+			},
+		},
 	}
 	columns := []*OracleColumn{
 		{
@@ -166,13 +188,77 @@ func TestGetTableOracleFK(t *testing.T) {
 		expected.Columns = append(expected.Columns, c.Column)
 	}
 
-	// Compare struct
-	if diff := cmp.Diff(table, expected); diff != "" {
+	// Compare struct. The name of the primary key index is generated
+	if diff := cmp.Diff(table, expected, ignoreServerDefaults, cmpopts.IgnoreFields(Index{}, "Name"), cmpopts.IgnoreFields(PrimaryKey{}, "Name")); diff != "" {
 		t.Errorf("Mismatch (-want +got):\n%s", diff)
 	}
 
 }
 
+// TestGetTableOracleCompositeFK tests a table with a primary and foreign key
+// over multiple columns
+func TestGetTableOracleCompositeFK(t *testing.T) {
+	db := ConnectToOracle(t)
+	oDb := NewOracleDb(db)
+
+	// Create table we reference to
+	referenceTableName, err := createTable(db, `
+		tenant  NUMBER(10,0) NOT NULL,
+		id      NUMBER(10,0) NOT NULL,
+		PRIMARY KEY (tenant, id)`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, referenceTableName)
+
+	// Create table with reference. The column order differs from the key order
+	tableName, err := createTable(db, `
+		other_id  NUMBER(10,0) NOT NULL,
+		tenant    NUMBER(10,0) NOT NULL,
+		PRIMARY KEY (tenant, other_id),
+		CONSTRAINT fk_test_composite FOREIGN KEY(tenant, other_id) REFERENCES `+referenceTableName+`(tenant, id)
+			ON DELETE CASCADE
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := oDb.GetTable(RequireEnvString("ORACLE_USER", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	// The name of the primary key is generated
+	expectedPk := &PrimaryKey{Columns: []string{"TENANT", "OTHER_ID"}}
+	if diff := cmp.Diff(table.PrimaryKey, expectedPk, cmpopts.IgnoreFields(PrimaryKey{}, "Name")); diff != "" {
+		t.Errorf("Mismatch of primary key (-want +got):\n%s", diff)
+	}
+
+	expectedFks := []*ForeignKey{
+		{
+			Name:              "FK_TEST_COMPOSITE",
+			Columns:           []string{"TENANT", "OTHER_ID"},
+			ReferencedSchema:  RequireEnvString("ORACLE_USER", t),
+			ReferencedTable:   strings.ToUpper(referenceTableName),
+			ReferencedColumns: []string{"TENANT", "ID"},
+			OnDelete:          "CASCADE",
+		},
+	}
+	if diff := cmp.Diff(table.ForeignKeys, expectedFks); diff != "" {
+		t.Errorf("Mismatch of foreign keys (-want +got):\n%s", diff)
+	}
+
+	// Every column is returned only once
+	if len(table.Columns) != 2 {
+		t.Fatalf("Expected 2 columns. Got %d", len(table.Columns))
+	}
+	if col := table.Columns[0].ForeignKeyColumn.Column; col != "ID" {
+		t.Errorf("Expected 'OTHER_ID' to reference 'ID'. Got %q", col)
+	}
+}
+
 // TestGetTableSimple tests the selecting of multiple tables to a []Table array
 func TestGetTablesOracle(t *testing.T) {
 	db := ConnectToOracle(t)
@@ -208,6 +294,7 @@ func TestGetTablesOracle(t *testing.T) {
 			expected := &Table{
 				Name:   tt.Name,
 				Schema: RequireEnvString("ORACLE_USER", t),
+				Kind:   TableKindTable,
 			}
 			columns := []*OracleColumn{
 				{
@@ -226,7 +313,7 @@ func TestGetTablesOracle(t *testing.T) {
 			}
 
 			// Compare struct
-			if diff := cmp.Diff(tt, expected); diff != "" {
+			if diff := cmp.Diff(tt, expected, ignoreServerDefaults); diff != "" {
 				t.Errorf("TestGetTables() mismatch of tab1: (-want +got):\n%s", diff)
 			}
 		}
@@ -238,6 +325,7 @@ func TestGetTablesOracle(t *testing.T) {
 			expected := &Table{
 				Name:   tt.Name,
 				Schema: RequireEnvString("ORACLE_USER", t),
+				Kind:   TableKindTable,
 			}
 			columns := []*OracleColumn{
 				{
@@ -256,7 +344,7 @@ func TestGetTablesOracle(t *testing.T) {
 			}
 
 			// Compare struct
-			if diff := cmp.Diff(tt, expected); diff != "" {
+			if diff := cmp.Diff(tt, expected, ignoreServerDefaults); diff != "" {
 				t.Errorf("Mismatch of tab2: (-want +got):\n%s", diff)
 			}
 		}
@@ -271,6 +359,340 @@ func TestGetTablesOracle(t *testing.T) {
 	}
 }
 
+// TestGetTablesOracleContext tests that no tables are returned for a canceled context
+func TestGetTablesOracleContext(t *testing.T) {
+	db := ConnectToOracle(t)
+	oDb := NewOracleDb(db)
+
+	tableName, err := createTable(db, `id NUMBER(10,0) NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName = strings.ToUpper(tableName)
+	defer dropTable(db, tableName)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := oDb.GetTableContext(ctx, RequireEnvString("ORACLE_USER", t), tableName); err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	cancel()
+	if tables, err := oDb.GetTablesContext(ctx, RequireEnvString("ORACLE_USER", t)); err == nil {
+		t.Errorf("Expected an error for a canceled context. Got %d tables", len(tables))
+	}
+}
+
+// BenchmarkGetTablesOracle compares the loading of all tables within a single query
+// against fetching every table on its own
+// TestGetDataTypeOracle tests the mapping of the column types to the generic data types
+func TestGetDataTypeOracle(t *testing.T) {
+	s := &OracleDb{}
+	for _, tt := range []struct {
+		typ      string
+		scale    int
+		expected DataType
+	}{
+		{"NUMBER", 0, IntType},
+		{"NUMBER", 2, DecimalType},
+		{"NUMBER", 64, DoubleType},
+		{"FLOAT", 0, DoubleType},
+		{"BINARY_DOUBLE", 0, DoubleType},
+		{"VARCHAR2", 0, StringType},
+		{"NCHAR", 0, StringType},
+		{"CLOB", 0, TextType},
+		{"BLOB", 0, BinaryType},
+		{"RAW", 0, BinaryType},
+		{"DATE", 0, DateType},
+		{"TIMESTAMP(6)", 6, DateType},
+		{"TIMESTAMP(6) WITH TIME ZONE", 6, TimestampTzType},
+		{"TIMESTAMP(6) WITH LOCAL TIME ZONE", 6, TimestampTzType},
+		{"INTERVAL DAY(2) TO SECOND(6)", 6, IntervalType},
+		{"INTERVAL YEAR(2) TO MONTH", 0, IntervalType},
+		{"JSON", 0, JsonType},
+		{"BOOLEAN", 0, BoolType},
+	} {
+		col := s.newColumn()
+		col.Scale = tt.scale
+		if got := s.GetDataType(tt.typ, col); got != tt.expected {
+			t.Errorf("Expected data type %q for %q. Got %q", tt.expected, tt.typ, got)
+		}
+	}
+}
+
+// TestGetTablesOracleView tests the kind and definition of views
+func TestGetTablesOracleView(t *testing.T) {
+	db := ConnectToOracle(t)
+	oDb := NewOracleDb(db)
+
+	tableName, err := createTable(db, `id NUMBER(10,0) PRIMARY KEY NOT NULL, txt VARCHAR2(100)`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+	if _, err := db.Exec("CREATE VIEW " + tableName + "_v AS SELECT id, txt FROM " + tableName); err != nil {
+		t.Fatalf("Failed to create view: %s", err)
+	}
+	defer db.Exec("DROP VIEW " + tableName + "_v")
+
+	views, err := oDb.GetTablesByType(RequireEnvString("ORACLE_USER", t), OracleView)
+	if err != nil {
+		t.Fatalf("Failed to get views: %s", err)
+	}
+
+	for _, v := range views {
+		if v.Name == strings.ToUpper(tableName+"_v") {
+			if v.Kind != TableKindView || !strings.Contains(strings.ToUpper(v.ViewDefinition), strings.ToUpper(tableName)) {
+				t.Errorf("Expected a view selecting from %q. Got %q: %q", tableName, v.Kind, v.ViewDefinition)
+			}
+			return
+		}
+	}
+	t.Errorf("View %s_v was not found", tableName)
+}
+
+// TestParseOracleEnumCheck tests the detection of check constraints that restrict
+// a column to a list of values
+func TestParseOracleEnumCheck(t *testing.T) {
+	for _, tt := range []struct {
+		condition string
+		column    string
+		values    []string
+	}{
+		{"state IN ('A', 'B')", "STATE", []string{"A", "B"}},
+		{`"state" in ('it''s')`, "state", []string{"it's"}},
+		{"STATE IN ('A', 1)", "", nil},
+		{"STATE IN ('A') OR STATE IS NULL", "", nil},
+		{"AMOUNT > 0", "", nil},
+		{`"ID" IS NOT NULL`, "", nil},
+	} {
+		column, values, ok := parseOracleEnumCheck(tt.condition)
+		if ok != (tt.column != "") || column != tt.column || !cmp.Equal(values, tt.values) {
+			t.Errorf("Unexpected result for %q: %q %q %t", tt.condition, column, values, ok)
+		}
+	}
+}
+
+// TestParseOracleSequenceTrigger tests the detection of sequences within triggers
+func TestParseOracleSequenceTrigger(t *testing.T) {
+	for body, expected := range map[string]*Sequence{
+		`BEGIN :NEW.ID := seq_id.NEXTVAL; END;`:                                              {Name: "SEQ_ID"},
+		`BEGIN :new."Id" := "Other".seq_id.nextval; END;`:                                    {Schema: "Other", Name: "SEQ_ID"},
+		`BEGIN SELECT seq_id.NEXTVAL INTO :NEW.id FROM dual; END;`:                           {Name: "SEQ_ID"},
+		`BEGIN IF :NEW.id IS NULL THEN SELECT s.seq.NEXTVAL INTO :NEW.id FROM dual; END IF;`: {Schema: "S", Name: "SEQ"},
+		`BEGIN :NEW.created := SYSDATE; END;`:                                                nil,
+		`BEGIN SELECT seq_id.CURRVAL INTO :NEW.id FROM dual; END;`:                           nil,
+	} {
+		column, seq, ok := parseOracleSequenceTrigger(body)
+		if expected == nil {
+			if ok {
+				t.Errorf("Expected no sequence for %q. Got %q for %q", body, seq.Name, column)
+			}
+			continue
+		}
+
+		expected.Start, expected.Increment = 1, 1
+		if !ok || (column != "ID" && column != "Id") {
+			t.Errorf("Expected the column ID for %q. Got %q (%t)", body, column, ok)
+		} else if diff := cmp.Diff(seq, expected); diff != "" {
+			t.Errorf("Mismatch of sequence for %q (-want +got):\n%s", body, diff)
+		}
+	}
+}
+
+// TestParseOracleIdentityOptions tests the parsing of the options of identity columns
+func TestParseOracleIdentityOptions(t *testing.T) {
+	seq := parseOracleIdentityOptions("START WITH: 10, INCREMENT BY: -2, MAX_VALUE: 9999999999999999999999999999, MIN_VALUE: 1, CYCLE_FLAG: Y, CACHE_SIZE: 20")
+	if diff := cmp.Diff(seq, &Sequence{Start: 10, Increment: -2, Cycle: true}); diff != "" {
+		t.Errorf("Mismatch of sequence (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableOracleIdentity tests the selecting of identity columns and sequences
+func TestGetTableOracleIdentity(t *testing.T) {
+	db := ConnectToOracle(t)
+	oDb := NewOracleDb(db)
+
+	tableName, err := createTable(db, `
+		id    NUMBER(10) PRIMARY KEY,
+		ident NUMBER(10) GENERATED BY DEFAULT AS IDENTITY (START WITH 10 INCREMENT BY 2),
+		txt   VARCHAR2(10)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName = strings.ToUpper(tableName)
+	defer dropTable(db, tableName)
+
+	seqName := "SEQ_" + tableName
+	if _, err := db.Exec("CREATE SEQUENCE " + seqName + " START WITH 5 INCREMENT BY 3"); err != nil {
+		t.Fatalf("Failed to create sequence: %s", err)
+	}
+	defer db.Exec("DROP SEQUENCE " + seqName)
+	if _, err := db.Exec(fmt.Sprintf(`
+		CREATE TRIGGER TRG_%[1]s BEFORE INSERT ON %[1]s FOR EACH ROW
+		BEGIN
+			:NEW.id := %[2]s.NEXTVAL;
+		END;`, tableName, seqName,
+	)); err != nil {
+		t.Fatalf("Failed to create trigger: %s", err)
+	}
+
+	schema := RequireEnvString("ORACLE_USER", t)
+	table, err := oDb.GetTable(schema, tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	id := table.Columns[0].Extras.(*OracleColumn)
+	expectedSeq := &Sequence{Schema: strings.ToUpper(schema), Name: seqName, Start: 5, Increment: 3}
+	if !id.AutoIncrement || !id.PrimaryKey {
+		t.Errorf("Expected the column ID to be an auto increment primary key")
+	} else if diff := cmp.Diff(id.Sequence, expectedSeq); diff != "" {
+		t.Errorf("Mismatch of sequence (-want +got):\n%s", diff)
+	}
+
+	ident := table.Columns[1].Extras.(*OracleColumn)
+	if !ident.AutoIncrement || ident.PrimaryKey || ident.IdentityGeneration != "BY DEFAULT" {
+		t.Errorf("Expected the column IDENT to be an identity without a primary key. Got %s", DumpStruct(ident))
+	} else if ident.Sequence.Start != 10 || ident.Sequence.Increment != 2 || ident.Sequence.Name == "" {
+		t.Errorf("Mismatch of identity sequence: %s", DumpStruct(ident.Sequence))
+	}
+
+	if table.Columns[2].Extras.(*OracleColumn).AutoIncrement {
+		t.Errorf("Expected the column TXT to be no auto increment column")
+	}
+
+	sequences, err := oDb.GetSequences(schema)
+	if err != nil {
+		t.Fatalf("Failed to get sequences: %s", err)
+	}
+	found := false
+	for _, seq := range sequences {
+		found = found || seq.Name == seqName
+	}
+	if !found {
+		t.Errorf("Expected the sequence %q in %s", seqName, DumpStruct(sequences))
+	}
+}
+
+// TestParseOracleNotNullCheck tests the detection of implicit NOT NULL check constraints
+func TestParseOracleNotNullCheck(t *testing.T) {
+	for condition, expected := range map[string]string{
+		`"ID" IS NOT NULL`:         "ID",
+		`id IS NOT NULL`:           "ID",
+		`"id" is not null`:         "id",
+		`ID IS NULL`:               "",
+		`ID IS NOT NULL AND A > 0`: "",
+	} {
+		column, ok := parseOracleNotNullCheck(condition)
+		if ok != (expected != "") || column != expected {
+			t.Errorf("Expected %q for %q. Got %q (%t)", expected, condition, column, ok)
+		}
+	}
+}
+
+// TestGetRoutinesOracle tests the selecting of procedures and functions including
+// overloaded routines of packages
+func TestGetRoutinesOracle(t *testing.T) {
+	db := ConnectToOracle(t)
+	oDb := NewOracleDb(db)
+
+	suffix, _ := GenerateRandomString(8)
+	procName, pkgName := strings.ToUpper("ddl_test_proc_"+suffix), strings.ToUpper("ddl_test_pkg_"+suffix)
+	if _, err := db.Exec(fmt.Sprintf(`
+		CREATE PROCEDURE %s(id IN NUMBER, name IN OUT VARCHAR2, amount OUT NUMBER) AS
+		BEGIN
+			amount := id * 2;
+		END;`, procName,
+	)); err != nil {
+		t.Fatalf("Failed to create procedure: %s", err)
+	}
+	defer db.Exec("DROP PROCEDURE " + procName)
+	if _, err := db.Exec(fmt.Sprintf(`
+		CREATE PACKAGE %s AS
+			FUNCTION get(id IN NUMBER) RETURN VARCHAR2;
+			FUNCTION get(name IN VARCHAR2, flag IN BOOLEAN DEFAULT TRUE) RETURN DATE;
+		END;`, pkgName,
+	)); err != nil {
+		t.Fatalf("Failed to create package: %s", err)
+	}
+	defer db.Exec("DROP PACKAGE " + pkgName)
+
+	schema := strings.ToUpper(RequireEnvString("ORACLE_USER", t))
+	routines, err := oDb.GetRoutines(schema)
+	if err != nil {
+		t.Fatalf("Failed to get routines: %s", err)
+	}
+
+	expected := []*Routine{
+		{Schema: schema, Name: procName, Kind: RoutineProcedure, Parameters: []*Parameter{
+			{Name: "ID", Direction: ParameterIn, Type: DoubleType, InternalType: "NUMBER"},
+			{Name: "NAME", Direction: ParameterInOut, Type: StringType, InternalType: "VARCHAR2"},
+			{Name: "AMOUNT", Direction: ParameterOut, Type: DoubleType, InternalType: "NUMBER"},
+		}},
+		{Schema: schema, Package: pkgName, Name: "GET", Kind: RoutineFunction, Overload: 1,
+			Parameters: []*Parameter{{Name: "ID", Direction: ParameterIn, Type: DoubleType, InternalType: "NUMBER"}},
+			Return:     &Parameter{Direction: ParameterOut, Type: StringType, InternalType: "VARCHAR2"},
+		},
+		{Schema: schema, Package: pkgName, Name: "GET", Kind: RoutineFunction, Overload: 2,
+			Parameters: []*Parameter{
+				{Name: "NAME", Direction: ParameterIn, Type: StringType, InternalType: "VARCHAR2"},
+				{Name: "FLAG", Direction: ParameterIn, Type: BoolType, InternalType: "PL/SQL BOOLEAN", HasDefault: true},
+			},
+			Return: &Parameter{Direction: ParameterOut, Type: DateType, InternalType: "DATE"},
+		},
+	}
+	got := []*Routine{}
+	for _, r := range routines {
+		if r.Name == procName || r.Package == pkgName {
+			got = append(got, r)
+		}
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Mismatch of routines (-want +got):\n%s", diff)
+	}
+}
+
+func BenchmarkGetTablesOracle(b *testing.B) {
+	db := ConnectToOracle(b)
+	oDb := NewOracleDb(db)
+	schema := RequireEnvString("ORACLE_USER", b)
+
+	for i := 0; i < 25; i++ {
+		tableName, err := createTable(db, `id NUMBER(10,0) PRIMARY KEY NOT NULL, txt VARCHAR2(100), dte DATE NOT NULL`)
+		if err != nil {
+			b.Fatalf("Failed to create table: %s", err)
+		}
+		defer dropTable(db, tableName)
+	}
+
+	b.ResetTimer()
+
+	b.Run("Bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := oDb.GetTables(schema); err != nil {
+				b.Fatalf("Failed to get tables: %s", err)
+			}
+		}
+	})
+	// Baseline of the previous N+1 implementation: one query to list the tables
+	// and one GetTable call per table. GetTable runs the queries filtered by the
+	// name of the table like before the bulk loading
+	b.Run("NPlusOne", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tables, err := oDb.ListTables(schema)
+			if err != nil {
+				b.Fatalf("Failed to list tables: %s", err)
+			}
+			for _, t := range tables {
+				if _, err := oDb.GetTable(t.Schema, t.Name); err != nil {
+					b.Fatalf("Failed to get table: %s", err)
+				}
+			}
+		}
+	})
+}
+
 func addOracleComment(db *sql.DB, tbl string, column string, comment string) error {
 	comment = strings.ReplaceAll(comment, "\n", `'||char(10)||'`)
 	sql := fmt.Sprintf("COMMENT ON COLUMN \"%s\".\"%s\" IS '%s'", tbl, column, comment)
@@ -281,7 +703,7 @@ func addOracleComment(db *sql.DB, tbl string, column string, comment string) err
 	return err
 }
 
-func ConnectToOracle(t *testing.T) *sql.DB {
+func ConnectToOracle(t testing.TB) *sql.DB {
 	conString := goOra.BuildUrl(
 		RequireEnvString("ORACLE_SERVER", t),
 		RequireEnvInt("ORACLE_PORT", t),
@@ -1,5 +1,7 @@
 package ddl
 
+import "context"
+
 // DbSystem has to be implemented by every single database system that is
 // supported by the module to fetch a list of tables and columns
 type DbSystem interface {
@@ -11,3 +13,66 @@ type DbSystem interface {
 	// or database
 	GetTables(schema string) ([]*Table, error)
 }
+
+// DbSystemContext is implemented by database systems that support cancelling
+// the queries through a context
+type DbSystemContext interface {
+	DbSystem
+
+	// GetTableContext is like GetTable but uses the context for all queries
+	GetTableContext(ctx context.Context, schema, name string) (*Table, error)
+
+	// GetTablesContext is like GetTables but uses the context for all queries.
+	// When the context is canceled, no further tables are fetched
+	GetTablesContext(ctx context.Context, schema string) ([]*Table, error)
+}
+
+// DbSystemDiscovery is implemented by database systems that can list all schemas
+// and load the tables of multiple schemas at once
+type DbSystemDiscovery interface {
+	DbSystemContext
+
+	// GetSchemas returns the names of all schemas or databases
+	GetSchemas() ([]string, error)
+
+	// GetSchemasContext is like GetSchemas but uses the context for the query
+	GetSchemasContext(ctx context.Context) ([]string, error)
+
+	// GetTablesFiltered returns the tables of all schemas that are matched by
+	// the filter. All tables are returned if no filter is provided
+	GetTablesFiltered(filter *TableFilter) ([]*Table, error)
+
+	// GetTablesFilteredContext is like GetTablesFiltered but uses the context
+	// for all queries
+	GetTablesFilteredContext(ctx context.Context, filter *TableFilter) ([]*Table, error)
+}
+
+// DbSystemListing is implemented by database systems that can list the tables of
+// a schema without loading their columns
+type DbSystemListing interface {
+	DbSystem
+
+	// ListTables returns all tables and views of the schema sorted by their name.
+	// Only the identity, kind and metadata of the tables are loaded without any
+	// columns, indexes or keys
+	ListTables(schema string) ([]*Table, error)
+}
+
+// DbSystemSequences is implemented by database systems that support sequences
+type DbSystemSequences interface {
+	DbSystem
+
+	// GetSequences returns all sequences of the schema sorted by their name.
+	// Sequences that are created internally for identity columns are not returned
+	GetSequences(schema string) ([]*Sequence, error)
+}
+
+// DbSystemRoutines is implemented by database systems that support stored
+// procedures and functions
+type DbSystemRoutines interface {
+	DbSystem
+
+	// GetRoutines returns all procedures and functions of the schema including the
+	// routines of packages. They are sorted by the package, name and overload
+	GetRoutines(schema string) ([]*Routine, error)
+}
@@ -0,0 +1,230 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+var _ DbSystem = &Postgres{}
+var _ Columner = &PostgresColumn{}
+
+// Postgres implements "DbSystem" for a PostgreSQL database
+type Postgres struct {
+	db *sql.DB
+}
+
+type PostgresColumn struct {
+	*Column
+
+	// Weather this column has an auto increment behaviour.
+	// This is true for "serial" and identity columns
+	AutoIncrement bool
+
+	// Weather the default value of this column is a "nextval()" of a
+	// sequence (like created by the pseudo type "serial")
+	Serial bool
+
+	// Weather this column is an identity column ("GENERATED ... AS IDENTITY")
+	Identity bool
+
+	// The character lenght or numeric precision
+	DataTypeLenght int
+
+	// Decimal precision on the RIGHT side of the dot
+	Scale int
+
+	// Number of array dimensions. Zero if this column is not an array
+	ArrayDimensions int
+
+	// Name of the underlying data type like "int4", "varchar" or "_int4" for arrays
+	UdtName string
+}
+
+func (c *PostgresColumn) GetExtraInfos() string {
+	return "Postgres!"
+}
+func (c *PostgresColumn) GetSpecificInfos() any {
+	return c
+}
+func (s *Postgres) newColumn() *PostgresColumn {
+	c := &PostgresColumn{}
+	c.Column = &Column{}
+	c.Column.Extras = c
+	return c
+}
+
+// NewPostgres initializes a new database parser for a PostgreSQL database
+func NewPostgres(db *sql.DB) DbSystem {
+	return &Postgres{
+		db: db,
+	}
+}
+
+// postgresCastedDefault matches a default value like "'value'::character varying"
+var postgresCastedDefault = regexp.MustCompile(`^'(.*)'::[\w\s."\[\]]+$`)
+
+func (s *Postgres) GetTable(schema, name string) (*Table, error) {
+	sql := `
+		SELECT
+			c.table_schema,
+			c.table_name,
+			c.column_name,
+			c.column_default,
+			c.is_nullable,
+			c.data_type,
+			format_type(a.atttypid, a.atttypmod),
+			c.udt_name,
+			COALESCE(c.character_maximum_length, c.numeric_precision, c.datetime_precision, 0),
+			COALESCE(c.numeric_scale, 0),
+			c.is_identity,
+			a.attndims,
+			COALESCE(col_description(a.attrelid, a.attnum), ''),
+			EXISTS (
+				SELECT 1 FROM pg_catalog.pg_constraint pk
+				WHERE pk.conrelid = a.attrelid AND pk.contype = 'p' AND a.attnum = ANY(pk.conkey)
+			),
+			-- Foreign key data
+			COALESCE(fk.ref_table, ''), COALESCE(fk.ref_schema, ''), COALESCE(fk.ref_column, '')
+		FROM information_schema.columns c
+		JOIN pg_catalog.pg_namespace n ON n.nspname = c.table_schema
+		JOIN pg_catalog.pg_class cl ON cl.relnamespace = n.oid AND cl.relname = c.table_name
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = cl.oid AND a.attname = c.column_name
+		LEFT JOIN LATERAL (
+			SELECT rn.nspname AS ref_schema, rc.relname AS ref_table, ra.attname AS ref_column
+			FROM pg_catalog.pg_constraint con
+			JOIN pg_catalog.pg_class rc ON rc.oid = con.confrelid
+			JOIN pg_catalog.pg_namespace rn ON rn.oid = rc.relnamespace
+			JOIN pg_catalog.pg_attribute ra ON ra.attrelid = con.confrelid
+				AND ra.attnum = con.confkey[array_position(con.conkey, a.attnum)]
+			WHERE con.conrelid = a.attrelid AND con.contype = 'f' AND a.attnum = ANY(con.conkey)
+			LIMIT 1
+		) fk ON true
+		WHERE c.table_schema = $1 AND c.table_name = $2
+		ORDER BY c.ordinal_position
+	`
+	rows, err := s.db.Query(sql, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema: %s", err)
+	}
+	defer rows.Close()
+
+	table := &Table{}
+	count := 0
+	for rows.Next() {
+		var tableSchema, tableName, isNullable, dataType, identity string
+		column := s.newColumn()
+
+		if err := rows.Scan(
+			&tableSchema, &tableName,
+			&column.Name, &column.DefaultValue, &isNullable,
+			&dataType, &column.InternalType, &column.UdtName,
+			&column.DataTypeLenght, &column.Scale,
+			&identity, &column.ArrayDimensions, &column.Comment, &column.PrimaryKey,
+			&column.ForeignKeyColumn.Name, &column.ForeignKeyColumn.Schema, &column.ForeignKeyColumn.Column,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Apply data
+		column.CanBeNull = isNullable == "YES"
+		column.Identity = identity == "YES"
+		column.Serial = column.DefaultValue.Valid && strings.HasPrefix(column.DefaultValue.String, "nextval(")
+		column.AutoIncrement = column.Identity || column.Serial
+		column.ForeignKey = column.ForeignKeyColumn.Column != ""
+		column.Type = s.GetDataType(dataType, column)
+		if column.Type == DecimalType {
+			column.NumericPrecision = column.DataTypeLenght
+			column.NumericScale = column.Scale
+		}
+
+		// Array types are reported without a dimension when no size was specified
+		if dataType == "ARRAY" && column.ArrayDimensions == 0 {
+			column.ArrayDimensions = 1
+		}
+
+		// The default value contains the raw single quotes and a type cast
+		if column.DefaultValue.Valid {
+			if matches := postgresCastedDefault.FindStringSubmatch(column.DefaultValue.String); len(matches) == 2 {
+				column.DefaultValue.String = matches[1]
+			} else {
+				column.DefaultValue.String = strings.TrimPrefix(column.DefaultValue.String, "'")
+				column.DefaultValue.String = strings.TrimSuffix(column.DefaultValue.String, "'")
+			}
+		}
+
+		// Initialize new table metadata
+		if count == 0 {
+			table.Schema = tableSchema
+			table.Name = tableName
+		}
+		table.Columns = append(table.Columns, column.Column)
+		count += 1
+	}
+
+	// We got no data
+	if count == 0 {
+		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	}
+
+	return table, nil
+}
+
+func (s *Postgres) GetTables(schema string) ([]*Table, error) {
+	sql := `
+		SELECT
+			t.table_schema,
+			t.table_name
+		FROM information_schema.tables t
+		WHERE t.table_schema = $1
+		ORDER BY t.table_name ASC
+	`
+	rows, err := s.db.Query(sql, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information_schema: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Table{}
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		t, err := s.GetTable(tableSchema, tableName)
+		if err != nil {
+			return rtc, fmt.Errorf("failed to get data for %s.%s: %s", tableSchema, tableName, err)
+		}
+		rtc = append(rtc, t)
+	}
+
+	return rtc, nil
+}
+
+func (s *Postgres) GetDataType(internalType string, col *PostgresColumn) DataType {
+	switch strings.ToLower(internalType) {
+	case "character varying", "character", "text", "citext", "name":
+		return StringType
+	case "integer", "smallint", "bigint":
+		return IntType
+	case "real", "double precision":
+		return DoubleType
+	case "numeric":
+		// A numeric without any scale can only hold integers
+		if col.Scale == 0 && col.DataTypeLenght != 0 {
+			return IntType
+		}
+		return DecimalType
+	case "date", "timestamp without time zone", "timestamp with time zone":
+		return DateType
+	case "point":
+		return GeoType
+	default:
+		logger.Warning("Postgres: received unknown data type column: %s", internalType)
+		return UnknownType
+	}
+}
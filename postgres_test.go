@@ -0,0 +1,294 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/lib/pq"
+)
+
+// TestGetTableSimplePostgres tests the construction of a Table struct
+// with all supported data types and fields
+func TestGetTableSimplePostgres(t *testing.T) {
+	db := ConnectToPostgres(t)
+	pDb := NewPostgres(db)
+
+	// Create test table
+	tableName, err := createTable(db,
+		`
+		id 		SERIAL PRIMARY KEY NOT NULL,
+		txt 	VARCHAR(100) DEFAULT 'Ich bins, der Tim!',
+		amount	NUMERIC(10,2),
+		tags	TEXT[],
+		dte		TIMESTAMP NOT NULL
+		`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName = strings.ToLower(tableName)
+	defer dropTable(db, tableName)
+
+	// Comment table
+	if _, err := db.Exec(fmt.Sprintf(`COMMENT ON COLUMN %s.dte IS 'Hallo ihr da!'`, tableName)); err != nil {
+		t.Fatalf("Failed to comment table: %s", err)
+	}
+
+	// Get columns
+	table, err := pDb.GetTable(RequireEnvString("POSTGRES_SCHEMA", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   tableName,
+		Schema: RequireEnvString("POSTGRES_SCHEMA", t),
+	}
+	columns := []*PostgresColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "integer",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: fmt.Sprintf("nextval('%s_id_seq'::regclass)", tableName),
+				},
+			},
+			AutoIncrement:  true,
+			Serial:         true,
+			DataTypeLenght: 32,
+			UdtName:        "int4",
+		},
+		{
+			Column: &Column{
+				Name:         "txt",
+				PrimaryKey:   false,
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "character varying(100)",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "Ich bins, der Tim!",
+				},
+			},
+			DataTypeLenght: 100,
+			UdtName:        "varchar",
+		},
+		{
+			Column: &Column{
+				Name:             "amount",
+				CanBeNull:        true,
+				Type:             DecimalType,
+				InternalType:     "numeric(10,2)",
+				NumericPrecision: 10,
+				NumericScale:     2,
+			},
+			DataTypeLenght: 10,
+			Scale:          2,
+			UdtName:        "numeric",
+		},
+		{
+			Column: &Column{
+				Name:         "tags",
+				CanBeNull:    true,
+				Type:         UnknownType,
+				InternalType: "text[]",
+			},
+			ArrayDimensions: 1,
+			UdtName:         "_text",
+		},
+		{
+			Column: &Column{
+				Name:         "dte",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         DateType,
+				InternalType: "timestamp without time zone",
+				Comment:      "Hallo ihr da!",
+			},
+			DataTypeLenght: 6,
+			UdtName:        "timestamp",
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch of columns (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTablePostgresFK tests the construction of a Table struct
+// that references another table
+func TestGetTablePostgresFK(t *testing.T) {
+	db := ConnectToPostgres(t)
+	pDb := NewPostgres(db)
+
+	// Create table we reference to
+	referenceTableName, err := createTable(db, `
+		id_to_ref   INTEGER PRIMARY KEY NOT NULL,
+		rand        VARCHAR(10) NOT NULL`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	referenceTableName = strings.ToLower(referenceTableName)
+	defer dropTable(db, referenceTableName)
+
+	// Create table with reference
+	tableName, err := createTable(db, `
+		id 		 INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+		other_id INTEGER NOT NULL,
+		CONSTRAINT fk_test_constraint_for_you FOREIGN KEY(other_id) REFERENCES `+referenceTableName+`(id_to_ref)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName = strings.ToLower(tableName)
+	defer dropTable(db, tableName)
+
+	table, err := pDb.GetTable(RequireEnvString("POSTGRES_SCHEMA", t), tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:   tableName,
+		Schema: RequireEnvString("POSTGRES_SCHEMA", t),
+	}
+	columns := []*PostgresColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "integer",
+			},
+			AutoIncrement:  true,
+			Identity:       true,
+			DataTypeLenght: 32,
+			UdtName:        "int4",
+		},
+		{
+			Column: &Column{
+				Name:         "other_id",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "integer",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   referenceTableName,
+					Schema: RequireEnvString("POSTGRES_SCHEMA", t),
+					Column: "id_to_ref",
+				},
+			},
+			DataTypeLenght: 32,
+			UdtName:        "int4",
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTablesPostgres tests the selecting of multiple tables to a []Table array
+func TestGetTablesPostgres(t *testing.T) {
+	db := ConnectToPostgres(t)
+	pDb := NewPostgres(db)
+
+	// Create two simple tables
+	tableName1, err := createTable(db, `idTab1 INTEGER NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName1 = strings.ToLower(tableName1)
+	defer dropTable(db, tableName1)
+
+	tableName2, err := createTable(db, `idTab2 INTEGER NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	tableName2 = strings.ToLower(tableName2)
+	defer dropTable(db, tableName2)
+
+	tables, err := pDb.GetTables(RequireEnvString("POSTGRES_SCHEMA", t))
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+
+	found1 := 0
+	found2 := 0
+	for _, tt := range tables {
+		if tt.Name == tableName1 {
+			found1 = found1 + 1
+		}
+		if tt.Name == tableName2 {
+			found2 = found2 + 1
+
+			// Compare table
+			expected := &Table{
+				Name:   tt.Name,
+				Schema: RequireEnvString("POSTGRES_SCHEMA", t),
+			}
+			columns := []*PostgresColumn{
+				{
+					Column: &Column{
+						Name:         "idtab2",
+						CanBeNull:    false,
+						Type:         IntType,
+						InternalType: "integer",
+					},
+					DataTypeLenght: 32,
+					UdtName:        "int4",
+				},
+			}
+			for _, c := range columns {
+				c.Extras = c
+				expected.Columns = append(expected.Columns, c.Column)
+			}
+
+			// Compare struct
+			if diff := cmp.Diff(tt, expected); diff != "" {
+				t.Errorf("Mismatch of tab2: (-want +got):\n%s", diff)
+			}
+		}
+	}
+
+	// We expected to find exactly one single table
+	if found1 != 1 {
+		t.Errorf("Found %d instances of tab1. Expected 1 (len(rtc) = %d)", found1, len(tables))
+	}
+	if found2 != 1 {
+		t.Errorf("Found %d instances of tab2. Expected 1 (len(rtc) = %d)", found2, len(tables))
+	}
+}
+
+func ConnectToPostgres(t *testing.T) *sql.DB {
+	db, err := sql.Open("postgres", fmt.Sprintf(
+		"postgres://%s:%s@%s/%s?sslmode=disable&search_path=%s",
+		RequireEnvString("POSTGRES_USER", t), RequireEnvString("POSTGRES_PASSWORD", t), RequireEnvString("POSTGRES_ADDRESS", t),
+		RequireEnvString("POSTGRES_DB", t), RequireEnvString("POSTGRES_SCHEMA", t),
+	))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to open DB connection: %s", err))
+	}
+
+	return db
+}
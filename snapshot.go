@@ -0,0 +1,414 @@
+package ddl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var _ DbSystem = &SnapshotDb{}
+var _ DbSystemListing = &SnapshotDb{}
+
+// Snapshot contains the tables of one or more schemas. It can be written to and
+// read from a JSON or YAML file to generate the structs without a database connection
+type Snapshot struct {
+
+	// All tables with their columns and constraints
+	Tables []*Table
+}
+
+// SnapshotDb implements "DbSystem" for the tables of a snapshot
+type SnapshotDb struct {
+	snapshot *Snapshot
+}
+
+// NewSnapshotDb initializes a new database system that returns the tables of the snapshot
+func NewSnapshotDb(snapshot *Snapshot) *SnapshotDb {
+	return &SnapshotDb{
+		snapshot: snapshot,
+	}
+}
+
+// NewSnapshotDbFromFile initializes a new database system with the snapshot
+// of the file
+func NewSnapshotDbFromFile(path string) (*SnapshotDb, error) {
+	snapshot, err := ReadSnapshotFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSnapshotDb(snapshot), nil
+}
+
+func (s *SnapshotDb) GetTable(schema, name string) (*Table, error) {
+	for _, t := range s.snapshot.Tables {
+		if t.Schema == schema && t.Name == name {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s.%s was not found", schema, name)
+}
+
+func (s *SnapshotDb) GetTables(schema string) ([]*Table, error) {
+	rtc := []*Table{}
+	for _, t := range s.snapshot.Tables {
+		if t.Schema == schema {
+			rtc = append(rtc, t)
+		}
+	}
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+
+	return rtc, nil
+}
+
+func (s *SnapshotDb) ListTables(schema string) ([]*Table, error) {
+	tables, err := s.GetTables(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the metadata of the tables is returned
+	rtc := make([]*Table, len(tables))
+	for i, t := range tables {
+		rtc[i] = &Table{Name: t.Name, Schema: t.Schema}
+		rtc[i].applyInfo(t)
+	}
+
+	return rtc, nil
+}
+
+// ReadSnapshotFile reads a snapshot from a JSON or YAML file. The format is
+// determined by the file extension
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %s", path, err)
+	}
+
+	rtc := &Snapshot{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, rtc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, rtc)
+	default:
+		return nil, fmt.Errorf("unsupported file extension of snapshot %q", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %q: %s", path, err)
+	}
+
+	return rtc, nil
+}
+
+// WriteFile writes the snapshot as JSON or YAML to the file. The format is
+// determined by the file extension
+func (s *Snapshot) WriteFile(path string) error {
+	var content []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		content, err = json.MarshalIndent(s, "", "\t")
+	case ".yaml", ".yml":
+		content, err = yaml.Marshal(s)
+	default:
+		return fmt.Errorf("unsupported file extension of snapshot %q", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshot: %s", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file %q: %s", path, err)
+	}
+	return nil
+}
+
+// snapshotExtras contains a constructor for the extras of every database system.
+// The key is stored as the type of the extras within a serialized column
+var snapshotExtras = map[string]func() Columner{
+	"mariadb":  func() Columner { return &MariadbColumn{} },
+	"oracle":   func() Columner { return &OracleColumn{} },
+	"mssql":    func() Columner { return &MssqlColumn{} },
+	"postgres": func() Columner { return &PostgresColumn{} },
+	"sqlite":   func() Columner { return &SqliteColumn{} },
+}
+
+// columnFields contains all fields of a column without any methods
+type columnFields Column
+
+// columnSnapshot is the serialized form of a column. The extras are stored
+// without the column together with the name of the database system
+type columnSnapshot struct {
+	columnFields `yaml:",inline"`
+
+	ExtrasType string `json:",omitempty" yaml:"extrastype,omitempty"`
+	Extras     any    `json:",omitempty" yaml:"extras,omitempty"`
+}
+
+func (c *Column) MarshalJSON() ([]byte, error) {
+	snapshot, err := c.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *Column) UnmarshalJSON(data []byte) error {
+	var snapshot struct {
+		columnFields
+
+		ExtrasType string
+		Extras     json.RawMessage
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	*c = Column(snapshot.columnFields)
+	return c.restoreExtras(snapshot.ExtrasType, func(v any) error {
+		return json.Unmarshal(snapshot.Extras, v)
+	})
+}
+
+func (c *Column) MarshalYAML() (any, error) {
+	return c.snapshot()
+}
+
+func (c *Column) UnmarshalYAML(node *yaml.Node) error {
+	var snapshot struct {
+		columnFields `yaml:",inline"`
+
+		ExtrasType string    `yaml:"extrastype"`
+		Extras     yaml.Node `yaml:"extras"`
+	}
+	if err := node.Decode(&snapshot); err != nil {
+		return err
+	}
+
+	*c = Column(snapshot.columnFields)
+	return c.restoreExtras(snapshot.ExtrasType, snapshot.Extras.Decode)
+}
+
+// snapshot returns the serialized form of the column
+func (c *Column) snapshot() (*columnSnapshot, error) {
+	rtc := &columnSnapshot{columnFields: columnFields(*c)}
+	if c.Extras == nil {
+		return rtc, nil
+	}
+
+	for name, newExtras := range snapshotExtras {
+		if reflect.TypeOf(newExtras()) != reflect.TypeOf(c.Extras) {
+			continue
+		}
+
+		extras := reflect.ValueOf(c.Extras).Elem()
+		fields, indexes := extrasFields(extras.Type())
+		value := reflect.New(fields).Elem()
+		for i, index := range indexes {
+			value.Field(i).Set(extras.Field(index))
+		}
+
+		rtc.ExtrasType = name
+		rtc.Extras = value.Addr().Interface()
+		return rtc, nil
+	}
+
+	return nil, fmt.Errorf("unsupported extras %T of column %q", c.Extras, c.Name)
+}
+
+// restoreExtras creates the extras of the type and links them with the column.
+// The fields of the extras are decoded with the provided function
+func (c *Column) restoreExtras(typ string, decode func(v any) error) error {
+	if typ == "" {
+		return nil
+	}
+	newExtras, ok := snapshotExtras[typ]
+	if !ok {
+		return fmt.Errorf("unsupported extras type %q of column %q", typ, c.Name)
+	}
+
+	extras := newExtras()
+	value := reflect.ValueOf(extras).Elem()
+	fields, indexes := extrasFields(value.Type())
+	decoded := reflect.New(fields)
+	if err := decode(decoded.Interface()); err != nil {
+		return fmt.Errorf("failed to decode extras of column %q: %s", c.Name, err)
+	}
+
+	for i, index := range indexes {
+		value.Field(index).Set(decoded.Elem().Field(i))
+	}
+	value.FieldByName("Column").Set(reflect.ValueOf(c))
+	c.Extras = extras
+
+	return nil
+}
+
+// extrasSnapshot returns the serialized form of the extras together with their column.
+// The methods of the column are promoted to the extras, so they have to be
+// serialized explicitly
+func extrasSnapshot(extras Columner, column *Column) (*columnSnapshot, error) {
+	c := Column{}
+	if column != nil {
+		c = *column
+	}
+	c.Extras = extras
+
+	return c.snapshot()
+}
+
+// decodeExtras decodes a serialized column with the provided function into the extras.
+// The column of the extras is allocated if it's nil and is linked with the extras
+func decodeExtras(extras Columner, column **Column, decode func(c *Column) error) error {
+	if *column == nil {
+		*column = &Column{}
+	}
+	c := *column
+	if err := decode(c); err != nil {
+		return err
+	}
+
+	// Take over the decoded extras which also point to the column
+	if c.Extras != nil {
+		if reflect.TypeOf(c.Extras) != reflect.TypeOf(extras) {
+			return fmt.Errorf("expected extras of the type %T for column %q. Got %T", extras, c.Name, c.Extras)
+		}
+		reflect.ValueOf(extras).Elem().Set(reflect.ValueOf(c.Extras).Elem())
+	}
+	c.Extras = extras
+
+	return nil
+}
+
+func (c MariadbColumn) MarshalJSON() ([]byte, error) {
+	snapshot, err := extrasSnapshot(&c, c.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *MariadbColumn) UnmarshalJSON(data []byte) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalJSON(data) })
+}
+
+func (c MariadbColumn) MarshalYAML() (any, error) {
+	return extrasSnapshot(&c, c.Column)
+}
+
+func (c *MariadbColumn) UnmarshalYAML(node *yaml.Node) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalYAML(node) })
+}
+
+func (c OracleColumn) MarshalJSON() ([]byte, error) {
+	snapshot, err := extrasSnapshot(&c, c.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *OracleColumn) UnmarshalJSON(data []byte) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalJSON(data) })
+}
+
+func (c OracleColumn) MarshalYAML() (any, error) {
+	return extrasSnapshot(&c, c.Column)
+}
+
+func (c *OracleColumn) UnmarshalYAML(node *yaml.Node) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalYAML(node) })
+}
+
+func (c MssqlColumn) MarshalJSON() ([]byte, error) {
+	snapshot, err := extrasSnapshot(&c, c.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *MssqlColumn) UnmarshalJSON(data []byte) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalJSON(data) })
+}
+
+func (c MssqlColumn) MarshalYAML() (any, error) {
+	return extrasSnapshot(&c, c.Column)
+}
+
+func (c *MssqlColumn) UnmarshalYAML(node *yaml.Node) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalYAML(node) })
+}
+
+func (c PostgresColumn) MarshalJSON() ([]byte, error) {
+	snapshot, err := extrasSnapshot(&c, c.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *PostgresColumn) UnmarshalJSON(data []byte) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalJSON(data) })
+}
+
+func (c PostgresColumn) MarshalYAML() (any, error) {
+	return extrasSnapshot(&c, c.Column)
+}
+
+func (c *PostgresColumn) UnmarshalYAML(node *yaml.Node) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalYAML(node) })
+}
+
+func (c SqliteColumn) MarshalJSON() ([]byte, error) {
+	snapshot, err := extrasSnapshot(&c, c.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (c *SqliteColumn) UnmarshalJSON(data []byte) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalJSON(data) })
+}
+
+func (c SqliteColumn) MarshalYAML() (any, error) {
+	return extrasSnapshot(&c, c.Column)
+}
+
+func (c *SqliteColumn) UnmarshalYAML(node *yaml.Node) error {
+	return decodeExtras(c, &c.Column, func(col *Column) error { return col.UnmarshalYAML(node) })
+}
+
+// extrasFields returns a struct type with all fields of the extras except the embedded
+// column and the indexes of these fields within the extras. The methods of the column
+// are not promoted to this type, so it can be serialized without a recursion
+func extrasFields(extras reflect.Type) (reflect.Type, []int) {
+	fields := []reflect.StructField{}
+	indexes := []int{}
+	for i := 0; i < extras.NumField(); i++ {
+		field := extras.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+		indexes = append(indexes, i)
+	}
+
+	return reflect.StructOf(fields), indexes
+}
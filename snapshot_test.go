@@ -0,0 +1,189 @@
+package ddl
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gopkg.in/yaml.v3"
+)
+
+// getSnapshotTables returns tables with the extras of different database systems
+func getSnapshotTables(t *testing.T) []*Table {
+	mariadb := NewMariadbScript("ddl")
+	if err := mariadb.Parse(`
+		CREATE TABLE parent (id INT(10) AUTO_INCREMENT PRIMARY KEY);
+		CREATE TABLE child (
+			id        INT(10) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			parent_id INT(10) NOT NULL,
+			state     ENUM('on', 'off') DEFAULT 'on' COMMENT 'State',
+			total     DECIMAL(10,2) AS (id * 2) PERSISTENT,
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id) ON DELETE CASCADE,
+			CONSTRAINT chk_id CHECK (id > 0)
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse mariadb script: %s", err)
+	}
+	tables, _ := mariadb.GetTables("ddl")
+
+	oracle := NewOracleScript("OTHER")
+	if err := oracle.Parse(`
+		CREATE TABLE item (
+			id   NUMBER(10) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			name VARCHAR2(20) NOT NULL
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse oracle script: %s", err)
+	}
+	oracleTables, _ := oracle.GetTables("OTHER")
+	tables = append(tables, oracleTables...)
+
+	// Columns of other database systems and without any extras
+	sqlite := &SqliteColumn{Column: &Column{Name: "rowid", Type: IntType}, AutoIncrement: true, Affinity: SqliteAffinityInteger}
+	sqlite.Extras = sqlite
+	tables = append(tables, &Table{
+		Name:          "various",
+		Schema:        "main",
+		Columns:       []*Column{sqlite.Column, {Name: "plain", Type: StringType, CanBeNull: true}},
+		Created:       sql.NullTime{Valid: true, Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		EstimatedRows: sql.NullInt64{Valid: true, Int64: 42},
+	})
+
+	return tables
+}
+
+// TestSnapshotRoundTrip tests the serialization of tables to JSON and YAML files
+func TestSnapshotRoundTrip(t *testing.T) {
+	tables := getSnapshotTables(t)
+
+	for _, file := range []string{"snapshot.json", "snapshot.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := (&Snapshot{Tables: tables}).WriteFile(path); err != nil {
+				t.Fatalf("Failed to write snapshot: %s", err)
+			}
+
+			snapshot, err := ReadSnapshotFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read snapshot: %s", err)
+			}
+			// Empty lists are decoded as an empty slice instead of nil from YAML
+			if diff := cmp.Diff(tables, snapshot.Tables, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Mismatch of tables (-want +got):\n%s", diff)
+			}
+
+			// The extras have to point to their column
+			for _, tbl := range snapshot.Tables {
+				for _, c := range tbl.Columns {
+					if c.Extras != nil && reflectColumn(c.Extras) != c {
+						t.Errorf("Expected the extras of %s.%s to reference the column", tbl.Name, c.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestSnapshotExtrasRoundTrip tests the serialization of the extras without a table
+func TestSnapshotExtrasRoundTrip(t *testing.T) {
+	mariadb := &MariadbColumn{Column: &Column{Name: "state", Type: EnumType}, EnumValues: []string{"on", "off"}}
+	oracle := &OracleColumn{Column: &Column{Name: "ID", Type: IntType}, AutoIncrement: true, IdentityGeneration: "ALWAYS"}
+	mssql := &MssqlColumn{Column: &Column{Name: "id", Type: IntType}, AutoIncrement: true, IdentitySeed: 5}
+	postgres := &PostgresColumn{Column: &Column{Name: "id", Type: IntType}, Serial: true, UdtName: "int4"}
+	sqlite := &SqliteColumn{Column: &Column{Name: "rowid", Type: IntType}, Affinity: SqliteAffinityInteger}
+	mariadb.Extras, oracle.Extras, mssql.Extras, postgres.Extras, sqlite.Extras = mariadb, oracle, mssql, postgres, sqlite
+
+	for _, extras := range []Columner{mariadb, oracle, mssql, postgres, sqlite} {
+		for name, format := range map[string]struct {
+			marshal   func(v any) ([]byte, error)
+			unmarshal func(data []byte, v any) error
+		}{
+			"json": {json.Marshal, json.Unmarshal},
+			"yaml": {yaml.Marshal, yaml.Unmarshal},
+		} {
+			t.Run(reflect.TypeOf(extras).Elem().Name()+"/"+name, func(t *testing.T) {
+				data, err := format.marshal(extras)
+				if err != nil {
+					t.Fatalf("Failed to marshal: %s", err)
+				}
+
+				// The column of the extras is allocated while decoding
+				got := reflect.New(reflect.TypeOf(extras).Elem()).Interface().(Columner)
+				if err := format.unmarshal(data, got); err != nil {
+					t.Fatalf("Failed to unmarshal: %s", err)
+				}
+				if diff := cmp.Diff(extras, got, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Mismatch of extras (-want +got):\n%s", diff)
+				}
+				if c := reflectColumn(got); c == nil || c.Extras != got {
+					t.Errorf("Expected the column to reference the extras")
+				}
+			})
+		}
+	}
+}
+
+// TestSnapshotDb tests the loading of tables from a snapshot
+func TestSnapshotDb(t *testing.T) {
+	db := NewSnapshotDb(&Snapshot{Tables: getSnapshotTables(t)})
+
+	tables, err := db.GetTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+	if len(tables) != 2 || tables[0].Name != "child" || tables[1].Name != "parent" {
+		t.Errorf("Expected the tables child and parent. Got %s", DumpStruct(tables))
+	}
+
+	table, err := db.GetTable("OTHER", "ITEM")
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err)
+	}
+	if !table.Columns[0].Extras.(*OracleColumn).AutoIncrement {
+		t.Errorf("Expected the column ID to be an auto increment column")
+	}
+	if _, err := db.GetTable("OTHER", "missing"); err == nil {
+		t.Errorf("Expected an error for a missing table")
+	}
+
+	listed, err := db.ListTables("ddl")
+	if err != nil {
+		t.Fatalf("Failed to list tables: %s", err)
+	}
+	if len(listed) != 2 || listed[0].Kind != TableKindTable || listed[0].Columns != nil {
+		t.Errorf("Expected the tables without columns. Got %s", DumpStruct(listed))
+	}
+}
+
+// TestReadSnapshotFileUnsupported tests the rejection of unknown file formats
+func TestReadSnapshotFileUnsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.txt")
+	if err := (&Snapshot{}).WriteFile(path); err == nil {
+		t.Errorf("Expected an error for writing an unsupported format")
+	}
+	if _, err := ReadSnapshotFile(path); err == nil {
+		t.Errorf("Expected an error for reading an unsupported format")
+	}
+}
+
+// reflectColumn returns the column embedded within the extras
+func reflectColumn(extras Columner) *Column {
+	switch e := extras.(type) {
+	case *MariadbColumn:
+		return e.Column
+	case *OracleColumn:
+		return e.Column
+	case *SqliteColumn:
+		return e.Column
+	case *MssqlColumn:
+		return e.Column
+	case *PostgresColumn:
+		return e.Column
+	}
+	return nil
+}
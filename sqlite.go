@@ -0,0 +1,488 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SqliteAffinity is the type affinity SQLite assigns to a column
+// based on its declared type.
+// See https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+type SqliteAffinity string
+
+const (
+	SqliteAffinityText    SqliteAffinity = "TEXT"
+	SqliteAffinityNumeric SqliteAffinity = "NUMERIC"
+	SqliteAffinityInteger SqliteAffinity = "INTEGER"
+	SqliteAffinityReal    SqliteAffinity = "REAL"
+	SqliteAffinityBlob    SqliteAffinity = "BLOB"
+)
+
+var _ DbSystem = &Sqlite{}
+var _ DbSystemListing = &Sqlite{}
+var _ Columner = &SqliteColumn{}
+
+// Sqlite implements "DbSystem" for a SQLite database.
+// The schema is the name of the attached database like "main"
+type Sqlite struct {
+	db *sql.DB
+}
+
+type SqliteColumn struct {
+	*Column
+
+	// Weather this column is an alias for the "rowid" and gets its value
+	// automatically assigned ("INTEGER PRIMARY KEY")
+	AutoIncrement bool
+
+	// The character lenght or numeric precision of the declared type
+	DataTypeLenght int
+
+	// The type affinity of the declared type
+	Affinity SqliteAffinity
+}
+
+func (c *SqliteColumn) GetExtraInfos() string {
+	return "SQLite!"
+}
+func (c *SqliteColumn) GetSpecificInfos() any {
+	return c
+}
+func (s *Sqlite) newColumn() *SqliteColumn {
+	c := &SqliteColumn{}
+	c.Column = &Column{}
+	c.Column.Extras = c
+	return c
+}
+
+// NewSqlite initializes a new database parser for a SQLite database
+func NewSqlite(db *sql.DB) DbSystem {
+	return &Sqlite{
+		db: db,
+	}
+}
+
+// sqliteTypeLength matches the first length argument of a declared type like "VARCHAR(100)"
+var sqliteTypeLength = regexp.MustCompile(`\(\s*(\d+)`)
+
+func (s *Sqlite) GetTable(schema, name string) (*Table, error) {
+
+	// Get the create statement to detect the "WITHOUT ROWID" option
+	var createStatement, objectType string
+	if err := s.db.QueryRow(
+		fmt.Sprintf(`SELECT sql, type FROM %s.sqlite_master WHERE type IN ('table', 'view') AND name = ?`, quoteSqliteIdentifier(schema)),
+		name,
+	).Scan(&createStatement, &objectType); err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s.%s was not found", schema, name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to query sqlite_master: %s", err)
+	}
+	withoutRowid := strings.Contains(strings.ToUpper(createStatement), "WITHOUT ROWID")
+
+	sql := `
+		SELECT
+			c.name,
+			c.dflt_value,
+			c."notnull",
+			c.type,
+			c.pk
+		FROM pragma_table_info(?, ?) c
+		ORDER BY c.cid
+	`
+	rows, err := s.db.Query(sql, name, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table_info: %s", err)
+	}
+	defer rows.Close()
+
+	table := &Table{
+		Schema: schema,
+		Name:   name,
+		Kind:   TableKindTable,
+	}
+	if objectType == "view" {
+		table.Kind = TableKindView
+		table.ViewDefinition = s.getViewDefinition(createStatement)
+	}
+	primaryKeys := 0
+	for rows.Next() {
+		var notNull, pk int
+		column := s.newColumn()
+
+		if err := rows.Scan(
+			&column.Name, &column.DefaultValue, &notNull,
+			&column.InternalType, &pk,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Apply data
+		column.PrimaryKey = pk > 0
+		column.CanBeNull = notNull == 0 && !column.PrimaryKey
+		column.Affinity = s.GetAffinity(column.InternalType)
+		column.Type = s.GetDataType(column.InternalType)
+		if matches := sqliteTypeLength.FindStringSubmatch(column.InternalType); len(matches) == 2 {
+			column.DataTypeLenght, _ = strconv.Atoi(matches[1])
+		}
+		if column.PrimaryKey {
+			primaryKeys++
+		}
+
+		// The default value contains the raw single quotes of the create statement
+		if column.DefaultValue.Valid {
+			column.DefaultValue.String = strings.TrimPrefix(column.DefaultValue.String, "'")
+			column.DefaultValue.String = strings.TrimSuffix(column.DefaultValue.String, "'")
+		}
+
+		table.Columns = append(table.Columns, column.Column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table_info: %s", err)
+	}
+
+	// A single primary key column with the type "INTEGER" is an alias for the rowid
+	if primaryKeys == 1 && !withoutRowid {
+		for _, c := range table.Columns {
+			if c.PrimaryKey && strings.EqualFold(c.InternalType, "INTEGER") {
+				c.Extras.(*SqliteColumn).AutoIncrement = true
+			}
+		}
+	}
+
+	// Get primary and foreign keys
+	if table.PrimaryKey, err = s.getPrimaryKey(schema, name); err != nil {
+		return nil, err
+	}
+	if table.ForeignKeys, err = s.getForeignKeys(schema, name); err != nil {
+		return nil, err
+	}
+	table.applyForeignKeys()
+
+	// Get indexes
+	if table.Indexes, err = s.getIndexes(schema, name); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// getIndexes returns all indexes of the table. Indexes created implicitly
+// for primary keys and unique constraints are named "sqlite_autoindex_*"
+func (s *Sqlite) getIndexes(schema, name string) ([]*Index, error) {
+	ssql := fmt.Sprintf(`
+		SELECT
+			il.name,
+			il."unique",
+			ii.name,
+			COALESCE(m.sql, '')
+		FROM pragma_index_list(?, ?) il
+		JOIN pragma_index_info(il.name, ?) ii
+		LEFT JOIN %s.sqlite_master m ON m.type = 'index' AND m.name = il.name
+		ORDER BY il.name, ii.seqno
+	`, quoteSqliteIdentifier(schema))
+	rows, err := s.db.Query(ssql, name, schema, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index_list: %s", err)
+	}
+	defer rows.Close()
+
+	var rtc []*Index
+	var expressions []string
+	for rows.Next() {
+		var indexName, createStatement string
+		var column sql.NullString
+		var unique int
+		if err := rows.Scan(&indexName, &unique, &column, &createStatement); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Every column of an index is returned as an own row
+		if len(rtc) == 0 || rtc[len(rtc)-1].Name != indexName {
+			index := &Index{
+				Name:   indexName,
+				Unique: unique == 1,
+			}
+			expressions, index.Predicate = parseSqliteIndex(createStatement)
+			rtc = append(rtc, index)
+		}
+		index := rtc[len(rtc)-1]
+
+		// The column name is NULL for expressions
+		if !column.Valid && len(index.Columns) < len(expressions) {
+			column.String = expressions[len(index.Columns)]
+		}
+		index.Columns = append(index.Columns, column.String)
+	}
+
+	return rtc, rows.Err()
+}
+
+// parseSqliteIndex returns the raw column expressions and the condition
+// of a partial index from a "CREATE INDEX" statement
+func parseSqliteIndex(createStatement string) (expressions []string, predicate string) {
+	p, err := newSqlParser(createStatement, sqliteDialect)
+	if err != nil {
+		return nil, ""
+	}
+
+	// Skip everything till the column list
+	for !p.eof() && !p.isSymbol("(") {
+		p.next()
+	}
+	if !p.acceptSymbol("(") {
+		return nil, ""
+	}
+	for !p.eof() {
+		expressions = append(expressions, p.expression("COLLATE", "ASC", "DESC"))
+		for !p.eof() && !p.isSymbol(",") && !p.isSymbol(")") {
+			p.next()
+		}
+		if p.acceptSymbol(")") {
+			break
+		}
+		p.acceptSymbol(",")
+	}
+
+	if p.acceptKeywords("WHERE") {
+		predicate = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(createStatement[p.peek().start:]), ";"))
+	}
+
+	return expressions, predicate
+}
+
+// getForeignKeys returns all foreign keys of the table. Because SQLite
+// doesn't store the name of the constraints, the name is always empty
+func (s *Sqlite) getForeignKeys(schema, name string) ([]*ForeignKey, error) {
+	ssql := `
+		SELECT
+			f.id,
+			f."table",
+			f."from",
+			f."to",
+			f.on_delete,
+			f.on_update
+		FROM pragma_foreign_key_list(?, ?) f
+		ORDER BY f.id, f.seq
+	`
+	rows, err := s.db.Query(ssql, name, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign_key_list: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*ForeignKey{}
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		var refTable, from, onDelete, onUpdate string
+		var to sql.NullString
+		if err := rows.Scan(&id, &refTable, &from, &to, &onDelete, &onUpdate); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		// Every column of a foreign key is returned as an own row
+		if len(ids) == 0 || ids[len(ids)-1] != id {
+			ids = append(ids, id)
+			rtc = append(rtc, &ForeignKey{
+				ReferencedSchema: schema,
+				ReferencedTable:  refTable,
+				OnDelete:         onDelete,
+				OnUpdate:         onUpdate,
+			})
+		}
+		fk := rtc[len(rtc)-1]
+		fk.Columns = append(fk.Columns, from)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, to.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read foreign_key_list: %s", err)
+	}
+
+	// The referenced columns are NULL when the primary key of the parent is referenced
+	for _, fk := range rtc {
+		if fk.ReferencedColumns[0] != "" {
+			continue
+		}
+
+		pk, err := s.getPrimaryKey(schema, fk.ReferencedTable)
+		if err != nil {
+			return nil, err
+		}
+		if pk != nil {
+			for i := range fk.ReferencedColumns {
+				if i < len(pk.Columns) {
+					fk.ReferencedColumns[i] = pk.Columns[i]
+				}
+			}
+		}
+	}
+
+	if len(rtc) == 0 {
+		return nil, nil
+	}
+	return rtc, nil
+}
+
+// getPrimaryKey returns the primary key of a table or nil if the table has
+// no primary key. Because SQLite doesn't store the name of the constraint,
+// the name is always empty
+func (s *Sqlite) getPrimaryKey(schema, name string) (*PrimaryKey, error) {
+	rows, err := s.db.Query(`SELECT name FROM pragma_table_info(?, ?) WHERE pk > 0 ORDER BY pk`, name, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query primary key of %s.%s: %s", schema, name, err)
+	}
+	defer rows.Close()
+
+	var rtc *PrimaryKey
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		if rtc == nil {
+			rtc = &PrimaryKey{}
+		}
+		rtc.Columns = append(rtc.Columns, column)
+	}
+
+	return rtc, rows.Err()
+}
+
+func (s *Sqlite) ListTables(schema string) ([]*Table, error) {
+	sql := fmt.Sprintf(`
+		SELECT
+			t.name,
+			t.type
+		FROM %s.sqlite_master t
+		WHERE t.type IN ('table', 'view') AND t.name NOT LIKE 'sqlite_%%'
+		ORDER BY t.name ASC
+	`, quoteSqliteIdentifier(schema))
+	rows, err := s.db.Query(sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sqlite_master: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Table{}
+	for rows.Next() {
+		var objectType string
+		t := &Table{Schema: schema, Kind: TableKindTable}
+		if err := rows.Scan(&t.Name, &objectType); err != nil {
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
+		}
+		if objectType == "view" {
+			t.Kind = TableKindView
+		}
+		rtc = append(rtc, t)
+	}
+	if err := rows.Err(); err != nil {
+		return rtc, fmt.Errorf("failed to read rows: %s", err)
+	}
+
+	return rtc, nil
+}
+
+func (s *Sqlite) GetTables(schema string) ([]*Table, error) {
+	sql := fmt.Sprintf(`
+		SELECT
+			t.name
+		FROM %s.sqlite_master t
+		WHERE t.type = 'table' AND t.name NOT LIKE 'sqlite_%%'
+		ORDER BY t.name ASC
+	`, quoteSqliteIdentifier(schema))
+	rows, err := s.db.Query(sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sqlite_master: %s", err)
+	}
+	defer rows.Close()
+
+	rtc := []*Table{}
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return rtc, fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		t, err := s.GetTable(schema, tableName)
+		if err != nil {
+			return rtc, fmt.Errorf("failed to get data for %s.%s: %s", schema, tableName, err)
+		}
+		rtc = append(rtc, t)
+	}
+
+	return rtc, nil
+}
+
+// GetAffinity returns the type affinity of a declared column type
+// by applying the rules of SQLite in their order
+func (s *Sqlite) GetAffinity(internalType string) SqliteAffinity {
+	internalType = strings.ToUpper(internalType)
+
+	switch {
+	case strings.Contains(internalType, "INT"):
+		return SqliteAffinityInteger
+	case strings.Contains(internalType, "CHAR"), strings.Contains(internalType, "CLOB"), strings.Contains(internalType, "TEXT"):
+		return SqliteAffinityText
+	case strings.Contains(internalType, "BLOB"), internalType == "":
+		return SqliteAffinityBlob
+	case strings.Contains(internalType, "REAL"), strings.Contains(internalType, "FLOA"), strings.Contains(internalType, "DOUB"):
+		return SqliteAffinityReal
+	default:
+		return SqliteAffinityNumeric
+	}
+}
+
+func (s *Sqlite) GetDataType(internalType string) DataType {
+
+	// Dates are stored with a numeric or text affinity. We use the declared name
+	// to detect them
+	typeName := strings.ToUpper(internalType)
+	if lastBracket := strings.Index(typeName, "("); lastBracket != -1 {
+		typeName = typeName[:lastBracket]
+	}
+	switch strings.TrimSpace(typeName) {
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return DateType
+	}
+
+	switch s.GetAffinity(internalType) {
+	case SqliteAffinityText:
+		return StringType
+	case SqliteAffinityInteger:
+		return IntType
+	case SqliteAffinityReal, SqliteAffinityNumeric:
+		return DoubleType
+	default:
+		return UnknownType
+	}
+}
+
+// quoteSqliteIdentifier quotes the identifier to be used within a statement
+func quoteSqliteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// getViewDefinition returns the query of a "CREATE VIEW" statement
+func (s *Sqlite) getViewDefinition(createStatement string) string {
+	p, err := newSqlParser(createStatement, sqliteDialect)
+	if err != nil {
+		return ""
+	}
+
+	// The query starts after the first "AS" outside of the column list
+	for !p.eof() {
+		if p.isSymbol("(") {
+			p.skipBrackets()
+		} else if p.acceptKeywords("AS") {
+			return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(p.input[p.peek().start:]), ";"))
+		} else {
+			p.next()
+		}
+	}
+
+	return ""
+}
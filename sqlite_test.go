@@ -0,0 +1,480 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	_ "modernc.org/sqlite"
+)
+
+// TestGetTableSimpleSqlite tests the construction of a Table struct
+// with all supported data types and fields
+func TestGetTableSimpleSqlite(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	// Create test table
+	tableName, err := createTable(db,
+		`
+		id 		INTEGER PRIMARY KEY NOT NULL,
+		txt 	VARCHAR(100) DEFAULT 'Ich bins, der Tim!',
+		amount	DECIMAL(10,2),
+		data	BLOB,
+		dte		DATETIME NOT NULL
+		`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	// Get columns
+	table, err := sDb.GetTable("main", tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:       tableName,
+		Schema:     "main",
+		Kind:       TableKindTable,
+		PrimaryKey: &PrimaryKey{Columns: []string{"id"}},
+	}
+	columns := []*SqliteColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "INTEGER",
+			},
+			AutoIncrement: true,
+			Affinity:      SqliteAffinityInteger,
+		},
+		{
+			Column: &Column{
+				Name:         "txt",
+				PrimaryKey:   false,
+				CanBeNull:    true,
+				Type:         StringType,
+				InternalType: "VARCHAR(100)",
+				DefaultValue: sql.NullString{
+					Valid:  true,
+					String: "Ich bins, der Tim!",
+				},
+			},
+			DataTypeLenght: 100,
+			Affinity:       SqliteAffinityText,
+		},
+		{
+			Column: &Column{
+				Name:         "amount",
+				CanBeNull:    true,
+				Type:         DoubleType,
+				InternalType: "DECIMAL(10,2)",
+			},
+			DataTypeLenght: 10,
+			Affinity:       SqliteAffinityNumeric,
+		},
+		{
+			Column: &Column{
+				Name:         "data",
+				CanBeNull:    true,
+				Type:         UnknownType,
+				InternalType: "BLOB",
+			},
+			Affinity: SqliteAffinityBlob,
+		},
+		{
+			Column: &Column{
+				Name:         "dte",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         DateType,
+				InternalType: "DATETIME",
+			},
+			Affinity: SqliteAffinityNumeric,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch of columns (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableSqliteFK tests the construction of a Table struct
+// that references another table
+func TestGetTableSqliteFK(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	// Create table we reference to
+	referenceTableName, err := createTable(db, `
+		id_to_ref   INTEGER PRIMARY KEY NOT NULL,
+		rand        VARCHAR(10) NOT NULL`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, referenceTableName)
+
+	// Create table with reference
+	tableName, err := createTable(db, `
+		id 		 INTEGER PRIMARY KEY NOT NULL,
+		other_id INT NOT NULL,
+		parent	 INT REFERENCES `+referenceTableName+`,
+		CONSTRAINT fk_test_constraint_for_you FOREIGN KEY(other_id) REFERENCES `+referenceTableName+`(id_to_ref)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := sDb.GetTable("main", tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := &Table{
+		Name:       tableName,
+		Schema:     "main",
+		Kind:       TableKindTable,
+		PrimaryKey: &PrimaryKey{Columns: []string{"id"}},
+		ForeignKeys: []*ForeignKey{
+			{
+				Columns:           []string{"other_id"},
+				ReferencedSchema:  "main",
+				ReferencedTable:   referenceTableName,
+				ReferencedColumns: []string{"id_to_ref"},
+				OnDelete:          "NO ACTION",
+				OnUpdate:          "NO ACTION",
+			},
+			{
+				Columns:           []string{"parent"},
+				ReferencedSchema:  "main",
+				ReferencedTable:   referenceTableName,
+				ReferencedColumns: []string{"id_to_ref"},
+				OnDelete:          "NO ACTION",
+				OnUpdate:          "NO ACTION",
+			},
+		},
+	}
+	columns := []*SqliteColumn{
+		{
+			Column: &Column{
+				Name:         "id",
+				PrimaryKey:   true,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "INTEGER",
+			},
+			AutoIncrement: true,
+			Affinity:      SqliteAffinityInteger,
+		},
+		{
+			Column: &Column{
+				Name:         "other_id",
+				PrimaryKey:   false,
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "INT",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   referenceTableName,
+					Schema: "main",
+					Column: "id_to_ref",
+				},
+			},
+			Affinity: SqliteAffinityInteger,
+		},
+		{
+			Column: &Column{
+				Name:         "parent",
+				CanBeNull:    true,
+				Type:         IntType,
+				InternalType: "INT",
+				ForeignKey:   true,
+				ForeignKeyColumn: ForeignColumn{
+					Name:   referenceTableName,
+					Schema: "main",
+					Column: "id_to_ref",
+				},
+			},
+			Affinity: SqliteAffinityInteger,
+		},
+	}
+	for _, c := range columns {
+		c.Extras = c
+		expected.Columns = append(expected.Columns, c.Column)
+	}
+
+	// Compare struct
+	if diff := cmp.Diff(table, expected); diff != "" {
+		t.Errorf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableSqliteCompositeFK tests keys over multiple columns that
+// reference the primary key of another table
+func TestGetTableSqliteCompositeFK(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	referenceTableName, err := createTable(db, `
+		tenant  INT NOT NULL,
+		id      INT NOT NULL,
+		PRIMARY KEY (tenant, id)`,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, referenceTableName)
+
+	// The column order differs from the key order
+	tableName, err := createTable(db, `
+		other_id INT NOT NULL,
+		tenant   INT NOT NULL,
+		PRIMARY KEY (tenant, other_id),
+		FOREIGN KEY (tenant, other_id) REFERENCES `+referenceTableName+` ON DELETE CASCADE
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName)
+
+	table, err := sDb.GetTable("main", tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expectedPk := &PrimaryKey{Columns: []string{"tenant", "other_id"}}
+	if diff := cmp.Diff(table.PrimaryKey, expectedPk); diff != "" {
+		t.Errorf("Mismatch of primary key (-want +got):\n%s", diff)
+	}
+
+	expectedFks := []*ForeignKey{
+		{
+			Columns:           []string{"tenant", "other_id"},
+			ReferencedSchema:  "main",
+			ReferencedTable:   referenceTableName,
+			ReferencedColumns: []string{"tenant", "id"},
+			OnDelete:          "CASCADE",
+			OnUpdate:          "NO ACTION",
+		},
+	}
+	if diff := cmp.Diff(table.ForeignKeys, expectedFks); diff != "" {
+		t.Errorf("Mismatch of foreign keys (-want +got):\n%s", diff)
+	}
+
+	expectedRefs := map[string]string{"other_id": "id", "tenant": "tenant"}
+	for _, c := range table.Columns {
+		if !c.PrimaryKey || !c.ForeignKey || c.ForeignKeyColumn.Column != expectedRefs[c.Name] {
+			t.Errorf("Wrong key information of column %q: %+v", c.Name, c)
+		}
+	}
+}
+
+// TestGetTablesSqlite tests the selecting of multiple tables to a []Table array
+func TestGetTablesSqlite(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	// Create two simple tables
+	tableName1, err := createTable(db, `idTab1 INTEGER NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName1)
+
+	tableName2, err := createTable(db, `idTab2 INTEGER NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	defer dropTable(db, tableName2)
+
+	tables, err := sDb.GetTables("main")
+	if err != nil {
+		t.Fatalf("Failed to get tables: %s", err)
+	}
+
+	// The database is created for this test only
+	if len(tables) != 2 {
+		t.Fatalf("Expected 2 tables. Got %d", len(tables))
+	}
+
+	for _, tt := range tables {
+		expected := &Table{
+			Name:   tt.Name,
+			Schema: "main",
+			Kind:   TableKindTable,
+		}
+		column := &SqliteColumn{
+			Column: &Column{
+				Name:         "idTab1",
+				CanBeNull:    false,
+				Type:         IntType,
+				InternalType: "INTEGER",
+			},
+			Affinity: SqliteAffinityInteger,
+		}
+		if tt.Name == tableName2 {
+			column.Name = "idTab2"
+		}
+		column.Extras = column
+		expected.Columns = append(expected.Columns, column.Column)
+
+		// Compare struct
+		if diff := cmp.Diff(tt, expected); diff != "" {
+			t.Errorf("Mismatch of %s: (-want +got):\n%s", tt.Name, diff)
+		}
+	}
+}
+
+// TestGetTableSqliteNotFound tests that an error is returned for unknown tables
+func TestGetTableSqliteNotFound(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	if _, err := sDb.GetTable("main", "not_existing"); err == nil {
+		t.Errorf("Expected an error for a not existing table")
+	}
+}
+
+// TestGetTableSqliteIndexes tests the selecting of composite, partial
+// and expression based indexes
+func TestGetTableSqliteIndexes(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	tableName, err := createTable(db, `
+		a     TEXT NOT NULL,
+		b     INTEGER NOT NULL,
+		state TEXT,
+		PRIMARY KEY (a, b)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	for _, stmt := range []string{
+		"CREATE INDEX idx_state ON " + tableName + " (state, b DESC) WHERE state IS NOT NULL",
+		"CREATE UNIQUE INDEX idx_lower ON " + tableName + " (lower(a) COLLATE NOCASE)",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to create index: %s", err)
+		}
+	}
+
+	table, err := sDb.GetTable("main", tableName)
+	if err != nil {
+		t.Fatalf("Failed to get columns: %s", err)
+	}
+
+	expected := []*Index{
+		{Name: "idx_lower", Columns: []string{"lower(a)"}, Unique: true},
+		{Name: "idx_state", Columns: []string{"state", "b"}, Predicate: "state IS NOT NULL"},
+		{Name: "sqlite_autoindex_" + tableName + "_1", Columns: []string{"a", "b"}, Unique: true},
+	}
+	if diff := cmp.Diff(table.Indexes, expected); diff != "" {
+		t.Errorf("Mismatch of indexes (-want +got):\n%s", diff)
+	}
+}
+
+// TestGetTableSqliteView tests the kind and definition of a view
+func TestGetTableSqliteView(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db)
+
+	tableName, err := createTable(db, `id INTEGER NOT NULL, txt TEXT`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	if _, err := db.Exec("CREATE VIEW " + tableName + "_view (id, alias) AS SELECT id, txt AS alias FROM " + tableName); err != nil {
+		t.Fatalf("Failed to create view: %s", err)
+	}
+
+	table, err := sDb.GetTable("main", tableName)
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err)
+	}
+	if table.Kind != TableKindTable || table.ViewDefinition != "" {
+		t.Errorf("Expected a table without definition. Got %q: %q", table.Kind, table.ViewDefinition)
+	}
+
+	view, err := sDb.GetTable("main", tableName+"_view")
+	if err != nil {
+		t.Fatalf("Failed to get view: %s", err)
+	}
+	if expected := "SELECT id, txt AS alias FROM " + tableName; view.Kind != TableKindView || view.ViewDefinition != expected {
+		t.Errorf("Expected a view with the definition %q. Got %q: %q", expected, view.Kind, view.ViewDefinition)
+	}
+	if len(view.Columns) != 2 || view.Columns[1].Name != "alias" {
+		t.Errorf("Expected the columns of the view. Got %+v", view.Columns)
+	}
+}
+
+// TestListTablesSqlite tests the listing of tables without columns
+func TestListTablesSqlite(t *testing.T) {
+	db := ConnectToSqlite(t)
+	sDb := NewSqlite(db).(DbSystemListing)
+
+	tableName, err := createTable(db, `id INTEGER NOT NULL`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+	if _, err := db.Exec("CREATE VIEW " + tableName + "_view AS SELECT id FROM " + tableName); err != nil {
+		t.Fatalf("Failed to create view: %s", err)
+	}
+
+	tables, err := sDb.ListTables("main")
+	if err != nil {
+		t.Fatalf("Failed to list tables: %s", err)
+	}
+	expected := []*Table{
+		{Schema: "main", Name: tableName, Kind: TableKindTable},
+		{Schema: "main", Name: tableName + "_view", Kind: TableKindView},
+	}
+	if diff := cmp.Diff(tables, expected); diff != "" {
+		t.Errorf("Mismatch of tables (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetAffinitySqlite(t *testing.T) {
+	s := &Sqlite{}
+	for typ, expected := range map[string]SqliteAffinity{
+		"INT":              SqliteAffinityInteger,
+		"UNSIGNED BIG INT": SqliteAffinityInteger,
+		"NVARCHAR(100)":    SqliteAffinityText,
+		"CLOB":             SqliteAffinityText,
+		"BLOB":             SqliteAffinityBlob,
+		"":                 SqliteAffinityBlob,
+		"DOUBLE PRECISION": SqliteAffinityReal,
+		"FLOAT":            SqliteAffinityReal,
+		"DECIMAL(10,5)":    SqliteAffinityNumeric,
+		"BOOLEAN":          SqliteAffinityNumeric,
+	} {
+		if got := s.GetAffinity(typ); got != expected {
+			t.Errorf("Expected affinity %q for %q. Got %q", expected, typ, got)
+		}
+	}
+}
+
+// ConnectToSqlite opens a new database file that only exists for the
+// duration of the test
+func ConnectToSqlite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "ddl_test.db"))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to open DB connection: %s", err))
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
@@ -0,0 +1,477 @@
+package structt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/RPJoshL/go-ddl-parser"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+// checkField is a struct field that can be used inside a generated validation
+type checkField struct {
+
+	// Name of the field inside the struct
+	name string
+
+	// Go data type of the field
+	dataType string
+
+	// Column of the field
+	column *ddl.Column
+}
+
+// getCheckComment returns the comment lines listing all check constraints of the table
+func getCheckComment(tbl *ddl.Table) string {
+	if len(tbl.Checks) == 0 {
+		return ""
+	}
+
+	// The expressions are formatted as a code block so quotes are not
+	// replaced by gofmt
+	rtc := "//\n// Check constraints:\n//\n"
+	for _, check := range tbl.Checks {
+		expression := strings.Join(strings.Fields(check.Expression), " ")
+		if check.Name == "" {
+			rtc += fmt.Sprintf("//\t%s\n", expression)
+		} else {
+			rtc += fmt.Sprintf("//\t%s: %s\n", check.Name, expression)
+		}
+	}
+
+	return rtc
+}
+
+// getValidation returns a "Validate" method for the struct that checks all
+// check constraints which can be translated to Go code. Only simple comparisons
+// of non-nullable numeric and string fields are supported. All other checks
+// are skipped.
+// If no check can be translated, an empty string is returned
+func (c *constructor) getValidation(structName string, tbl *ddl.Table, fields map[string]*checkField) (code string, imports []string) {
+	body := ""
+	imps := map[string]bool{}
+	for _, check := range tbl.Checks {
+		expr, checkImports, err := translateCheck(check.Expression, fields)
+		if err != nil {
+			logger.Debug("Skipping check %q of table %s.%s: %s", check.Name, tbl.Schema, tbl.Name, err)
+			continue
+		}
+		for _, imp := range checkImports {
+			imps[imp] = true
+		}
+
+		message := fmt.Sprintf("check constraint %q is violated: %s", check.Name, strings.Join(strings.Fields(check.Expression), " "))
+		if check.Name == "" {
+			message = "check constraint is violated: " + strings.Join(strings.Fields(check.Expression), " ")
+		}
+		body += fmt.Sprintf("\tif !%s {\n\t\treturn errors.New(%q)\n\t}\n", expr, message)
+	}
+
+	if body == "" {
+		return "", nil
+	}
+
+	imports = []string{"errors"}
+	for imp := range imps {
+		imports = append(imports, imp)
+	}
+
+	return fmt.Sprintf(
+		"// Validate returns an error if a check constraint of the table %q is violated\nfunc (s *%s) Validate() error {\n%s\treturn nil\n}\n",
+		tbl.Name, structName, body,
+	), imports
+}
+
+// patchValidation replaces an existing "Validate" method of the struct inside the
+// file content or appends it to the end
+func (c *constructor) patchValidation(existingContent string, structName string, code string) string {
+	reg := regexp.MustCompile(fmt.Sprintf(
+		`(?s)// Validate returns [^\n]*\nfunc \(s \*%s\) Validate\(\) error {\n.*?\n}\n`,
+		structName,
+	))
+
+	if loc := reg.FindStringIndex(existingContent); loc != nil {
+		return existingContent[:loc[0]] + code + existingContent[loc[1]:]
+	}
+	if code == "" {
+		return existingContent
+	}
+	return existingContent + "\n" + code
+}
+
+// checkOperators maps the supported comparison operators of SQL to Go
+var checkOperators = map[string]string{"=": "==", "<>": "!=", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">="}
+
+// checkToken is a single token of a check expression
+type checkToken struct {
+	value string
+
+	// One of "ident", "number", "string" or "symbol"
+	kind string
+}
+
+// checkTranslator translates a check expression to a Go expression
+type checkTranslator struct {
+	tokens  []checkToken
+	pos     int
+	fields  map[string]*checkField
+	imports map[string]bool
+}
+
+// checkOperand is a translated value of a check expression
+type checkOperand struct {
+	code string
+
+	// Go data type of the value. Literals have the type "untyped int",
+	// "untyped float" or "untyped string"
+	dataType string
+
+	// Column of the value if it's a field
+	column *ddl.Column
+}
+
+// translateCheck translates a check expression like "min <= max" to a Go expression
+// like "(s.Min <= s.Max)". The fields are identified by the lowercase column name
+func translateCheck(expression string, fields map[string]*checkField) (string, []string, error) {
+	tokens, err := tokenizeCheck(expression)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t := &checkTranslator{tokens: tokens, fields: fields, imports: map[string]bool{}}
+	rtc, err := t.or()
+	if err != nil {
+		return "", nil, err
+	}
+	if t.pos != len(t.tokens) {
+		return "", nil, fmt.Errorf("unexpected token %q", t.tokens[t.pos].value)
+	}
+
+	imports := []string{}
+	for imp := range t.imports {
+		imports = append(imports, imp)
+	}
+
+	return rtc, imports, nil
+}
+
+// tokenizeCheck splits a check expression into its tokens
+func tokenizeCheck(expression string) ([]checkToken, error) {
+	rtc := []checkToken{}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			value := ""
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string")
+				}
+				if runes[i] == '\'' {
+					// Escaped quote
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value += "'"
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				value += string(runes[i])
+			}
+			rtc = append(rtc, checkToken{value, "string"})
+		case r == '`' || r == '"':
+			end := strings.IndexRune(string(runes[i+1:]), r)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated identifier")
+			}
+			value := string(runes[i+1:])[:end]
+			rtc = append(rtc, checkToken{value, "ident"})
+			i += len([]rune(value)) + 2
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			rtc = append(rtc, checkToken{string(runes[start:i]), "number"})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			rtc = append(rtc, checkToken{string(runes[start:i]), "ident"})
+		default:
+			symbol := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "<=", ">=", "<>", "!=":
+					symbol = two
+				}
+			}
+			if !strings.Contains("()<>=,-", string(r)) && len(symbol) == 1 {
+				return nil, fmt.Errorf("unsupported symbol %q", symbol)
+			}
+			rtc = append(rtc, checkToken{symbol, "symbol"})
+			i += len(symbol)
+		}
+	}
+
+	return rtc, nil
+}
+
+// peek returns the next token without consuming it
+func (t *checkTranslator) peek() checkToken {
+	if t.pos >= len(t.tokens) {
+		return checkToken{}
+	}
+	return t.tokens[t.pos]
+}
+
+// acceptKeyword consumes the next token if it's the keyword
+func (t *checkTranslator) acceptKeyword(keyword string) bool {
+	if tok := t.peek(); tok.kind == "ident" && strings.EqualFold(tok.value, keyword) {
+		t.pos++
+		return true
+	}
+	return false
+}
+
+// acceptSymbol consumes the next token if it's the symbol
+func (t *checkTranslator) acceptSymbol(symbol string) bool {
+	if tok := t.peek(); tok.kind == "symbol" && tok.value == symbol {
+		t.pos++
+		return true
+	}
+	return false
+}
+
+func (t *checkTranslator) or() (string, error) {
+	rtc, err := t.and()
+	for err == nil && t.acceptKeyword("OR") {
+		var right string
+		right, err = t.and()
+		rtc = "(" + rtc + " || " + right + ")"
+	}
+	return rtc, err
+}
+
+func (t *checkTranslator) and() (string, error) {
+	rtc, err := t.not()
+	for err == nil && t.acceptKeyword("AND") {
+		var right string
+		right, err = t.not()
+		rtc = "(" + rtc + " && " + right + ")"
+	}
+	return rtc, err
+}
+
+func (t *checkTranslator) not() (string, error) {
+	if t.acceptKeyword("NOT") {
+		rtc, err := t.not()
+		return "!(" + rtc + ")", err
+	}
+	return t.predicate()
+}
+
+// predicate translates a comparison, "BETWEEN" or "IN" expression or
+// an expression inside brackets
+func (t *checkTranslator) predicate() (string, error) {
+	if t.acceptSymbol("(") {
+		rtc, err := t.or()
+		if err != nil {
+			return "", err
+		}
+		if !t.acceptSymbol(")") {
+			return "", fmt.Errorf("expected a closing bracket")
+		}
+		return rtc, nil
+	}
+
+	left, err := t.operand()
+	if err != nil {
+		return "", err
+	}
+
+	negate := t.acceptKeyword("NOT")
+	rtc := ""
+	switch {
+	case t.acceptKeyword("BETWEEN"):
+		lower, err := t.operand()
+		if err != nil {
+			return "", err
+		}
+		if !t.acceptKeyword("AND") {
+			return "", fmt.Errorf("expected AND for BETWEEN")
+		}
+		upper, err := t.operand()
+		if err != nil {
+			return "", err
+		}
+
+		from, err := t.compare(left, ">=", lower)
+		if err != nil {
+			return "", err
+		}
+		to, err := t.compare(left, "<=", upper)
+		if err != nil {
+			return "", err
+		}
+		rtc = "(" + from + " && " + to + ")"
+	case t.acceptKeyword("IN"):
+		if !t.acceptSymbol("(") {
+			return "", fmt.Errorf("expected an opening bracket for IN")
+		}
+		values := []string{}
+		for {
+			value, err := t.operand()
+			if err != nil {
+				return "", err
+			}
+			cmp, err := t.compare(left, "=", value)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, cmp)
+
+			if t.acceptSymbol(")") {
+				break
+			} else if !t.acceptSymbol(",") {
+				return "", fmt.Errorf("expected a comma for IN")
+			}
+		}
+		rtc = "(" + strings.Join(values, " || ") + ")"
+	default:
+		if negate {
+			return "", fmt.Errorf("unexpected NOT")
+		}
+		op := t.peek()
+		if _, exists := checkOperators[op.value]; op.kind != "symbol" || !exists {
+			return "", fmt.Errorf("unsupported operator %q", op.value)
+		}
+		t.pos++
+
+		right, err := t.operand()
+		if err != nil {
+			return "", err
+		}
+		return t.compare(left, op.value, right)
+	}
+
+	if negate {
+		return "!" + rtc, nil
+	}
+	return rtc, nil
+}
+
+// operand translates a column, literal or length function
+func (t *checkTranslator) operand() (*checkOperand, error) {
+	tok := t.peek()
+	t.pos++
+
+	switch tok.kind {
+	case "number":
+		if strings.Contains(tok.value, ".") {
+			return &checkOperand{code: tok.value, dataType: "untyped float"}, nil
+		}
+		return &checkOperand{code: tok.value, dataType: "untyped int"}, nil
+	case "string":
+		return &checkOperand{code: fmt.Sprintf("%q", tok.value), dataType: "untyped string"}, nil
+	case "symbol":
+		if tok.value == "-" && t.peek().kind == "number" {
+			rtc, err := t.operand()
+			if err == nil {
+				rtc.code = "-" + rtc.code
+			}
+			return rtc, err
+		}
+	case "ident":
+		// Length of a string
+		if t.acceptSymbol("(") {
+			function := strings.ToUpper(tok.value)
+			if function != "LENGTH" && function != "CHAR_LENGTH" {
+				return nil, fmt.Errorf("unsupported function %q", tok.value)
+			}
+
+			value, err := t.operand()
+			if err != nil {
+				return nil, err
+			}
+			if value.dataType != "string" || !t.acceptSymbol(")") {
+				return nil, fmt.Errorf("unsupported argument for %s", function)
+			}
+
+			// MariaDB counts the bytes for "LENGTH"
+			if _, isMariadb := value.column.Extras.(*ddl.MariadbColumn); isMariadb && function == "LENGTH" {
+				return &checkOperand{code: "len(" + value.code + ")", dataType: "int"}, nil
+			}
+			t.imports["unicode/utf8"] = true
+			return &checkOperand{code: "utf8.RuneCountInString(" + value.code + ")", dataType: "int"}, nil
+		}
+
+		field, exists := t.fields[strings.ToLower(tok.value)]
+		if !exists {
+			return nil, fmt.Errorf("unknown column %q", tok.value)
+		}
+		switch field.dataType {
+		case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return &checkOperand{code: "s." + field.name, dataType: field.dataType, column: field.column}, nil
+		}
+		return nil, fmt.Errorf("unsupported data type %q of column %q", field.dataType, tok.value)
+	}
+
+	return nil, fmt.Errorf("unsupported token %q", tok.value)
+}
+
+// compare returns a comparison of both operands. The data types of the operands
+// have to be compatible
+func (t *checkTranslator) compare(left *checkOperand, op string, right *checkOperand) (string, error) {
+	goOp := checkOperators[op]
+
+	isString := func(o *checkOperand) bool { return o.dataType == "string" || o.dataType == "untyped string" }
+	isFloat := func(o *checkOperand) bool {
+		return strings.HasPrefix(o.dataType, "float") || o.dataType == "untyped float"
+	}
+
+	switch {
+	case isString(left) != isString(right):
+		return "", fmt.Errorf("cannot compare %s with %s", left.dataType, right.dataType)
+	case isString(left):
+		// The order depends on the collation of the database
+		if goOp != "==" && goOp != "!=" {
+			return "", fmt.Errorf("unsupported string comparison %q", op)
+		}
+		if caseInsensitive(left.column) || caseInsensitive(right.column) {
+			t.imports["strings"] = true
+			if goOp == "!=" {
+				return fmt.Sprintf("!strings.EqualFold(%s, %s)", left.code, right.code), nil
+			}
+			return fmt.Sprintf("strings.EqualFold(%s, %s)", left.code, right.code), nil
+		}
+	case left.dataType == "untyped float" && !isFloat(right), right.dataType == "untyped float" && !isFloat(left):
+		return "", fmt.Errorf("cannot compare %s with %s", left.dataType, right.dataType)
+	case !strings.HasPrefix(left.dataType, "untyped") && !strings.HasPrefix(right.dataType, "untyped") && left.dataType != right.dataType:
+		return "", fmt.Errorf("cannot compare %s with %s", left.dataType, right.dataType)
+	}
+
+	return left.code + " " + goOp + " " + right.code, nil
+}
+
+// caseInsensitive returns weather string comparisons of the column ignore the case.
+// This is the default for MariaDB
+func caseInsensitive(column *ddl.Column) bool {
+	if column == nil {
+		return false
+	}
+	col, ok := column.Extras.(*ddl.MariadbColumn)
+	if !ok {
+		return false
+	}
+
+	return !strings.HasSuffix(col.Collation, "_bin") && !strings.HasSuffix(col.Collation, "_cs")
+}
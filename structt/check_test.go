@@ -0,0 +1,105 @@
+package structt
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/RPJoshL/go-ddl-parser"
+)
+
+func TestGetGoFileChecks(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{GenerateValidation: true},
+	}
+	table := &ddl.Table{
+		Name:   "product",
+		Schema: "ddl",
+		Columns: []*ddl.Column{
+			{Name: "price", Type: ddl.DoubleType},
+			{Name: "min", Type: ddl.IntType},
+			{Name: "max", Type: ddl.IntType},
+			{Name: "code", Type: ddl.StringType},
+			{Name: "note", Type: ddl.StringType, CanBeNull: true},
+		},
+		Checks: []*ddl.CheckConstraint{
+			{Name: "chk_code", Expression: "CHAR_LENGTH(`code`) BETWEEN 2 AND 5", Columns: []string{"code"}},
+			{Name: "chk_note", Expression: "note <> ''", Columns: []string{"note"}},
+			{Name: "chk_price", Expression: "price > 0.5 OR code IN ('FREE', 'it''s')", Columns: []string{"price", "code"}},
+			{Name: "chk_range", Expression: "`min` <= `max` AND NOT min < -1", Columns: []string{"min", "max"}},
+		},
+	}
+	tableConfig := &TableConfig{PackageName: "olaf"}
+
+	goFile := c.getGoFile("", table, tableConfig)
+	formatted, err := format.Source([]byte(goFile))
+	if err != nil {
+		t.Fatalf("Generated file is not valid: %s\n%s", err, goFile)
+	}
+
+	for _, expected := range []string{
+		"// Check constraints:\n//\n//\tchk_code: CHAR_LENGTH(`code`) BETWEEN 2 AND 5\n",
+		"//\tchk_note: note <> ''\n",
+		"func (s *Product) Validate() error {",
+		"if !(utf8.RuneCountInString(s.Code) >= 2 && utf8.RuneCountInString(s.Code) <= 5) {",
+		`if !(s.Price > 0.5 || (s.Code == "FREE" || s.Code == "it's")) {`,
+		"if !(s.Min <= s.Max && !(s.Min < -1)) {",
+		`return errors.New("check constraint \"chk_range\" is violated: ` + "`min` <= `max`" + ` AND NOT min < -1")`,
+		`"unicode/utf8"`,
+	} {
+		if !strings.Contains(string(formatted), expected) {
+			t.Errorf("Expected %q in generated file:\n%s", expected, formatted)
+		}
+	}
+
+	// Nullable columns are not supported
+	if strings.Contains(goFile, "s.Note") {
+		t.Errorf("Expected the check of a nullable column to be skipped:\n%s", formatted)
+	}
+
+	// Patching the file should replace the existing method
+	patched := c.getGoFile(goFile, table, tableConfig)
+	if count := strings.Count(patched, "Validate() error"); count != 1 {
+		t.Errorf("Expected the validation exactly once. Got %d times:\n%s", count, patched)
+	}
+}
+
+func TestTranslateCheck(t *testing.T) {
+	mariadbColumn := &ddl.MariadbColumn{Column: &ddl.Column{Name: "txt", Type: ddl.StringType}, Collation: "utf8mb4_general_ci"}
+	mariadbColumn.Extras = mariadbColumn
+	fields := map[string]*checkField{
+		"amount": {name: "Amount", dataType: "int64"},
+		"rate":   {name: "Rate", dataType: "float64"},
+		"txt":    {name: "Txt", dataType: "string", column: mariadbColumn.Column},
+		"state":  {name: "State", dataType: "*ProductState"},
+	}
+
+	for expression, expected := range map[string]string{
+		"amount >= 10":               "s.Amount >= 10",
+		"rate BETWEEN 0 AND 1.5":     "(s.Rate >= 0 && s.Rate <= 1.5)",
+		"txt = 'a'":                  `strings.EqualFold(s.Txt, "a")`,
+		"txt NOT IN ('a', 'b')":      `!(strings.EqualFold(s.Txt, "a") || strings.EqualFold(s.Txt, "b"))`,
+		"LENGTH(txt) < 5":            "len(s.Txt) < 5",
+		"(amount > 0) OR (rate > 0)": "(s.Amount > 0 || s.Rate > 0)",
+	} {
+		if got, _, err := translateCheck(expression, fields); err != nil || got != expected {
+			t.Errorf("Expected %q for %q. Got %q (%v)", expected, expression, got, err)
+		}
+	}
+
+	// Unsupported checks
+	for _, expression := range []string{
+		"amount > 1.5",
+		"amount = txt",
+		"txt < 'b'",
+		"state = 'a'",
+		"unknown > 0",
+		"amount + 1 > 0",
+		"amount IS NOT NULL",
+		"UPPER(txt) = 'A'",
+	} {
+		if got, _, err := translateCheck(expression, fields); err == nil {
+			t.Errorf("Expected an error for %q. Got %q", expression, got)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package structt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/RPJoshL/go-ddl-parser"
+)
+
+// enumType is a named string type generated for a column with a fixed
+// list of allowed values
+type enumType struct {
+
+	// Name of the generated type
+	name string
+
+	// Data type to use for the struct field
+	dataType string
+
+	// Go code of the type with its constants and methods
+	code string
+
+	// Imports required by the code
+	imports []string
+}
+
+// getEnumValues returns the allowed values of a column and weather multiple
+// values can be combined like in a MariaDB "SET".
+// If the column has no fixed list of values, nil is returned
+func getEnumValues(column *ddl.Column) (values []string, isSet bool) {
+	switch col := column.Extras.(type) {
+	case *ddl.MariadbColumn:
+		return col.EnumValues, col.Type == ddl.SetType
+	case *ddl.OracleColumn:
+		return col.EnumValues, false
+	}
+
+	return nil, false
+}
+
+// getEnum returns a named type for the column with a constant for every value.
+// The type validates the values when reading or writing it to the database or json
+func (c *constructor) getEnum(name string, column *ddl.Column, values []string, isSet bool) *enumType {
+	rtc := &enumType{
+		name:     name,
+		dataType: name,
+		imports:  []string{"database/sql/driver", "encoding/json", "fmt"},
+	}
+
+	// Nil represents null
+	if column.CanBeNull && !c.config.NullConfig.Disable {
+		rtc.dataType = "*" + name
+	}
+
+	// Add type with constants
+	code := fmt.Sprintf("// %s contains the allowed values of the column %q\ntype %s string\n\nconst (\n", name, column.Name, name)
+	constants := make([]string, 0, len(values))
+	for _, v := range values {
+		constName := getEnumConstName(name, v, constants)
+		constants = append(constants, constName)
+		code += fmt.Sprintf("\t%s %s = %q\n", constName, name, v)
+	}
+	code += ")\n\n"
+
+	// Validation
+	if isSet {
+		rtc.imports = append(rtc.imports, "strings")
+		code += fmt.Sprintf(`// Valid returns weather all comma seperated values are allowed
+func (e %[1]s) Valid() bool {
+	if e == "" {
+		return true
+	}
+	for _, v := range strings.Split(string(e), ",") {
+		switch %[1]s(v) {
+		case %[2]s:
+		default:
+			return false
+		}
+	}
+	return true
+}
+`, name, strings.Join(constants, ", "))
+	} else {
+		code += fmt.Sprintf(`// Valid returns weather the value is one of the allowed values
+func (e %[1]s) Valid() bool {
+	switch e {
+	case %[2]s:
+		return true
+	}
+	return false
+}
+`, name, strings.Join(constants, ", "))
+	}
+
+	// Database and json conversion
+	code += fmt.Sprintf(`
+// Scan implements the [sql.Scanner] interface
+func (e *%[1]s) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*e = %[1]s(v)
+	case []byte:
+		*e = %[1]s(v)
+	default:
+		return fmt.Errorf("unsupported type %%T for %[1]s", src)
+	}
+	if !e.Valid() {
+		return fmt.Errorf("invalid value %%q for %[1]s", string(*e))
+	}
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface
+func (e %[1]s) Value() (driver.Value, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("invalid value %%q for %[1]s", string(e))
+	}
+	return string(e), nil
+}
+
+// MarshalJSON implements the [json.Marshaler] interface
+func (e %[1]s) MarshalJSON() ([]byte, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("invalid value %%q for %[1]s", string(e))
+	}
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface
+func (e *%[1]s) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if !%[1]s(s).Valid() {
+		return fmt.Errorf("invalid value %%q for %[1]s", s)
+	}
+	*e = %[1]s(s)
+	return nil
+}
+`, name)
+
+	rtc.code = code
+	return rtc
+}
+
+// getEnumConstName returns the name of the constant for an enum value like
+// "StateActive". Any characters that are not allowed within an identifier are
+// used as a word seperator. Already used names get a numeric suffix
+func getEnumConstName(typeName string, value string, existing []string) string {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	name := GetFieldName(strings.Join(words, "_"))
+	if name == "" {
+		name = "Empty"
+	}
+	name = typeName + name
+
+	// Make the name unique
+	rtc := name
+	for i := 2; containsString(existing, rtc); i++ {
+		rtc = fmt.Sprintf("%s%d", name, i)
+	}
+
+	return rtc
+}
+
+// patchEnum replaces an existing type of the enum inside the file content
+// or appends it to the end
+func (c *constructor) patchEnum(existingContent string, enum *enumType) string {
+	reg := regexp.MustCompile(fmt.Sprintf(
+		`(?s)// %[1]s [^\n]*\ntype %[1]s string\n.*?\nfunc \(e \*%[1]s\) UnmarshalJSON\(.*?\n}\n`,
+		enum.name,
+	))
+
+	if loc := reg.FindStringIndex(existingContent); loc != nil {
+		return existingContent[:loc[0]] + enum.code + existingContent[loc[1]:]
+	}
+	return existingContent + "\n" + enum.code
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package structt
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/RPJoshL/go-ddl-parser"
+)
+
+// newEnumColumn returns a MariaDB column with the allowed values
+func newEnumColumn(name string, typ ddl.DataType, canBeNull bool, values ...string) *ddl.Column {
+	col := &ddl.MariadbColumn{Column: &ddl.Column{Name: name, Type: typ, CanBeNull: canBeNull}, EnumValues: values}
+	col.Extras = col
+	return col.Column
+}
+
+func TestGetGoFileEnum(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{},
+	}
+	table := &ddl.Table{
+		Name:   "workout",
+		Schema: "ddl",
+		Columns: []*ddl.Column{
+			newEnumColumn("state", ddl.EnumType, false, "active", "in-progress", "DONE"),
+			newEnumColumn("tags", ddl.SetType, true, "a", "b"),
+			{Name: "name", Type: ddl.StringType},
+		},
+	}
+	tableConfig := &TableConfig{PackageName: "olaf"}
+
+	goFile := c.getGoFile("", table, tableConfig)
+	formatted, err := format.Source([]byte(goFile))
+	if err != nil {
+		t.Fatalf("Generated file is not valid: %s\n%s", err, goFile)
+	}
+
+	for _, expected := range []string{
+		"State WorkoutState `",
+		"Tags  *WorkoutTags `",
+		"Name  string `",
+		"type WorkoutState string",
+		`WorkoutStateInProgress WorkoutState = "in-progress"`,
+		`WorkoutStateDone       WorkoutState = "DONE"`,
+		"case WorkoutStateActive, WorkoutStateInProgress, WorkoutStateDone:",
+		"func (e *WorkoutTags) Scan(src any) error {",
+		`"database/sql/driver"`,
+		`"strings"`,
+	} {
+		// Ignore the alignment of go fmt
+		if !strings.Contains(strings.Join(strings.Fields(string(formatted)), " "), strings.Join(strings.Fields(expected), " ")) {
+			t.Errorf("Expected %q in generated file:\n%s", expected, formatted)
+		}
+	}
+
+	// Patching the file should replace the existing types
+	patched := c.getGoFile(goFile, table, tableConfig)
+	if count := strings.Count(patched, "type WorkoutState string"); count != 1 {
+		t.Errorf("Expected the enum type exactly once. Got %d times:\n%s", count, patched)
+	}
+	if count := strings.Count(patched, "func (e *WorkoutTags) UnmarshalJSON"); count != 1 {
+		t.Errorf("Expected the set type exactly once. Got %d times:\n%s", count, patched)
+	}
+}
+
+func TestGetEnumConstName(t *testing.T) {
+	existing := []string{}
+	for _, tt := range []struct {
+		value    string
+		expected string
+	}{
+		{"active", "StateActive"},
+		{"IN_PROGRESS", "StateInProgress"},
+		{"in progress", "StateInProgress2"},
+		{"", "StateEmpty"},
+		{"1", "State1"},
+	} {
+		got := getEnumConstName("State", tt.value, existing)
+		if got != tt.expected {
+			t.Errorf("Expected %q for %q. Got %q", tt.expected, tt.value, got)
+		}
+		existing = append(existing, got)
+	}
+}
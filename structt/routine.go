@@ -0,0 +1,305 @@
+package structt
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/RPJoshL/go-ddl-parser"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+// Supported database systems to call routines
+const (
+	RoutineMariadb = "mariadb"
+	RoutineOracle  = "oracle"
+)
+
+// RoutineConfig configures the generation of Go functions that call stored
+// procedures and functions
+type RoutineConfig struct {
+
+	// Absolute or relative path to the ".go" file to write all functions to.
+	// Defaulting to "routines.go" within the "GenericOutputPath"
+	Path string `yaml:"path"`
+
+	// Name of the Go package used for the file. Defaulting to the package
+	// name of the struct configuration
+	PackageName string `yaml:"packageName"`
+
+	// Database system of the routines. Either "mariadb" or "oracle"
+	DbSystem string `yaml:"dbSystem"`
+}
+
+// routineParam is a parameter of a generated routine function
+type routineParam struct {
+	*ddl.Parameter
+
+	// Name of the Go variable
+	name string
+
+	// Go data type of the variable
+	dataType string
+}
+
+// CreateRoutines creates a single ".go" file with a function for every routine that
+// calls it via "database/sql". Any existing file is overwritten.
+// Routines with parameters of an unknown data type like cursors or records are skipped
+func CreateRoutines(conf *StructConfig, routines []*ddl.Routine) error {
+	c := &constructor{
+		config: conf,
+	}
+
+	path := conf.RoutineConfig.Path
+	if path == "" {
+		path = conf.GenericOutputPath + "routines.go"
+	}
+
+	content, err := c.getRoutineFile(routines)
+	if err != nil {
+		return err
+	}
+
+	return writeGoFile(path, content)
+}
+
+// getRoutineFile returns the content of a go file with a function for every routine
+func (c *constructor) getRoutineFile(routines []*ddl.Routine) (string, error) {
+	conf := c.config.RoutineConfig
+	if conf.DbSystem != RoutineMariadb && conf.DbSystem != RoutineOracle {
+		return "", fmt.Errorf("unsupported database system %q for routines", conf.DbSystem)
+	}
+	packageName := conf.PackageName
+	if packageName == "" {
+		packageName = c.config.PackgeName
+	}
+
+	// A procedure and a function can have the same name
+	kinds := map[string]map[ddl.RoutineKind]bool{}
+	for _, r := range routines {
+		name := getRoutineName(r)
+		if kinds[name] == nil {
+			kinds[name] = map[ddl.RoutineKind]bool{}
+		}
+		kinds[name][r.Kind] = true
+	}
+
+	imports := map[string]bool{"context": true, "database/sql": true}
+	functions := ""
+	for _, r := range routines {
+		function, imps, err := c.getRoutineFunction(r, len(kinds[getRoutineName(r)]) > 1)
+		if err != nil {
+			logger.Debug("Skipping routine %s: %s", r.FullName(), err)
+			continue
+		}
+
+		functions += "\n" + function
+		for _, imp := range imps {
+			imports[imp] = true
+		}
+	}
+
+	// Add package header
+	importList := make([]string, 0, len(imports))
+	for imp := range imports {
+		importList = append(importList, imp)
+	}
+	sort.Strings(importList)
+	rtc := fmt.Sprintf("package %s\n\nimport (\n", packageName)
+	for _, imp := range importList {
+		rtc += fmt.Sprintf("\t%q\n", imp)
+	}
+	rtc += ")\n\n"
+
+	rtc += `// RoutineExecutor executes the statements to call a routine. It's implemented
+// by *sql.DB, *sql.Tx and *sql.Conn
+type RoutineExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+`
+
+	return rtc + functions, nil
+}
+
+// getRoutineFunction returns a function that calls the routine and the
+// required imports. With "withKind" the kind of the routine is appended to the name
+func (c *constructor) getRoutineFunction(r *ddl.Routine, withKind bool) (string, []string, error) {
+	used := map[string]bool{"ctx": true, "db": true, "err": true}
+	imports := []string{}
+
+	// Get the Go variables of all parameters
+	newParam := func(p *ddl.Parameter) (*routineParam, error) {
+		if p.Type == ddl.UnknownType {
+			return nil, fmt.Errorf("unsupported data type %q of parameter %q", p.InternalType, p.Name)
+		}
+
+		// Values returned by the database can always be null
+		dataType, imp := c.getDataType(&ddl.Column{Type: p.Type, CanBeNull: p.Direction != ddl.ParameterIn}, &TableConfig{}, nil)
+		if imp != "" {
+			imports = append(imports, imp)
+		}
+		if p.Direction == ddl.ParameterInOut {
+			dataType = "*" + dataType
+		}
+
+		return &routineParam{Parameter: p, name: getRoutineParamName(p.Name, used), dataType: dataType}, nil
+	}
+
+	params := []*routineParam{}
+	for _, p := range r.Parameters {
+		param, err := newParam(p)
+		if err != nil {
+			return "", nil, err
+		}
+		params = append(params, param)
+	}
+	var result *routineParam
+	if r.Return != nil {
+		var err error
+		if result, err = newParam(r.Return); err != nil {
+			return "", nil, err
+		}
+	}
+
+	// Build the signature
+	name := getRoutineName(r)
+	if withKind {
+		name += GetFieldName(strings.ToLower(string(r.Kind)))
+	}
+	if r.Overload != 0 {
+		name += fmt.Sprint(r.Overload)
+	}
+
+	args := []string{"ctx context.Context", "db RoutineExecutor"}
+	returns := []string{}
+	if result != nil {
+		returns = append(returns, result.name+" "+result.dataType)
+	}
+	for _, p := range params {
+		if p.Direction == ddl.ParameterOut {
+			returns = append(returns, p.name+" "+p.dataType)
+		} else {
+			args = append(args, p.name+" "+p.dataType)
+		}
+	}
+	returns = append(returns, "err error")
+
+	rtc := fmt.Sprintf("// %s calls the %s %q\n", name, strings.ToLower(string(r.Kind)), r.FullName())
+	if c.config.RoutineConfig.DbSystem == RoutineMariadb && len(returns) > 1 && r.Kind == ddl.RoutineProcedure {
+		rtc += "// The out parameters are read from session variables. Use a single\n// connection like *sql.Conn or *sql.Tx for the executor\n"
+	}
+	rtc += fmt.Sprintf("func %s(%s) (%s) {\n", name, strings.Join(args, ", "), strings.Join(returns, ", "))
+
+	if c.config.RoutineConfig.DbSystem == RoutineOracle {
+		rtc += getOracleRoutineCall(r, params, result)
+	} else {
+		rtc += getMariadbRoutineCall(r, params, result)
+	}
+	rtc += "\treturn\n}\n"
+
+	return rtc, imports, nil
+}
+
+// getRoutineName returns the name of the Go function without the kind and overload
+func getRoutineName(r *ddl.Routine) string {
+	if r.Package != "" {
+		return GetFieldName(r.Package + "_" + r.Name)
+	}
+	return GetFieldName(r.Name)
+}
+
+// getOracleRoutineCall returns the statements to call the routine within a PL/SQL
+// block. Out parameters are bound with "sql.Out"
+func getOracleRoutineCall(r *ddl.Routine, params []*routineParam, result *routineParam) string {
+	binds := []string{}
+	values := []string{}
+	if result != nil {
+		values = append(values, "sql.Out{Dest: &"+result.name+"}")
+	}
+	for _, p := range params {
+		switch p.Direction {
+		case ddl.ParameterOut:
+			values = append(values, "sql.Out{Dest: &"+p.name+"}")
+		case ddl.ParameterInOut:
+			values = append(values, "sql.Out{Dest: "+p.name+", In: true}")
+		default:
+			values = append(values, p.name)
+		}
+		binds = append(binds, fmt.Sprintf(":%d", len(values)))
+	}
+
+	call := fmt.Sprintf(`"%s"."%s"`, r.Schema, r.Name)
+	if r.Package != "" {
+		call = fmt.Sprintf(`"%s"."%s"."%s"`, r.Schema, r.Package, r.Name)
+	}
+	call += "(" + strings.Join(binds, ", ") + ")"
+	if result != nil {
+		call = ":1 := " + call
+	}
+
+	return fmt.Sprintf("\t_, err = db.ExecContext(ctx, %q, %s)\n", "BEGIN "+call+"; END;", strings.Join(values, ", "))
+}
+
+// getMariadbRoutineCall returns the statements to call the routine. Functions are
+// called with a "SELECT". The out parameters of procedures are passed with session
+// variables
+func getMariadbRoutineCall(r *ddl.Routine, params []*routineParam, result *routineParam) string {
+	call := fmt.Sprintf("`%s`.`%s`", r.Schema, r.Name)
+	binds := []string{}
+	values := []string{}
+	for _, p := range params {
+		if p.Direction == ddl.ParameterIn {
+			binds = append(binds, "?")
+			values = append(values, ", "+p.name)
+		} else {
+			binds = append(binds, "@_"+p.name)
+		}
+	}
+	call += "(" + strings.Join(binds, ", ") + ")"
+
+	if result != nil {
+		return fmt.Sprintf("\terr = db.QueryRowContext(ctx, %q%s).Scan(&%s)\n", "SELECT "+call, strings.Join(values, ""), result.name)
+	}
+
+	rtc := ""
+	variables := []string{}
+	targets := []string{}
+	for _, p := range params {
+		switch p.Direction {
+		case ddl.ParameterInOut:
+			rtc += fmt.Sprintf("\tif _, err = db.ExecContext(ctx, %q, %s); err != nil {\n\t\treturn\n\t}\n", "SET @_"+p.name+" = ?", p.name)
+			variables = append(variables, "@_"+p.name)
+			targets = append(targets, p.name)
+		case ddl.ParameterOut:
+			variables = append(variables, "@_"+p.name)
+			targets = append(targets, "&"+p.name)
+		}
+	}
+
+	if len(variables) == 0 {
+		return rtc + fmt.Sprintf("\t_, err = db.ExecContext(ctx, %q%s)\n", "CALL "+call, strings.Join(values, ""))
+	}
+	rtc += fmt.Sprintf("\tif _, err = db.ExecContext(ctx, %q%s); err != nil {\n\t\treturn\n\t}\n", "CALL "+call, strings.Join(values, ""))
+	rtc += fmt.Sprintf("\terr = db.QueryRowContext(ctx, %q).Scan(%s)\n", "SELECT "+strings.Join(variables, ", "), strings.Join(targets, ", "))
+
+	return rtc
+}
+
+// getRoutineParamName returns a unique Go variable name for the parameter like "userId".
+// The return value of a function is named "result"
+func getRoutineParamName(name string, used map[string]bool) string {
+	rtc := GetJsonName(name)
+	if name == "" {
+		rtc = "result"
+	}
+
+	for token.IsKeyword(rtc) || used[rtc] {
+		rtc += "_"
+	}
+	used[rtc] = true
+
+	return rtc
+}
@@ -0,0 +1,142 @@
+package structt
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/RPJoshL/go-ddl-parser"
+)
+
+func getTestRoutines() []*ddl.Routine {
+	return []*ddl.Routine{
+		{
+			Schema: "ddl", Name: "get_price", Kind: ddl.RoutineFunction,
+			Parameters: []*ddl.Parameter{{Name: "product_id", Direction: ddl.ParameterIn, Type: ddl.IntType}},
+			Return:     &ddl.Parameter{Direction: ddl.ParameterOut, Type: ddl.DecimalType},
+		},
+		{
+			Schema: "ddl", Name: "update_user", Kind: ddl.RoutineProcedure,
+			Parameters: []*ddl.Parameter{
+				{Name: "id", Direction: ddl.ParameterIn, Type: ddl.IntType},
+				{Name: "name", Direction: ddl.ParameterInOut, Type: ddl.StringType},
+				{Name: "type", Direction: ddl.ParameterOut, Type: ddl.IntType},
+			},
+		},
+		{
+			Schema: "ddl", Name: "cleanup", Kind: ddl.RoutineProcedure,
+		},
+		{
+			Schema: "ddl", Package: "pkg", Name: "open", Kind: ddl.RoutineFunction, Overload: 1,
+			Return: &ddl.Parameter{Direction: ddl.ParameterOut, Type: ddl.UnknownType, InternalType: "REF CURSOR"},
+		},
+	}
+}
+
+func TestGetRoutineFileMariadb(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{PackgeName: "olaf", RoutineConfig: RoutineConfig{DbSystem: RoutineMariadb}},
+	}
+
+	goFile, err := c.getRoutineFile(getTestRoutines())
+	if err != nil {
+		t.Fatalf("Failed to get routine file: %s", err)
+	}
+	formatted, err := format.Source([]byte(goFile))
+	if err != nil {
+		t.Fatalf("Generated file is not valid: %s\n%s", err, goFile)
+	}
+
+	for _, expected := range []string{
+		"package olaf\n",
+		`"github.com/RPJoshL/go-ddl-parser"`,
+		"type RoutineExecutor interface {",
+		"func GetPrice(ctx context.Context, db RoutineExecutor, productId int) (result ddl.NullDecimal, err error) {",
+		"err = db.QueryRowContext(ctx, \"SELECT `ddl`.`get_price`(?)\", productId).Scan(&result)",
+		"func UpdateUser(ctx context.Context, db RoutineExecutor, id int, name *sql.NullString) (type_ sql.NullInt64, err error) {",
+		`if _, err = db.ExecContext(ctx, "SET @_name = ?", name); err != nil {`,
+		"if _, err = db.ExecContext(ctx, \"CALL `ddl`.`update_user`(?, @_name, @_type_)\", id); err != nil {",
+		`err = db.QueryRowContext(ctx, "SELECT @_name, @_type_").Scan(name, &type_)`,
+		"func Cleanup(ctx context.Context, db RoutineExecutor) (err error) {",
+		"_, err = db.ExecContext(ctx, \"CALL `ddl`.`cleanup`()\")",
+	} {
+		if !strings.Contains(string(formatted), expected) {
+			t.Errorf("Expected %q in generated file:\n%s", expected, formatted)
+		}
+	}
+
+	// Routines with unknown types are skipped
+	if strings.Contains(goFile, "PkgOpen") {
+		t.Errorf("Expected the routine with a cursor to be skipped:\n%s", formatted)
+	}
+}
+
+func TestGetRoutineFileOracle(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{PackgeName: "olaf", RoutineConfig: RoutineConfig{DbSystem: RoutineOracle, PackageName: "routines"}},
+	}
+
+	routines := getTestRoutines()
+	routines[3].Return.Type = ddl.DateType
+	goFile, err := c.getRoutineFile(routines)
+	if err != nil {
+		t.Fatalf("Failed to get routine file: %s", err)
+	}
+	formatted, err := format.Source([]byte(goFile))
+	if err != nil {
+		t.Fatalf("Generated file is not valid: %s\n%s", err, goFile)
+	}
+
+	for _, expected := range []string{
+		"package routines\n",
+		`_, err = db.ExecContext(ctx, "BEGIN :1 := \"ddl\".\"get_price\"(:2); END;", sql.Out{Dest: &result}, productId)`,
+		`_, err = db.ExecContext(ctx, "BEGIN \"ddl\".\"update_user\"(:1, :2, :3); END;", id, sql.Out{Dest: name, In: true}, sql.Out{Dest: &type_})`,
+		`_, err = db.ExecContext(ctx, "BEGIN \"ddl\".\"cleanup\"(); END;")`,
+		"func PkgOpen1(ctx context.Context, db RoutineExecutor) (result sql.NullTime, err error) {",
+		`"BEGIN :1 := \"ddl\".\"pkg\".\"open\"(); END;"`,
+	} {
+		if !strings.Contains(string(formatted), expected) {
+			t.Errorf("Expected %q in generated file:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestGetRoutineFileSameName(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{PackgeName: "olaf", RoutineConfig: RoutineConfig{DbSystem: RoutineMariadb}},
+	}
+
+	routines := append(getTestRoutines(), &ddl.Routine{
+		Schema: "ddl", Name: "cleanup", Kind: ddl.RoutineFunction,
+		Return: &ddl.Parameter{Direction: ddl.ParameterOut, Type: ddl.IntType},
+	})
+	goFile, err := c.getRoutineFile(routines)
+	if err != nil {
+		t.Fatalf("Failed to get routine file: %s", err)
+	}
+	formatted, err := format.Source([]byte(goFile))
+	if err != nil {
+		t.Fatalf("Generated file is not valid: %s\n%s", err, goFile)
+	}
+
+	// The kind is only appended for routines with the same name
+	for _, expected := range []string{
+		"func CleanupProcedure(ctx context.Context, db RoutineExecutor) (err error) {",
+		"func CleanupFunction(ctx context.Context, db RoutineExecutor) (result sql.NullInt64, err error) {",
+		"func GetPrice(ctx context.Context",
+	} {
+		if !strings.Contains(string(formatted), expected) {
+			t.Errorf("Expected %q in generated file:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestGetRoutineFileUnsupported(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{PackgeName: "olaf"},
+	}
+
+	if _, err := c.getRoutineFile(getTestRoutines()); err == nil {
+		t.Errorf("Expected an error for a missing database system")
+	}
+}
@@ -41,6 +41,22 @@ type StructConfig struct {
 
 	// Configuration of how to handle nullable columns
 	NullConfig NullConfig
+
+	// Configuration of the type to use for exact decimal columns
+	DecimalConfig DecimalConfig `yaml:"decimalConfig"`
+
+	// Generate read-only structs for views. The metadata of these structs is marked
+	// as read only and the columns don't contain any information only required for
+	// inserts like the primary key, auto increment or default values
+	ReadOnlyViews bool `yaml:"readOnlyViews"`
+
+	// Generate a "Validate" method for every struct that checks the check constraints
+	// of the table. Only simple comparisons of non-nullable numeric and string columns
+	// are translated, all other checks are skipped
+	GenerateValidation bool `yaml:"generateValidation"`
+
+	// Configuration of the functions generated for stored routines
+	RoutineConfig RoutineConfig `yaml:"routineConfig"`
 }
 
 // TableConfig contains options for a specific table
@@ -88,6 +104,21 @@ type NullConfig struct {
 	Custom func(typ ddl.DataType, defaultName string) (typeName, imp string)
 }
 
+// DecimalConfig configures the type to use for exact decimal columns.
+// By default "ddl.Decimal" and "ddl.NullDecimal" of this package are used
+type DecimalConfig struct {
+
+	// Name of the type like "decimal.Decimal"
+	Type string `yaml:"type"`
+
+	// Name of the type for nullable columns like "decimal.NullDecimal".
+	// Defaulting to "NullConfig.Custom" or to a pointer of "Type" if only that is provided
+	NullType string `yaml:"nullType"`
+
+	// Name of the package to import the types from
+	Package string `yaml:"package"`
+}
+
 type constructor struct {
 	config *StructConfig
 	tables []*ddl.Table
@@ -131,25 +162,34 @@ func CreateStructs(conf *StructConfig, tables []*ddl.Table) error {
 
 		// Get new file content and writeto file
 		newContent := c.getGoFile(content, t, tblConfig)
-		f, err := os.Create(tblConfig.Path)
-		if err != nil {
-			return fmt.Errorf("failed to open file %q: %s", tblConfig.Path, err)
+		if err := writeGoFile(tblConfig.Path, newContent); err != nil {
+			return err
 		}
+	}
 
-		_, err = f.WriteString(newContent)
-		if err != nil {
-			f.Close()
-			return fmt.Errorf("failed to write file %q: %s", tblConfig.Path, err)
-		}
+	return nil
+}
+
+// writeGoFile overwrites the file with the content and lints it with "go fmt"
+func writeGoFile(path string, content string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %q: %s", path, err)
+	}
+
+	_, err = f.WriteString(content)
+	if err != nil {
 		f.Close()
+		return fmt.Errorf("failed to write file %q: %s", path, err)
+	}
+	f.Close()
 
-		// Lint go file
-		cmd := exec.Command("go", "fmt", tblConfig.Path)
-		if err := cmd.Run(); err != nil {
-			logger.Warning("Failed to run go fmt: %s", err)
-		}
-		cmd.Wait()
+	// Lint go file
+	cmd := exec.Command("go", "fmt", path)
+	if err := cmd.Run(); err != nil {
+		logger.Warning("Failed to run go fmt: %s", err)
 	}
+	cmd.Wait()
 
 	return nil
 }
@@ -242,11 +282,26 @@ func (c *constructor) getGoFile(existingContent string, tbl *ddl.Table, tblConfi
 
 	// Add struct type header
 	tableName := GetFieldName(tbl.Name) + tblConfig.Suffix
+	readOnly := c.config.ReadOnlyViews && tbl.Kind.IsView()
+	if tbl.Comment != "" {
+		for _, comment := range strings.Split(tbl.Comment, "\n") {
+			rtc += fmt.Sprintf("// %s\n", comment)
+		}
+	}
+	if checks := getCheckComment(tbl); checks != "" {
+		// Omit the empty separator line without a comment
+		if tbl.Comment == "" {
+			checks = strings.TrimPrefix(checks, "//\n")
+		}
+		rtc += checks
+	}
 	rtc += fmt.Sprintf("type %s struct {\n", tableName)
 	columns += fmt.Sprintf("// %s\nconst (\n", tableName)
 
 	// Add columns
 	imports := make(map[string]bool, 0)
+	enums := []*enumType{}
+	fields := make(map[string]*checkField, len(tbl.Columns))
 	for _, col := range tbl.Columns {
 
 		// Add comments
@@ -257,19 +312,37 @@ func (c *constructor) getGoFile(existingContent string, tbl *ddl.Table, tblConfi
 		}
 
 		// Initialize tags
-		tags := GetColumnTag(col)
+		tags := GetTableColumnTag(tbl, col)
+		if readOnly {
+			tags.IsPrimaryKey = false
+			tags.AutoIncrement = false
+			tags.HasDefaultValue = false
+		}
+
+		fieldName := GetFieldName(col.Name)
+		jsonName := GetJsonName(col.Name)
 
 		// Get data type to use
-		dataType, imp := c.getDataType(col, tblConfig, tags)
+		dataType, imp := "", ""
+		if values, isSet := getEnumValues(col); len(values) != 0 && c.findOneToOne(col, tblConfig, tags) == "" {
+			// Columns with a fixed list of values get an own type
+			enum := c.getEnum(tableName+fieldName, col, values, isSet)
+			enums = append(enums, enum)
+			dataType = enum.dataType
+			for _, imp := range enum.imports {
+				imports[imp] = true
+			}
+		} else {
+			dataType, imp = c.getDataType(col, tblConfig, tags)
+		}
 		if imp != "" {
 			if _, exists := imports[imp]; !exists {
 				imports[imp] = true
 			}
 		}
 
-		fieldName := GetFieldName(col.Name)
-		jsonName := GetJsonName(col.Name)
 		rtc += fmt.Sprintf("\t%s %s `json:\"%s\" %s:\"%s\"`\n", fieldName, dataType, jsonName, ColumnTagId, tags.ToTag())
+		fields[strings.ToLower(col.Name)] = &checkField{name: fieldName, dataType: dataType, column: col}
 
 		// We also add the full reference to the column inside the string value.
 		// It's needed to reference it without information of the table (which we can't get
@@ -296,8 +369,9 @@ func (c *constructor) getGoFile(existingContent string, tbl *ddl.Table, tblConfi
 
 	// Add metadata tag
 	metaData := &MetadataTag{
-		Schema: tbl.Schema,
-		Table:  tbl.Name,
+		Schema:   tbl.Schema,
+		Table:    tbl.Name,
+		ReadOnly: readOnly,
 	}
 	rtc += fmt.Sprintf("\t%s any `json:\"-\" %s:\"%s\"`\n", MetadataFieldName, MetadataTagId, metaData.ToTag())
 
@@ -305,6 +379,16 @@ func (c *constructor) getGoFile(existingContent string, tbl *ddl.Table, tblConfi
 	rtc += "}\n"
 	columns += ")\n"
 
+	// Add validation of the check constraints
+	validation := ""
+	if c.config.GenerateValidation {
+		var validationImports []string
+		validation, validationImports = c.getValidation(tableName, tbl, fields)
+		for _, imp := range validationImports {
+			imports[imp] = true
+		}
+	}
+
 	// Add package header if no file exists already
 	if existingContent == "" {
 		header := fmt.Sprintf("package %s\n\n", tblConfig.PackageName)
@@ -318,23 +402,48 @@ func (c *constructor) getGoFile(existingContent string, tbl *ddl.Table, tblConfi
 		}
 
 		rtc = header + importStr + "\n" + rtc + columns
+		for _, e := range enums {
+			rtc += "\n" + e.code
+		}
+		if validation != "" {
+			rtc += "\n" + validation
+		}
 	} else {
 		rtc = c.patchFile(existingContent, rtc+columns, tbl, tblConfig, imports)
+		for _, e := range enums {
+			rtc = c.patchEnum(rtc, e)
+		}
+		if c.config.GenerateValidation {
+			rtc = c.patchValidation(rtc, tableName, validation)
+		}
 	}
 
 	return rtc
 }
 
+// GoType returns the type of the struct field that is generated for the column
+// with the default configuration like "string" or "sql.NullInt64"
+func GoType(column *ddl.Column) string {
+	c := &constructor{config: &StructConfig{}}
+	name, _ := c.getDataType(column, &TableConfig{}, nil)
+	return name
+}
+
 // getDataType returns the data type to use for the column as a string expression
 // and the extra imports required for this data type.
 // The tags my be updated within this function
-func (c *constructor) getDataType(column *ddl.Column, tblConfig *TableConfig, _ *ColumnTag) (name string, imp string) {
+func (c *constructor) getDataType(column *ddl.Column, tblConfig *TableConfig, tags *ColumnTag) (name string, imp string) {
 
 	// Find 1:1 relationship
-	if oneToOne := c.findOneToOne(column, tblConfig); oneToOne != "" {
+	if oneToOne := c.findOneToOne(column, tblConfig, tags); oneToOne != "" {
 		return oneToOne, ""
 	}
 
+	// Decimals are not converted into a float to keep the precision
+	if column.Type == ddl.DecimalType {
+		return c.getDecimalType(column.CanBeNull && !c.config.NullConfig.Disable)
+	}
+
 	// Try to use sql null strings
 	if column.CanBeNull && !c.config.NullConfig.Disable {
 
@@ -351,16 +460,23 @@ func (c *constructor) getDataType(column *ddl.Column, tblConfig *TableConfig, _
 		// The default data type name
 		typeName := ""
 		switch column.Type {
-		case ddl.StringType:
+		case ddl.StringType, ddl.TextType, ddl.EnumType, ddl.SetType, ddl.UuidType, ddl.TimeType, ddl.IntervalType:
 			typeName = "String"
-		case ddl.IntType:
+		case ddl.IntType, ddl.YearType:
 			typeName = "Int64"
 		case ddl.DoubleType:
 			typeName = "Float64"
-		case ddl.DateType:
+		case ddl.DateType, ddl.TimestampTzType:
 			typeName = "Time"
+		case ddl.BoolType:
+			typeName = "Bool"
 		case ddl.GeoType:
 			return "ddl.Location", PackageName
+		case ddl.BinaryType, ddl.BitType:
+			// A nil slice represents null
+			return "[]byte", ""
+		case ddl.JsonType:
+			return "json.RawMessage", "encoding/json"
 		}
 
 		// Null types
@@ -371,14 +487,20 @@ func (c *constructor) getDataType(column *ddl.Column, tblConfig *TableConfig, _
 	}
 
 	switch column.Type {
-	case ddl.StringType:
+	case ddl.StringType, ddl.TextType, ddl.EnumType, ddl.SetType, ddl.UuidType, ddl.TimeType, ddl.IntervalType:
 		return "string", ""
-	case ddl.IntType:
+	case ddl.IntType, ddl.YearType:
 		return "int", ""
 	case ddl.DoubleType:
 		return "float64", ""
-	case ddl.DateType:
+	case ddl.DateType, ddl.TimestampTzType:
 		return "time.Time", "time"
+	case ddl.BoolType:
+		return "bool", ""
+	case ddl.BinaryType, ddl.BitType:
+		return "[]byte", ""
+	case ddl.JsonType:
+		return "json.RawMessage", "encoding/json"
 	case ddl.GeoType:
 		return "ddl.Location", PackageName
 	}
@@ -386,10 +508,39 @@ func (c *constructor) getDataType(column *ddl.Column, tblConfig *TableConfig, _
 	return "any", ""
 }
 
+// getDecimalType returns the type to use for exact decimal columns and the
+// required import
+func (c *constructor) getDecimalType(nullable bool) (name string, imp string) {
+	conf := c.config.DecimalConfig
+	nullConf := c.config.NullConfig
+
+	if !nullable {
+		if conf.Type == "" {
+			return "ddl.Decimal", PackageName
+		}
+		return conf.Type, conf.Package
+	}
+
+	switch {
+	case conf.NullType != "":
+		return conf.NullType, conf.Package
+	case nullConf.Custom != nil:
+		return nullConf.Custom(ddl.DecimalType, "Decimal")
+	case conf.Type != "":
+		// A nil pointer represents null
+		return "*" + conf.Type, conf.Package
+	}
+
+	// Use the types of this package. The package and prefix of the null config
+	// are not used because packages like "database/sql" don't provide a decimal type
+	return "ddl.NullDecimal", PackageName
+}
+
 // findOneToOne tries to find a 1:1 relationship by scanning the foreign keys of a column
 // and the specified table configuration.
+// For foreign keys with multiple columns, only the first column references the struct.
 // It returns an empty string if no relationship was found or it's disable in the config
-func (c *constructor) findOneToOne(column *ddl.Column, tblConfig *TableConfig) string {
+func (c *constructor) findOneToOne(column *ddl.Column, tblConfig *TableConfig, tags *ColumnTag) string {
 
 	// Check if we have a foreign key for this column.
 	// Otherwise we can't and don't reference another struct
@@ -397,14 +548,25 @@ func (c *constructor) findOneToOne(column *ddl.Column, tblConfig *TableConfig) s
 		return ""
 	}
 
+	// The struct is only referenced once by the first column of the foreign key
+	columns := []string{column.Name}
+	if tags != nil && tags.ForeignKeyColumns != "" {
+		columns = strings.Split(tags.ForeignKeyColumns, "+")
+		if columns[0] != column.Name {
+			return ""
+		}
+	}
+
 	// If the first element contains "*", we apply it for each table
 	includeReference := len(tblConfig.IncludeReferencedStructs) == 1 && tblConfig.IncludeReferencedStructs[0] == "*"
 
-	// Try to find by column name
+	// Try to find by any column name of the foreign key
 	if !includeReference {
 		for _, c := range tblConfig.IncludeReferencedStructs {
-			if c == GetFieldName(column.Name) || c == column.Name {
-				includeReference = true
+			for _, name := range columns {
+				if c == GetFieldName(name) || c == name {
+					includeReference = true
+				}
 			}
 		}
 	}
@@ -446,12 +608,12 @@ func (c *constructor) getOneToMany(tblConfig *TableConfig, tbl *ddl.Table) (rtc
 		// Get the table configuration
 		tblConfRef := c.getTableConfigForTable(t)
 
-		// Loop through all columns to find a foreign key
-		for _, c := range t.Columns {
-			if c.ForeignKey && c.ForeignKeyColumn.Schema == tbl.Schema && c.ForeignKeyColumn.Name == tbl.Name {
+		// Loop through all foreign keys to find a reference to this table
+		for _, fk := range getForeignKeys(t) {
+			if fk.ReferencedSchema == tbl.Schema && fk.ReferencedTable == tbl.Name {
 				tblName := GetFieldName(t.Name) + tblConfRef.Suffix
 				tag := &ColumnTag{
-					PointedKeyReference: t.Schema + "." + t.Name + "." + c.Name,
+					PointedKeyReference: t.Schema + "." + t.Name + "." + strings.Join(fk.Columns, "+"),
 				}
 				rtc += fmt.Sprintf("\t%s []%s `%s:\"%s\"`\n", GetFieldName(t.Name), tblName, ColumnTagId, tag.ToTag())
 
@@ -471,6 +633,28 @@ func (c *constructor) getOneToMany(tblConfig *TableConfig, tbl *ddl.Table) (rtc
 	return rtc, constValues, imports
 }
 
+// getForeignKeys returns the foreign keys of the table. If the table contains no
+// foreign key constraints, they are build from the foreign key reference of the columns
+func getForeignKeys(tbl *ddl.Table) []*ddl.ForeignKey {
+	if len(tbl.ForeignKeys) != 0 {
+		return tbl.ForeignKeys
+	}
+
+	rtc := []*ddl.ForeignKey{}
+	for _, c := range tbl.Columns {
+		if c.ForeignKey {
+			rtc = append(rtc, &ddl.ForeignKey{
+				Columns:           []string{c.Name},
+				ReferencedSchema:  c.ForeignKeyColumn.Schema,
+				ReferencedTable:   c.ForeignKeyColumn.Name,
+				ReferencedColumns: []string{c.ForeignKeyColumn.Column},
+			})
+		}
+	}
+
+	return rtc
+}
+
 // patchFile patches the content of an existing file with the new struct.
 // Any existing struct with that name will be overwritten
 func (c *constructor) patchFile(existingContent string, newStruct string, tbl *ddl.Table, tblConfig *TableConfig, imports map[string]bool) (newContent string) {
@@ -481,11 +665,11 @@ func (c *constructor) patchFile(existingContent string, newStruct string, tbl *d
 		logger.Error("Failed to patch imports for table %s.%s: %s", tbl.Schema, tbl.Name, err)
 	}
 
-	// Find existing struct config
+	// Find existing struct config including the comment of the table
 	tblName := GetFieldName(tbl.Name) + tblConfig.Suffix
 	reg := regexp.MustCompile(
 		fmt.Sprintf(
-			`type %s struct {(.|\n)*?\s*%s.*\n}((//.*)|(\s|\n)*)*const \((.|\n)*?\)\n`,
+			`(?m)(^//.*\n)*^type %s struct {(.|\n)*?\s*%s.*\n}((//.*)|(\s|\n)*)*const \((.|\n)*?\)\n`,
 			tblName, MetadataFieldName,
 		),
 	)
@@ -276,6 +276,112 @@ func TestRelationshipOneToMany(t *testing.T) {
 	}
 }
 
+// Test the relationships and tags of a foreign key with multiple columns
+func TestRelationshipCompositeKey(t *testing.T) {
+
+	tableConfig1 := &TableConfig{
+		PackageName:              "olaf",
+		Suffix:                   "Tab",
+		IncludeReferencedStructs: []string{"*"},
+	}
+	tableConfig2 := &TableConfig{
+		PackageName:           "olaf",
+		Suffix:                "Tab",
+		IncludePointedStructs: true,
+	}
+
+	tables := []*ddl.Table{
+		{
+			Name:   "workout_details",
+			Schema: "here_is_me",
+			Columns: []*ddl.Column{
+				{
+					Name:       "workout_id",
+					Type:       ddl.IntType,
+					ForeignKey: true,
+					ForeignKeyColumn: ddl.ForeignColumn{
+						Name:   "workout",
+						Column: "id",
+						Schema: "here_is_me",
+					},
+				},
+				{
+					Name:       "tenant",
+					Type:       ddl.IntType,
+					ForeignKey: true,
+					ForeignKeyColumn: ddl.ForeignColumn{
+						Name:   "workout",
+						Column: "tenant",
+						Schema: "here_is_me",
+					},
+				},
+			},
+			ForeignKeys: []*ddl.ForeignKey{
+				{
+					Name:              "fk_workout",
+					Columns:           []string{"tenant", "workout_id"},
+					ReferencedSchema:  "here_is_me",
+					ReferencedTable:   "workout",
+					ReferencedColumns: []string{"tenant", "id"},
+				},
+			},
+		},
+		{
+			Name:   "workout",
+			Schema: "here_is_me",
+			Columns: []*ddl.Column{
+				{
+					Name:       "tenant",
+					Type:       ddl.IntType,
+					PrimaryKey: true,
+				},
+				{
+					Name:       "id",
+					Type:       ddl.IntType,
+					PrimaryKey: true,
+				},
+			},
+			PrimaryKey: &ddl.PrimaryKey{Columns: []string{"tenant", "id"}},
+		},
+	}
+	c := &constructor{
+		config: &StructConfig{
+			Tableconfig: map[string]*TableConfig{
+				"workout_details": tableConfig1,
+				"workout":         tableConfig2,
+			},
+		},
+		tables: tables,
+	}
+
+	// Only the first column of the foreign key references the struct
+	expectedTypes := map[string]string{"tenant": "*WorkoutTab", "workout_id": "int"}
+	for _, col := range tables[0].Columns {
+		tags := GetTableColumnTag(tables[0], col)
+		if tags.ForeignKeyColumns != "tenant+workout_id" {
+			t.Errorf("Expected foreign key columns 'tenant+workout_id' for %q. Found '%s'", col.Name, tags.ForeignKeyColumns)
+		}
+
+		dt, _ := c.getDataType(col, tableConfig1, tags)
+		if dt != expectedTypes[col.Name] {
+			t.Errorf("Expected data type '%s' for %q. Found '%s'", expectedTypes[col.Name], col.Name, dt)
+		}
+	}
+
+	// A single field is added for the foreign key
+	dt, _, _ := c.getOneToMany(tableConfig2, tables[1])
+	expectedTag := &ColumnTag{
+		PointedKeyReference: "here_is_me.workout_details.tenant+workout_id",
+	}
+	expected := fmt.Sprintf("\tWorkoutDetails []WorkoutDetailsTab `%s:\"%s\"`\n", ColumnTagId, expectedTag.ToTag())
+	if diff := cmp.Diff(
+		replaceWhitespaces(expected),
+		replaceWhitespaces(dt),
+	); diff != "" {
+		t.Errorf("TestRelationshipCompositeKey() mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestPatchFileAppend(t *testing.T) {
 
 	existingContent := `
@@ -534,3 +640,157 @@ func getMetadataTag(tbl *ddl.Table) string {
 	}
 	return tagStart + m.ToTag() + tagEnd
 }
+
+func TestGetDataTypes(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{},
+	}
+
+	for _, tt := range []struct {
+		typ       ddl.DataType
+		canBeNull bool
+		expected  string
+		imp       string
+	}{
+		{ddl.BoolType, false, "bool", ""},
+		{ddl.BoolType, true, "sql.NullBool", "database/sql"},
+		{ddl.BinaryType, false, "[]byte", ""},
+		{ddl.BinaryType, true, "[]byte", ""},
+		{ddl.BitType, false, "[]byte", ""},
+		{ddl.JsonType, false, "json.RawMessage", "encoding/json"},
+		{ddl.JsonType, true, "json.RawMessage", "encoding/json"},
+		{ddl.TextType, false, "string", ""},
+		{ddl.EnumType, true, "sql.NullString", "database/sql"},
+		{ddl.UuidType, false, "string", ""},
+		{ddl.TimeType, false, "string", ""},
+		{ddl.TimestampTzType, false, "time.Time", "time"},
+		{ddl.TimestampTzType, true, "sql.NullTime", "database/sql"},
+		{ddl.YearType, false, "int", ""},
+		{ddl.IntervalType, false, "string", ""},
+	} {
+		col := &ddl.Column{Name: "col", Type: tt.typ, CanBeNull: tt.canBeNull}
+		dt, imp := c.getDataType(col, &TableConfig{}, GetColumnTag(col))
+		if dt != tt.expected || imp != tt.imp {
+			t.Errorf("Expected %q (%q) for %s (nullable: %t). Got %q (%q)", tt.expected, tt.imp, tt.typ, tt.canBeNull, dt, imp)
+		}
+	}
+}
+
+func TestDecimalConfig(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{},
+	}
+	notNull := &ddl.Column{Name: "price", Type: ddl.DecimalType, NumericPrecision: 10, NumericScale: 2}
+	nullable := &ddl.Column{Name: "discount", Type: ddl.DecimalType, CanBeNull: true}
+
+	// Types of this package
+	if dt, imp := c.getDataType(notNull, &TableConfig{}, nil); dt != "ddl.Decimal" || imp != PackageName {
+		t.Errorf("Expected 'ddl.Decimal'. Got %q (%q)", dt, imp)
+	}
+	if dt, imp := c.getDataType(nullable, &TableConfig{}, nil); dt != "ddl.NullDecimal" || imp != PackageName {
+		t.Errorf("Expected 'ddl.NullDecimal'. Got %q (%q)", dt, imp)
+	}
+
+	// Third-party type
+	c.config.DecimalConfig = DecimalConfig{
+		Type:     "decimal.Decimal",
+		NullType: "decimal.NullDecimal",
+		Package:  "github.com/shopspring/decimal",
+	}
+	if dt, imp := c.getDataType(notNull, &TableConfig{}, nil); dt != "decimal.Decimal" || imp != "github.com/shopspring/decimal" {
+		t.Errorf("Expected 'decimal.Decimal'. Got %q (%q)", dt, imp)
+	}
+	if dt, _ := c.getDataType(nullable, &TableConfig{}, nil); dt != "decimal.NullDecimal" {
+		t.Errorf("Expected 'decimal.NullDecimal'. Got %q", dt)
+	}
+
+	// A pointer is used without a null type
+	c.config.DecimalConfig.NullType = ""
+	if dt, imp := c.getDataType(nullable, &TableConfig{}, nil); dt != "*decimal.Decimal" || imp != "github.com/shopspring/decimal" {
+		t.Errorf("Expected '*decimal.Decimal'. Got %q (%q)", dt, imp)
+	}
+
+	// The null config is used like for other types
+	c.config.NullConfig.Custom = func(typ ddl.DataType, defaultName string) (string, string) {
+		return "null." + defaultName, "example.com/null"
+	}
+	if dt, imp := c.getDataType(nullable, &TableConfig{}, nil); dt != "null.Decimal" || imp != "example.com/null" {
+		t.Errorf("Expected 'null.Decimal'. Got %q (%q)", dt, imp)
+	}
+	c.config.NullConfig.Custom = nil
+	c.config.DecimalConfig = DecimalConfig{}
+
+	// The package and prefix of the null config don't provide a decimal type
+	c.config.NullConfig.Package, c.config.NullConfig.Prefix = "example.com/types", sql.NullString{Valid: true, String: "types.Null"}
+	if dt, imp := c.getDataType(nullable, &TableConfig{}, nil); dt != "ddl.NullDecimal" || imp != PackageName {
+		t.Errorf("Expected 'ddl.NullDecimal'. Got %q (%q)", dt, imp)
+	}
+
+	// Disabled null types
+	c.config.DecimalConfig = DecimalConfig{Type: "decimal.Decimal", Package: "github.com/shopspring/decimal"}
+	c.config.NullConfig.Disable = true
+	if dt, _ := c.getDataType(nullable, &TableConfig{}, nil); dt != "decimal.Decimal" {
+		t.Errorf("Expected 'decimal.Decimal' for disabled null types. Got %q", dt)
+	}
+}
+
+func TestReadOnlyViews(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{},
+	}
+	table := &ddl.Table{
+		Name:   "active_users",
+		Schema: "ddl",
+		Kind:   ddl.TableKindView,
+		Columns: []*ddl.Column{
+			{Name: "id", Type: ddl.IntType, PrimaryKey: true, DefaultValue: sql.NullString{Valid: true, String: "0"}},
+		},
+	}
+	tableConfig := &TableConfig{PackageName: "olaf"}
+
+	// Views are handled like tables by default
+	goFile := c.getGoFile("", table, tableConfig)
+	if !strings.Contains(goFile, "Column:id,PrimaryKey,DefaultValue") || strings.Contains(goFile, "ReadOnly") {
+		t.Errorf("Expected the insert metadata for the view:\n%s", goFile)
+	}
+
+	c.config.ReadOnlyViews = true
+	goFile = c.getGoFile("", table, tableConfig)
+	if !strings.Contains(goFile, `dbColumn:"Column:id"`) || !strings.Contains(goFile, "Table:active_users,ReadOnly") {
+		t.Errorf("Expected a read-only struct without insert metadata:\n%s", goFile)
+	}
+
+	// Tables are never read only
+	table.Kind = ddl.TableKindTable
+	goFile = c.getGoFile("", table, tableConfig)
+	if strings.Contains(goFile, "ReadOnly") {
+		t.Errorf("Expected no read-only struct for a table:\n%s", goFile)
+	}
+}
+
+func TestTableComment(t *testing.T) {
+	c := &constructor{
+		config: &StructConfig{},
+	}
+	table := &ddl.Table{
+		Name:    "users",
+		Schema:  "ddl",
+		Comment: "All registered users\nof the application",
+		Columns: []*ddl.Column{
+			{Name: "id", Type: ddl.IntType},
+		},
+	}
+	tableConfig := &TableConfig{PackageName: "olaf"}
+
+	goFile := c.getGoFile("", table, tableConfig)
+	if !strings.Contains(goFile, "// All registered users\n// of the application\ntype Users struct {") {
+		t.Errorf("Expected the table comment as struct comment:\n%s", goFile)
+	}
+
+	// The comment is replaced when patching the file
+	table.Comment = "Users"
+	goFile = c.getGoFile(goFile, table, tableConfig)
+	if !strings.Contains(goFile, "\n// Users\ntype Users struct {") || strings.Contains(goFile, "registered") {
+		t.Errorf("Expected the new table comment as struct comment:\n%s", goFile)
+	}
+}
@@ -34,16 +34,26 @@ type ColumnTag struct {
 	// format Schema.Table.Column
 	ForeignKeyReference string
 
+	// Ordered columns of the foreign key in format ColumnA+ColumnB if the
+	// foreign key consists of multiple columns. It's empty otherwise
+	ForeignKeyColumns string
+
 	// Column from which this struct was referenced (n:1 relations) in
-	// format Schema.Table.Column.
+	// format Schema.Table.Column. For foreign keys with multiple columns,
+	// the columns are joined with a "+": Schema.Table.ColumnA+ColumnB.
 	// If this field is present, all other fields are empty
 	PointedKeyReference string
 
-	// Mariadb: weather this field has the property "auto_increment"
+	// Weather the value of this field is generated on inserts like with the MariaDB
+	// property "auto_increment" or an oracle identity
 	AutoIncrement bool
 
 	// Weather this column has a default value
 	HasDefaultValue bool
+
+	// Weather the value of this column is generated by the database.
+	// It's read only and must not be written on inserts or updates
+	Generated bool
 }
 
 // Identifier of the struct tag for "MetadataTag"
@@ -60,6 +70,9 @@ type MetadataTag struct {
 
 	// Name of the table this struct represents
 	Table string
+
+	// Weather the struct represents a view that cannot be modified
+	ReadOnly bool
 }
 
 // GetColumnTag returns a "ColumnTag" struct from a ddl column
@@ -80,12 +93,29 @@ func GetColumnTag(col *ddl.Column) *ColumnTag {
 	}
 
 	// Add auto increment property
-	if mariaDb, ok := col.Extras.(*ddl.MariadbColumn); ok {
-		rtc.AutoIncrement = mariaDb.AutoIncrement
+	switch extras := col.Extras.(type) {
+	case *ddl.MariadbColumn:
+		rtc.AutoIncrement = extras.AutoIncrement
+	case *ddl.OracleColumn:
+		rtc.AutoIncrement = extras.AutoIncrement
 	}
 
 	// Add some boolean flags
 	rtc.HasDefaultValue = col.DefaultValue.Valid
+	rtc.Generated = col.IsGenerated()
+
+	return rtc
+}
+
+// GetTableColumnTag returns a "ColumnTag" struct from a ddl column like
+// "GetColumnTag". Additionally, the foreign keys of the table are used
+// to add information about foreign keys with multiple columns
+func GetTableColumnTag(tbl *ddl.Table, col *ddl.Column) *ColumnTag {
+	rtc := GetColumnTag(col)
+
+	if fk := tbl.GetForeignKey(col.Name); fk != nil && len(fk.Columns) > 1 {
+		rtc.ForeignKeyColumns = strings.Join(fk.Columns, "+")
+	}
 
 	return rtc
 }
@@ -110,9 +140,15 @@ func (c *ColumnTag) ToTag() (rtc string) {
 	if c.ForeignKeyReference != "" {
 		rtc += ",ForeignKey:" + c.ForeignKeyReference
 	}
+	if c.ForeignKeyColumns != "" {
+		rtc += ",ForeignKeyColumns:" + c.ForeignKeyColumns
+	}
 	if c.HasDefaultValue {
 		rtc += ",DefaultValue"
 	}
+	if c.Generated {
+		rtc += ",Generated"
+	}
 
 	return rtc
 }
@@ -133,6 +169,8 @@ func FromColumnTag(tag string) *ColumnTag {
 			rtc.IsPrimaryKey = true
 		case "DefaultValue":
 			rtc.HasDefaultValue = true
+		case "Generated":
+			rtc.Generated = true
 		}
 
 		// Key-value pairs
@@ -152,6 +190,8 @@ func FromColumnTag(tag string) *ColumnTag {
 				rtc.Name = value
 			case "ForeignKey":
 				rtc.ForeignKeyReference = value
+			case "ForeignKeyColumns":
+				rtc.ForeignKeyColumns = value
 			case "PointedForeignKey":
 				rtc.PointedKeyReference = value
 			default:
@@ -167,6 +207,9 @@ func FromColumnTag(tag string) *ColumnTag {
 // struct tag
 func (c *MetadataTag) ToTag() string {
 	rtc := fmt.Sprintf("Schema:%s,Table:%s", c.Schema, c.Table)
+	if c.ReadOnly {
+		rtc += ",ReadOnly"
+	}
 
 	return rtc
 }
@@ -180,6 +223,11 @@ func FromMetadataTag(tag string) *MetadataTag {
 	vals := strings.Split(tag, ",")
 	for _, val := range vals {
 
+		// Boolean flags
+		if val == "ReadOnly" {
+			rtc.ReadOnly = true
+		}
+
 		// Key-value pairs
 		if strings.Contains(val, ":") {
 			point := strings.Index(val, ":")
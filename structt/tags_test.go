@@ -3,6 +3,7 @@ package structt
 import (
 	"testing"
 
+	"github.com/RPJoshL/go-ddl-parser"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -30,9 +31,11 @@ func TestColumnTagTransformPositive(t *testing.T) {
 		Name:                "",
 		IsPrimaryKey:        true,
 		ForeignKeyReference: "workout.users.id",
+		ForeignKeyColumns:   "tenant+user_id",
 		PointedKeyReference: "hello",
 		AutoIncrement:       true,
 		HasDefaultValue:     true,
+		Generated:           true,
 	}
 
 	// Transform to string
@@ -50,8 +53,9 @@ func TestColumnTagTransformPositive(t *testing.T) {
 func TestMetadataTagTransform(t *testing.T) {
 	// Base struct to test
 	baseTag := &MetadataTag{
-		Schema: "workout",
-		Table:  "user",
+		Schema:   "workout",
+		Table:    "user",
+		ReadOnly: true,
 	}
 
 	// Transform to string
@@ -65,3 +69,18 @@ func TestMetadataTagTransform(t *testing.T) {
 		t.Errorf("TestColumnTagTransformNegative() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestGetColumnTagGenerated(t *testing.T) {
+	tag := GetColumnTag(&ddl.Column{Name: "total", GenerationExpression: "price * amount"})
+	if expected := "Column:total,Generated"; tag.ToTag() != expected {
+		t.Errorf("Expected tag %q. Got %q", expected, tag.ToTag())
+	}
+}
+
+func TestGetColumnTagOracleIdentity(t *testing.T) {
+	col := &ddl.OracleColumn{Column: &ddl.Column{Name: "ID"}, AutoIncrement: true, IdentityGeneration: "ALWAYS"}
+	col.Extras = col
+	if expected := "Column:ID,AutoIncrement"; GetColumnTag(col.Column).ToTag() != expected {
+		t.Errorf("Expected tag %q. Got %q", expected, GetColumnTag(col.Column).ToTag())
+	}
+}
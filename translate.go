@@ -0,0 +1,478 @@
+package ddl
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/RPJoshL/go-logger"
+)
+
+// TypeTranslation is the translation of the data type of a column into another dialect
+type TypeTranslation struct {
+
+	// Schema and name of the table. They are only set by "TranslateTables"
+	Schema string
+	Table  string
+
+	// Name of the column
+	Column string
+
+	// Data type of the source column with its lenght like "int(10)" or "NUMBER(10)"
+	From string
+
+	// Data type within the target dialect
+	To string
+
+	// Descriptions of the information that is lost by the translation.
+	// It's empty if the data type can be translated without any loss
+	Warnings []string
+}
+
+// IsLossy returns weather information is lost by the translation
+func (t *TypeTranslation) IsLossy() bool {
+	return len(t.Warnings) != 0
+}
+
+// String returns the translation like "ddl.user.id: int(10) -> NUMBER(10)"
+// followed by the warnings of a lossy translation
+func (t *TypeTranslation) String() string {
+	rtc := t.Column
+	if t.Table != "" {
+		rtc = t.Table + "." + rtc
+	}
+	if t.Schema != "" {
+		rtc = t.Schema + "." + rtc
+	}
+
+	rtc += ": " + t.From + " -> " + t.To
+	if t.IsLossy() {
+		rtc += " (LOSSY: " + strings.Join(t.Warnings, "; ") + ")"
+	}
+	return rtc
+}
+
+// warn adds a warning about lost information
+func (t *TypeTranslation) warn(format string, args ...any) {
+	t.Warnings = append(t.Warnings, fmt.Sprintf(format, args...))
+}
+
+// TranslateType translates the data type of the column into the dialect. Columns that were
+// read from the same database system keep their data type. Otherwise, the data type is
+// derived from the generic data type with its lenght, precision and scale
+func (d Dialect) TranslateType(c *Column) *TypeTranslation {
+	rtc := &TypeTranslation{Column: c.Name, From: c.InternalType}
+	if extras, ok := c.Extras.(*OracleColumn); ok {
+		rtc.From = oracleColumnType(extras)
+	}
+
+	switch c.Extras.(type) {
+	case *MariadbColumn:
+		if d == DialectMariadb {
+			rtc.To = rtc.From
+			return rtc
+		}
+	case *OracleColumn:
+		if d == DialectOracle {
+			rtc.To = rtc.From
+			return rtc
+		}
+	}
+
+	if d == DialectMariadb {
+		rtc.To = mariadbType(c, rtc)
+	} else {
+		rtc.To = oracleType(c, rtc)
+	}
+
+	// Expressions are written in the SQL dialect of the source database system
+	if c.IsGenerated() {
+		rtc.warn("generation expression %q is not translated", c.GenerationExpression)
+	} else if v := c.DefaultValue.String; c.DefaultValue.Valid && !c.IsAutoIncrement() && defaultExpression.MatchString(v) {
+		if _, ok := d.translateExpression(c, v); !ok {
+			rtc.warn("default expression %q can't be translated and is skipped", v)
+		}
+	}
+
+	return rtc
+}
+
+// TranslateTables translates the data types of all columns of the tables into the dialect.
+// The translations can be used as a report of lossy conversions before a migration
+func (d Dialect) TranslateTables(tables []*Table) []*TypeTranslation {
+	rtc := []*TypeTranslation{}
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			translation := d.TranslateType(c)
+			translation.Schema, translation.Table = t.Schema, t.Name
+			rtc = append(rtc, translation)
+		}
+	}
+
+	return rtc
+}
+
+// ToOracleColumn converts the MariaDB column into an oracle column with a translated
+// data type. Auto increment columns are converted into identity columns
+func ToOracleColumn(c *MariadbColumn) (*OracleColumn, *TypeTranslation) {
+	translation := DialectOracle.TranslateType(c.Column)
+
+	col := &OracleColumn{Column: copyColumn(c.Column), AutoIncrement: c.AutoIncrement}
+	col.Extras = col
+	if c.AutoIncrement {
+		col.IdentityGeneration = "BY DEFAULT"
+	}
+
+	parsed, ok := parseColumnType(DialectOracle, translation.To).(*OracleColumn)
+	if !ok {
+		col.InternalType, col.Type = translation.To, UnknownType
+		return col, translation
+	}
+	col.InternalType, col.Type = parsed.InternalType, parsed.Type
+	col.NumericPrecision, col.NumericScale = parsed.NumericPrecision, parsed.NumericScale
+	col.DataTypeLenght, col.Scale, col.CharLenght = parsed.DataTypeLenght, parsed.Scale, parsed.CharLenght
+
+	return col, translation
+}
+
+// ToMariadbColumn converts the oracle column into a MariaDB column with a translated
+// data type. Identity and sequence columns are converted into auto increment columns
+func ToMariadbColumn(c *OracleColumn) (*MariadbColumn, *TypeTranslation) {
+	translation := DialectMariadb.TranslateType(c.Column)
+
+	col := &MariadbColumn{Column: copyColumn(c.Column), AutoIncrement: c.AutoIncrement}
+	col.Extras = col
+	if c.PrimaryKey {
+		col.KeyType = MariadbKeyPrimary
+	}
+
+	parsed, ok := parseColumnType(DialectMariadb, translation.To).(*MariadbColumn)
+	if !ok {
+		col.InternalType, col.Type = translation.To, UnknownType
+		return col, translation
+	}
+	col.InternalType, col.Type = parsed.InternalType, parsed.Type
+	col.NumericPrecision, col.NumericScale = parsed.NumericPrecision, parsed.NumericScale
+	col.DataTypeLenght, col.EnumValues = parsed.DataTypeLenght, parsed.EnumValues
+
+	return col, translation
+}
+
+// copyColumn returns a copy of the column without any extras
+func copyColumn(c *Column) *Column {
+	rtc := *c
+	rtc.Extras = nil
+	rtc.Type, rtc.InternalType = UnknownType, ""
+	rtc.NumericPrecision, rtc.NumericScale = 0, 0
+	return &rtc
+}
+
+// parseColumnType parses the data type with the script parser of the dialect, so the
+// lenght and scale are set like for every other column of the database system.
+// It returns nil if the data type can't be parsed
+func parseColumnType(d Dialect, dataType string) Columner {
+	var script interface {
+		Parse(script string) error
+		GetTables(schema string) ([]*Table, error)
+	}
+	schema := "ddl"
+	if d == DialectMariadb {
+		script = NewMariadbScript(schema)
+	} else {
+		schema = "DDL"
+		script = NewOracleScript(schema)
+	}
+
+	if err := script.Parse(fmt.Sprintf("CREATE TABLE t (c %s);", dataType)); err != nil {
+		logger.Debug("Failed to parse translated data type %q: %s", dataType, err)
+		return nil
+	}
+	tables, err := script.GetTables(schema)
+	if err != nil || len(tables) == 0 || len(tables[0].Columns) == 0 {
+		return nil
+	}
+	return tables[0].Columns[0].Extras
+}
+
+// fractionalPrecision returns the number of fractional digits of the seconds
+// of a date or time column or zero if it's not known
+func (c *Column) fractionalPrecision() int {
+	switch extras := c.Extras.(type) {
+	case *MariadbColumn:
+		return extras.DataTypeLenght
+	case *OracleColumn:
+		return extras.Scale
+	}
+
+	return 0
+}
+
+// bytesPerCharacter returns the maximum number of bytes of a character in the
+// character set of the column
+func (c *Column) bytesPerCharacter() int {
+	if extras, ok := c.Extras.(*MariadbColumn); ok {
+		switch {
+		case extras.Charset == "":
+		case strings.HasPrefix(extras.Charset, "latin"), extras.Charset == "ascii", extras.Charset == "binary":
+			return 1
+		case extras.Charset == "utf8" || extras.Charset == "utf8mb3":
+			return 3
+		}
+	}
+
+	return 4
+}
+
+// withPrecision appends the precision to the data type like "datetime(6)" if it's not zero
+func withPrecision(dataType string, precision int) string {
+	if precision == 0 {
+		return dataType
+	}
+	return fmt.Sprintf("%s(%d)", dataType, precision)
+}
+
+// mariadbType returns the MariaDB data type of a column of another database system
+func mariadbType(c *Column, t *TypeTranslation) string {
+	lenght := c.dataTypeLenght()
+	internalType := strings.ToUpper(c.InternalType)
+	extras, isOracle := c.Extras.(*OracleColumn)
+
+	switch c.Type {
+	case StringType:
+		switch {
+		case isOracle && extras.CharLenght != 0:
+			// The lenght of character semantics is stored in bytes
+			lenght = extras.CharLenght
+		case lenght == 0:
+			t.warn("lenght is unknown and limited to 255 characters")
+			return "varchar(255)"
+		case isOracle && strings.HasPrefix(internalType, "N"):
+			lenght /= 2
+		}
+		switch {
+		case lenght > 16383:
+			// The lenght would exceed the maximum row size
+			return "mediumtext"
+		case (internalType == "CHAR" || internalType == "NCHAR") && lenght <= 255:
+			return fmt.Sprintf("char(%d)", lenght)
+		}
+		return fmt.Sprintf("varchar(%d)", lenght)
+	case TextType:
+		return "longtext"
+	case JsonType:
+		return "json"
+	case EnumType, SetType:
+		values := c.enumValues()
+		if len(values) == 0 {
+			t.warn("allowed values are unknown and not enforced")
+			return "varchar(255)"
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = DialectMariadb.QuoteString(v)
+		}
+		return fmt.Sprintf("%s(%s)", strings.ToLower(string(c.Type)), strings.Join(quoted, ","))
+	case IntType:
+		switch {
+		case lenght == 0:
+			return "bigint(20)"
+		case lenght <= 2:
+			return "tinyint(4)"
+		case lenght <= 4:
+			return "smallint(6)"
+		case lenght <= 6:
+			return "mediumint(9)"
+		case lenght <= 9:
+			return "int(11)"
+		case lenght <= 18:
+			return "bigint(20)"
+		}
+		return fmt.Sprintf("decimal(%d,0)", min(lenght, 65))
+	case DoubleType:
+		switch {
+		case isOracle && internalType == "NUMBER":
+			t.warn("precision of NUMBER is unknown and limited to 35 integer and 30 fractional digits")
+			return "decimal(65,30)"
+		case internalType == "BINARY_FLOAT" || internalType == "FLOAT" && lenght <= 24 || internalType == "REAL":
+			return "float"
+		case internalType == "FLOAT" && lenght > 53:
+			t.warn("binary precision of %d digits is reduced to 53 digits", lenght)
+		}
+		return "double"
+	case DecimalType:
+		precision, scale := c.NumericPrecision, c.NumericScale
+		switch {
+		case precision == 0:
+			t.warn("precision is unknown and limited to 35 integer and 30 fractional digits")
+			return "decimal(65,30)"
+		case scale < 0:
+			// Negative scales round to the left of the dot
+			precision, scale = precision-scale, 0
+		case scale > precision:
+			precision = scale
+		}
+		if scale > 30 {
+			t.warn("scale of %d digits is reduced to 30 digits", scale)
+			precision, scale = precision-scale+30, 30
+		}
+		if precision > 65 {
+			t.warn("precision of %d digits is reduced to 65 digits", precision)
+			precision = 65
+		}
+		return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+	case DateType:
+		if !isOracle && internalType == "DATE" {
+			return "date"
+		}
+		return "datetime" + mariadbFraction(c, t)
+	case TimestampTzType:
+		if strings.Contains(internalType, "LOCAL TIME ZONE") {
+			t.warn("range of the values is limited to the years 1970 to 2038")
+			return "timestamp" + mariadbFraction(c, t)
+		}
+		t.warn("time zones of the values are lost")
+		return "datetime" + mariadbFraction(c, t)
+	case TimeType:
+		return "time" + mariadbFraction(c, t)
+	case IntervalType:
+		if strings.HasPrefix(internalType, "INTERVAL YEAR") {
+			t.warn("intervals of years and months are stored as string")
+			return "varchar(32)"
+		}
+		t.warn("intervals are limited to 838 hours")
+		return "time" + mariadbFraction(c, t)
+	case BoolType:
+		return "tinyint(1)"
+	case BinaryType:
+		switch {
+		case internalType == "RAW" && lenght > 0:
+			return fmt.Sprintf("varbinary(%d)", lenght)
+		case internalType == "BFILE":
+			t.warn("content of external files is not stored within the database")
+		}
+		return "longblob"
+	case UuidType:
+		return "uuid"
+	case BitType:
+		if lenght > 64 {
+			t.warn("lenght of %d bits is reduced to 64 bits", lenght)
+			lenght = 64
+		}
+		return fmt.Sprintf("bit(%d)", max(lenght, 1))
+	case YearType:
+		return "year(4)"
+	case GeoType:
+		return "point"
+	}
+
+	t.warn("unknown data type %q is not translated", c.InternalType)
+	return c.InternalType
+}
+
+// mariadbFraction returns the fractional seconds of a MariaDB date or time type like "(6)"
+func mariadbFraction(c *Column, t *TypeTranslation) string {
+	precision := c.fractionalPrecision()
+	if precision > 6 {
+		t.warn("fractional seconds are reduced from %d to 6 digits", precision)
+		precision = 6
+	}
+
+	return withPrecision("", precision)
+}
+
+// oracleType returns the oracle data type of a column of another database system
+func oracleType(c *Column, t *TypeTranslation) string {
+	lenght := c.dataTypeLenght()
+	internalType := strings.ToLower(c.InternalType)
+
+	switch c.Type {
+	case StringType, EnumType, SetType:
+		if lenght == 0 {
+			t.warn("lenght is unknown and limited to 255 characters")
+			lenght = 255
+		}
+		switch c.Type {
+		case EnumType:
+			t.warn("allowed values are not enforced")
+		case SetType:
+			t.warn("values are stored as comma separated string")
+		}
+
+		// Values of VARCHAR2 are limited to 4000 bytes regardless of the character semantics
+		if lenght > 4000 {
+			t.warn("lenght of %d characters exceeds the limit of VARCHAR2 and is stored as CLOB", lenght)
+			return "CLOB"
+		}
+		if bytes := lenght * c.bytesPerCharacter(); bytes > 4000 {
+			t.warn("values are limited to 4000 bytes instead of %d bytes", bytes)
+		}
+		return fmt.Sprintf("VARCHAR2(%d CHAR)", lenght)
+	case TextType:
+		return "CLOB"
+	case JsonType:
+		t.warn("JSON values are stored as CLOB without validation")
+		return "CLOB"
+	case IntType:
+		switch {
+		case lenght == 0:
+			lenght = 19
+		case lenght > 38:
+			t.warn("precision of %d digits is reduced to 38 digits", lenght)
+			lenght = 38
+		}
+		return fmt.Sprintf("NUMBER(%d)", lenght)
+	case DoubleType:
+		if strings.HasPrefix(internalType, "float") || internalType == "real" {
+			return "BINARY_FLOAT"
+		}
+		return "BINARY_DOUBLE"
+	case DecimalType:
+		precision, scale := c.NumericPrecision, c.NumericScale
+		if precision == 0 {
+			return "NUMBER"
+		}
+		if precision > 38 {
+			t.warn("precision of %d digits is reduced to 38 digits", precision)
+			precision = 38
+		}
+		return fmt.Sprintf("NUMBER(%d,%d)", precision, min(scale, precision))
+	case DateType:
+		precision := c.fractionalPrecision()
+		switch {
+		case strings.HasPrefix(internalType, "timestamp"):
+			// The values of MariaDB timestamps are converted into the time zone of the session
+			return withPrecision("TIMESTAMP", precision) + " WITH LOCAL TIME ZONE"
+		case precision > 0:
+			return withPrecision("TIMESTAMP", precision)
+		}
+		return "DATE"
+	case TimestampTzType:
+		return withPrecision("TIMESTAMP", c.fractionalPrecision()) + " WITH TIME ZONE"
+	case TimeType:
+		return fmt.Sprintf("INTERVAL DAY(2) TO SECOND(%d)", c.fractionalPrecision())
+	case IntervalType:
+		t.warn("intervals of years and months are not supported")
+		return fmt.Sprintf("INTERVAL DAY(9) TO SECOND(%d)", c.fractionalPrecision())
+	case BoolType:
+		return "NUMBER(1)"
+	case BinaryType:
+		if (strings.HasPrefix(internalType, "binary") || strings.HasPrefix(internalType, "varbinary")) && lenght > 0 && lenght <= 2000 {
+			return fmt.Sprintf("RAW(%d)", lenght)
+		}
+		return "BLOB"
+	case UuidType:
+		t.warn("UUIDs are stored as 16 raw bytes instead of a string")
+		return "RAW(16)"
+	case BitType:
+		// Number of decimal digits of the largest value
+		return fmt.Sprintf("NUMBER(%d)", int(float64(max(lenght, 1))*math.Log10(2))+1)
+	case YearType:
+		return "NUMBER(4)"
+	case GeoType:
+		t.warn("spatial values have to be converted into SDO_GEOMETRY")
+		return "SDO_GEOMETRY"
+	}
+
+	t.warn("unknown data type %q is not translated", c.InternalType)
+	return c.InternalType
+}
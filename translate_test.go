@@ -0,0 +1,179 @@
+package ddl
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// translationStrings returns the string representation of the translations
+func translationStrings(translations []*TypeTranslation) []string {
+	rtc := []string{}
+	for _, t := range translations {
+		rtc = append(rtc, t.String())
+	}
+	return rtc
+}
+
+func TestTranslateTablesToOracle(t *testing.T) {
+	s := NewMariadbScript("ddl")
+	if err := s.Parse(`
+		CREATE TABLE user (
+			id      BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name    VARCHAR(100) NOT NULL,
+			mail    VARCHAR(1500),
+			bio     VARCHAR(5000),
+			state   ENUM('on', 'off'),
+			price   DECIMAL(65,30),
+			ratio   FLOAT,
+			created DATETIME(6) DEFAULT current_timestamp(6),
+			changed TIMESTAMP,
+			birth   DATE,
+			active  BOOL,
+			avatar  VARBINARY(100),
+			uid     UUID,
+			flags   BIT(8)
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+	tables, _ := s.GetTables("ddl")
+
+	// Latin characters require only a single byte
+	tables[0].GetColumn("name").Extras.(*MariadbColumn).Charset = "latin1"
+
+	expected := []string{
+		"ddl.user.id: bigint(20) unsigned -> NUMBER(20)",
+		"ddl.user.name: varchar(100) -> VARCHAR2(100 CHAR)",
+		"ddl.user.mail: varchar(1500) -> VARCHAR2(1500 CHAR) (LOSSY: values are limited to 4000 bytes instead of 6000 bytes)",
+		"ddl.user.bio: varchar(5000) -> CLOB (LOSSY: lenght of 5000 characters exceeds the limit of VARCHAR2 and is stored as CLOB)",
+		"ddl.user.state: enum('on','off') -> VARCHAR2(3 CHAR) (LOSSY: allowed values are not enforced)",
+		"ddl.user.price: decimal(65,30) -> NUMBER(38,30) (LOSSY: precision of 65 digits is reduced to 38 digits)",
+		"ddl.user.ratio: float -> BINARY_FLOAT",
+		"ddl.user.created: datetime(6) -> TIMESTAMP(6)",
+		"ddl.user.changed: timestamp -> TIMESTAMP WITH LOCAL TIME ZONE",
+		"ddl.user.birth: date -> DATE",
+		"ddl.user.active: tinyint(1) -> NUMBER(1)",
+		"ddl.user.avatar: varbinary(100) -> RAW(100)",
+		"ddl.user.uid: uuid -> RAW(16) (LOSSY: UUIDs are stored as 16 raw bytes instead of a string)",
+		"ddl.user.flags: bit(8) -> NUMBER(3)",
+	}
+	if diff := cmp.Diff(expected, translationStrings(DialectOracle.TranslateTables(tables))); diff != "" {
+		t.Errorf("Mismatch of translations (-want +got):\n%s", diff)
+	}
+}
+
+func TestTranslateTablesToMariadb(t *testing.T) {
+	s := NewOracleScript("DDL")
+	if err := s.Parse(`
+		CREATE TABLE users (
+			id       NUMBER(10) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			code     NUMBER(3),
+			big      NUMBER(30),
+			amount   NUMBER,
+			price    NUMBER(10,2),
+			ratio    FLOAT,
+			name     VARCHAR2(50 CHAR) NOT NULL,
+			title    NVARCHAR2(20),
+			flag     CHAR(1),
+			created  DATE DEFAULT SYSDATE,
+			changed  TIMESTAMP(9),
+			zoned    TIMESTAMP WITH TIME ZONE,
+			duration INTERVAL DAY TO SECOND,
+			period   INTERVAL YEAR TO MONTH,
+			content  CLOB,
+			hash     RAW(16),
+			photo    BLOB
+		);
+	`); err != nil {
+		t.Fatalf("Failed to parse script: %s", err)
+	}
+	tables, _ := s.GetTables("DDL")
+
+	expected := []string{
+		"DDL.USERS.ID: NUMBER(10) -> bigint(20)",
+		"DDL.USERS.CODE: NUMBER(3) -> smallint(6)",
+		"DDL.USERS.BIG: NUMBER(30) -> decimal(30,0)",
+		"DDL.USERS.AMOUNT: NUMBER -> decimal(65,30) (LOSSY: precision of NUMBER is unknown and limited to 35 integer and 30 fractional digits)",
+		"DDL.USERS.PRICE: NUMBER(10,2) -> decimal(10,2)",
+		"DDL.USERS.RATIO: FLOAT(126) -> double (LOSSY: binary precision of 126 digits is reduced to 53 digits)",
+		"DDL.USERS.NAME: VARCHAR2(50 CHAR) -> varchar(50)",
+		"DDL.USERS.TITLE: NVARCHAR2(20) -> varchar(20)",
+		"DDL.USERS.FLAG: CHAR(1) -> char(1)",
+		"DDL.USERS.CREATED: DATE -> datetime",
+		"DDL.USERS.CHANGED: TIMESTAMP(9) -> datetime(6) (LOSSY: fractional seconds are reduced from 9 to 6 digits)",
+		"DDL.USERS.ZONED: TIMESTAMP(6) WITH TIME ZONE -> datetime(6) (LOSSY: time zones of the values are lost)",
+		"DDL.USERS.DURATION: INTERVAL DAY(2) TO SECOND(6) -> time(6) (LOSSY: intervals are limited to 838 hours)",
+		"DDL.USERS.PERIOD: INTERVAL YEAR(2) TO MONTH -> varchar(32) (LOSSY: intervals of years and months are stored as string)",
+		"DDL.USERS.CONTENT: CLOB -> longtext",
+		"DDL.USERS.HASH: RAW(16) -> varbinary(16)",
+		"DDL.USERS.PHOTO: BLOB -> longblob",
+	}
+	if diff := cmp.Diff(expected, translationStrings(DialectMariadb.TranslateTables(tables))); diff != "" {
+		t.Errorf("Mismatch of translations (-want +got):\n%s", diff)
+	}
+
+	// Columns of the same database system keep their data type
+	if translation := DialectOracle.TranslateType(tables[0].Columns[0]); translation.To != "NUMBER(10)" || translation.IsLossy() {
+		t.Errorf("Expected an unchanged data type. Got %s", translation)
+	}
+}
+
+func TestConvertColumns(t *testing.T) {
+	mariadb := &MariadbColumn{
+		Column: &Column{
+			Name: "price", Type: DecimalType, InternalType: "decimal(10,2)", NumericPrecision: 10, NumericScale: 2,
+			DefaultValue: sql.NullString{Valid: true, String: "0"}, Comment: "Price",
+		},
+		DataTypeLenght: 10,
+	}
+	mariadb.Extras = mariadb
+
+	oracle, translation := ToOracleColumn(mariadb)
+	if translation.To != "NUMBER(10,2)" || translation.IsLossy() {
+		t.Errorf("Unexpected translation: %s", translation)
+	}
+	if oracle.Extras != oracle || mariadb.Extras != mariadb {
+		t.Errorf("Expected the extras to reference the own column")
+	}
+	expectedOracle := &OracleColumn{
+		Column: &Column{
+			Name: "price", Type: DecimalType, InternalType: "NUMBER", NumericPrecision: 10, NumericScale: 2,
+			DefaultValue: sql.NullString{Valid: true, String: "0"}, Comment: "Price",
+		},
+		DataTypeLenght: 10,
+		Scale:          2,
+	}
+	expectedOracle.Extras = expectedOracle
+	if diff := cmp.Diff(expectedOracle, oracle); diff != "" {
+		t.Errorf("Mismatch of oracle column (-want +got):\n%s", diff)
+	}
+
+	// Converting the column back results in the original column
+	converted, translation := ToMariadbColumn(oracle)
+	if translation.From != "NUMBER(10,2)" || translation.To != "decimal(10,2)" {
+		t.Errorf("Unexpected translation: %s", translation)
+	}
+	if diff := cmp.Diff(mariadb, converted); diff != "" {
+		t.Errorf("Mismatch of MariaDB column (-want +got):\n%s", diff)
+	}
+
+	// The lenght of strings is kept in characters
+	name := &MariadbColumn{Column: &Column{Name: "name", Type: StringType, InternalType: "varchar(10)"}, DataTypeLenght: 10}
+	name.Extras = name
+	oracleName, translation := ToOracleColumn(name)
+	if translation.To != "VARCHAR2(10 CHAR)" || oracleName.CharLenght != 10 {
+		t.Errorf("Unexpected translation: %s", translation)
+	}
+	if converted, translation := ToMariadbColumn(oracleName); translation.To != "varchar(10)" || converted.DataTypeLenght != 10 {
+		t.Errorf("Unexpected translation: %s", translation)
+	}
+
+	// Auto increment columns are identity columns
+	id := &MariadbColumn{Column: &Column{Name: "id", Type: IntType, InternalType: "int(11)"}, AutoIncrement: true, DataTypeLenght: 10}
+	id.Extras = id
+	if oracle, _ := ToOracleColumn(id); !oracle.AutoIncrement || oracle.IdentityGeneration != "BY DEFAULT" || oracle.InternalType != "NUMBER" {
+		t.Errorf("Expected an identity column. Got %+v", oracle)
+	}
+}
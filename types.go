@@ -1,6 +1,10 @@
 package ddl
 
-import "database/sql"
+import (
+	"database/sql"
+	"sort"
+	"strings"
+)
 
 // DataType is a generic data type of a db type
 type DataType string
@@ -12,6 +16,33 @@ const (
 	DateType    DataType = "Date"
 	GeoType     DataType = "Geo"
 	UnknownType DataType = "Unknown"
+
+	// Large character objects like "CLOB" or "LONGTEXT"
+	TextType DataType = "Text"
+	// Boolean values. MariaDB stores them as "tinyint(1)"
+	BoolType DataType = "Boolean"
+	// Binary data like "BLOB" or "RAW"
+	BinaryType DataType = "Binary"
+	// JSON documents
+	JsonType DataType = "Json"
+	// Time of a day without a date
+	TimeType DataType = "Time"
+	// Date and time with a time zone
+	TimestampTzType DataType = "TimestampTz"
+	// Fixed point numbers with a precision and scale
+	DecimalType DataType = "Decimal"
+	// One value of a list of allowed values
+	EnumType DataType = "Enum"
+	// Any number of values of a list of allowed values
+	SetType DataType = "Set"
+	// Universally unique identifier
+	UuidType DataType = "UUID"
+	// Bit field with a fixed number of bits
+	BitType DataType = "Bit"
+	// Year with four digits
+	YearType DataType = "Year"
+	// Period of time like "INTERVAL DAY TO SECOND"
+	IntervalType DataType = "Interval"
 )
 
 // Table represents a logical table on the database
@@ -25,6 +56,340 @@ type Table struct {
 
 	// List of columns the table has
 	Columns []*Column
+
+	// List of indexes the table has
+	Indexes []*Index
+
+	// Primary key of the table. It's nil if the table has no primary key
+	PrimaryKey *PrimaryKey
+
+	// List of foreign keys that reference other tables
+	ForeignKeys []*ForeignKey
+
+	// List of check constraints sorted by their name
+	Checks []*CheckConstraint
+
+	// Kind of the table like a normal table or a view.
+	// It's empty if the database system doesn't provide this information
+	Kind TableKind
+
+	// SQL query of a view. It's empty for normal tables
+	ViewDefinition string
+
+	// Comment of the table
+	Comment string
+
+	// MariaDB: storage engine of the table like "InnoDB"
+	Engine string
+
+	// Oracle: tablespace the table is stored in
+	Tablespace string
+
+	// Default character set of the columns
+	Charset string
+
+	// Default collation of the columns
+	Collation string
+
+	// Time the table was created
+	Created sql.NullTime
+
+	// Estimated number of rows based on the statistics of the database.
+	// It's not valid if no statistics are available like for views
+	EstimatedRows sql.NullInt64
+}
+
+// applyInfo sets the metadata of the table like the kind or comment from
+// the provided table
+func (t *Table) applyInfo(info *Table) {
+	t.Kind = info.Kind
+	t.ViewDefinition = info.ViewDefinition
+	t.Comment = info.Comment
+	t.Engine = info.Engine
+	t.Tablespace = info.Tablespace
+	t.Charset = info.Charset
+	t.Collation = info.Collation
+	t.Created = info.Created
+	t.EstimatedRows = info.EstimatedRows
+}
+
+// listTables returns the tables with the metadata sorted by their name
+func listTables(schema string, infos map[string]*Table) []*Table {
+	rtc := make([]*Table, 0, len(infos))
+	for _, info := range infos {
+		info.Schema = schema
+		rtc = append(rtc, info)
+	}
+	sort.Slice(rtc, func(i, j int) bool { return rtc[i].Name < rtc[j].Name })
+
+	return rtc
+}
+
+// expressionColumns returns the columns of the table that are used within the
+// SQL expression in the order of the table columns
+func (t *Table) expressionColumns(expression string, dialect sqlDialect) []string {
+	tokens, err := lexSql(expression, dialect)
+	if err != nil {
+		return nil
+	}
+
+	used := map[string]bool{}
+	for i, tok := range tokens {
+		// Ignore function names like "LENGTH(col)"
+		isFunction := tok.typ == tokenIdent && tokens[i+1].typ == tokenSymbol && tokens[i+1].value == "("
+		if (tok.typ == tokenIdent || tok.typ == tokenQuotedIdent) && !isFunction {
+			used[strings.ToLower(tok.value)] = true
+		}
+	}
+
+	rtc := []string{}
+	for _, c := range t.Columns {
+		if used[strings.ToLower(c.Name)] {
+			rtc = append(rtc, c.Name)
+		}
+	}
+
+	return rtc
+}
+
+// TableKind is the kind of a table
+type TableKind string
+
+const (
+	// Normal table that stores data
+	TableKindTable TableKind = "TABLE"
+	// View that is defined by a query
+	TableKindView TableKind = "VIEW"
+	// View that stores the result of its query
+	TableKindMaterializedView TableKind = "MATERIALIZED VIEW"
+	// View provided by the database system like the "information_schema"
+	TableKindSystemView TableKind = "SYSTEM VIEW"
+)
+
+// IsView returns weather the kind is any type of view
+func (k TableKind) IsView() bool {
+	return k == TableKindView || k == TableKindMaterializedView || k == TableKindSystemView
+}
+
+// GetColumn returns the column with the name or nil if it does not exist
+func (t *Table) GetColumn(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// GetForeignKey returns the first foreign key the column belongs to
+// or nil if the column doesn't reference another table
+func (t *Table) GetForeignKey(column string) *ForeignKey {
+	for _, fk := range t.ForeignKeys {
+		for _, c := range fk.Columns {
+			if c == column {
+				return fk
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyForeignKeys sets the foreign key reference of all columns based on
+// the foreign keys of the table
+func (t *Table) applyForeignKeys() {
+	for _, c := range t.Columns {
+		c.ForeignKey = false
+		c.ForeignKeyColumn = ForeignColumn{}
+
+		fk := t.GetForeignKey(c.Name)
+		if fk == nil {
+			continue
+		}
+		for i, name := range fk.Columns {
+			if name == c.Name && i < len(fk.ReferencedColumns) {
+				c.ForeignKey = true
+				c.ForeignKeyColumn = ForeignColumn{
+					Name:   fk.ReferencedTable,
+					Schema: fk.ReferencedSchema,
+					Column: fk.ReferencedColumns[i],
+				}
+			}
+		}
+	}
+}
+
+// PrimaryKey constraint of a table
+type PrimaryKey struct {
+
+	// Name of the constraint
+	Name string
+
+	// Ordered list of the columns
+	Columns []string
+}
+
+// ForeignKey constraint of a table that references another table
+type ForeignKey struct {
+
+	// Name of the constraint
+	Name string
+
+	// Ordered list of the columns of this table
+	Columns []string
+
+	// Schema and name of the referenced table
+	ReferencedSchema string
+	ReferencedTable  string
+
+	// Referenced columns in the same order as "Columns"
+	ReferencedColumns []string
+
+	// Referential actions like "CASCADE", "SET NULL", "RESTRICT" or "NO ACTION".
+	// The action is empty if it's not supported by the database system
+	OnDelete string
+	OnUpdate string
+}
+
+// CheckConstraint is a constraint that validates the values of a row with an expression
+type CheckConstraint struct {
+
+	// Name of the constraint
+	Name string
+
+	// SQL expression that has to be true for every row
+	Expression string
+
+	// Columns that are used within the expression
+	Columns []string
+}
+
+// Sequence is a database object that generates unique numbers
+type Sequence struct {
+
+	// Schema the sequence belongs to
+	Schema string
+
+	// Name of the sequence
+	Name string
+
+	// First value of the sequence. Oracle doesn't store the initial value of an existing
+	// sequence, so the next value that is not cached is returned instead
+	Start int64
+
+	// Value that is added to the last number to generate the next one
+	Increment int64
+
+	// Weather the sequence starts again after reaching its limit
+	Cycle bool
+}
+
+// RoutineKind is the type of a stored routine
+type RoutineKind string
+
+const (
+	RoutineProcedure RoutineKind = "PROCEDURE"
+	RoutineFunction  RoutineKind = "FUNCTION"
+)
+
+// ParameterDirection defines weather a parameter passes a value to a routine,
+// returns a value or both
+type ParameterDirection string
+
+const (
+	ParameterIn    ParameterDirection = "IN"
+	ParameterOut   ParameterDirection = "OUT"
+	ParameterInOut ParameterDirection = "INOUT"
+)
+
+// Routine is a stored procedure or function
+type Routine struct {
+
+	// Schema the routine belongs to
+	Schema string
+
+	// Name of the oracle package that contains the routine. It's empty for
+	// standalone routines
+	Package string
+
+	// Name of the routine
+	Name string
+
+	// Weather the routine is a procedure or a function
+	Kind RoutineKind
+
+	// Number of the overloaded routine with the same name within a package
+	// starting with 1. It's 0 if the routine is not overloaded
+	Overload int
+
+	// Ordered parameters of the routine
+	Parameters []*Parameter
+
+	// Return value of a function. It's nil for procedures
+	Return *Parameter
+}
+
+// Parameter of a stored routine
+type Parameter struct {
+
+	// Name of the parameter. It's empty for the return value of a function
+	Name string
+
+	// Weather the parameter is an input, output or both
+	Direction ParameterDirection
+
+	// Generic data type definition
+	Type DataType
+
+	// Internal name of the data type
+	InternalType string
+
+	// Weather the parameter has a default value and can be omitted
+	HasDefault bool
+}
+
+// FullName returns the qualified name of the routine like "schema.package.name"
+func (r *Routine) FullName() string {
+	if r.Package != "" {
+		return r.Schema + "." + r.Package + "." + r.Name
+	}
+	return r.Schema + "." + r.Name
+}
+
+// sortRoutines sorts the routines by their package, name and overload
+func sortRoutines(routines []*Routine) {
+	sort.SliceStable(routines, func(i, j int) bool {
+		a, b := routines[i], routines[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Overload < b.Overload
+	})
+}
+
+// Index of a table
+type Index struct {
+
+	// Name of the index
+	Name string
+
+	// Ordered list of the indexed columns. For function based indexes
+	// the expression is contained instead of a column name
+	Columns []string
+
+	// Weather the index allows only unique values
+	Unique bool
+
+	// Database specific type of the index like BTREE, HASH, FULLTEXT or BITMAP
+	Type string
+
+	// Condition of a partial index. It's empty if the index covers all rows
+	// or the database system does not support partial indexes
+	Predicate string
 }
 
 // Column of a table.
@@ -41,6 +406,11 @@ type Column struct {
 	// Internal name of the data type (with lenght)
 	InternalType string
 
+	// Total number of digits and the number of digits on the right side
+	// of the dot. They are only set for exact decimal numbers ("DecimalType")
+	NumericPrecision int
+	NumericScale     int
+
 	// Weather this column is a primary key of the table
 	PrimaryKey bool
 
@@ -55,11 +425,29 @@ type Column struct {
 	// A default value of the column
 	DefaultValue sql.NullString
 
+	// Expression of a generated (computed) column. It's empty for
+	// normal columns
+	GenerationExpression string
+
+	// Weather the value of a generated column is stored on writes. Otherwise,
+	// it's calculated on every read (virtual)
+	GenerationStored bool
+
+	// Weather the column is invisible and not returned by "SELECT *"
+	Invisible bool
+
 	// Comment of this column
 	Comment string
 
-	// Extras
-	Extras Columner
+	// Extras of the database system. They are serialized with the name of the
+	// database system, because the extras embed this column
+	Extras Columner `json:"-" yaml:"-"`
+}
+
+// IsGenerated returns weather the value of the column is calculated by
+// the database system and cannot be written
+func (c *Column) IsGenerated() bool {
+	return c.GenerationExpression != ""
 }
 
 // Columner returns additonal informations to a column that are specific for a SQL system
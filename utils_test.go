@@ -9,9 +9,18 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
-func RequireEnvString(name string, t *testing.T) string {
+// ignoreServerDefaults ignores the metadata of tables and columns that depends on
+// the configuration of the database server like the storage engine or charset
+var ignoreServerDefaults = cmp.Options{
+	cmpopts.IgnoreFields(Table{}, "Engine", "Tablespace", "Charset", "Collation", "Created", "EstimatedRows"),
+	cmpopts.IgnoreFields(MariadbColumn{}, "Charset", "Collation"),
+}
+
+func RequireEnvString(name string, t testing.TB) string {
 	if strVal, isSet := os.LookupEnv(name); isSet {
 		return strVal
 	} else {
@@ -20,7 +29,7 @@ func RequireEnvString(name string, t *testing.T) string {
 	}
 }
 
-func RequireEnvInt(name string, t *testing.T) int {
+func RequireEnvInt(name string, t testing.TB) int {
 	if strVal, isSet := os.LookupEnv(name); isSet {
 		if intVal, err := strconv.Atoi(strVal); err != nil {
 			t.Fatalf("Invalid number value given for the environment variable %q: %s", name, strVal)